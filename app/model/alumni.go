@@ -20,4 +20,5 @@ type Alumni struct {
 	UpdatedAt  time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
 	DeletedAt  *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 	UserID     primitive.ObjectID `bson:"user_id" json:"user_id" example:"6710c5c2f8f4a385cd123456"`
+	Version    int64              `bson:"version" json:"version" example:"3"` // naik tiap perubahan, dikirim sebagai ETag
 }
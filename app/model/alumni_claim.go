@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status klaim alumni
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusApproved = "approved"
+	ClaimStatusRejected = "rejected"
+)
+
+// ✅ Permintaan user untuk menghubungkan akunnya dengan data alumni (koleksi "alumni_claims").
+// Setelah disetujui admin, users.alumni_id diisi dan dipakai untuk semua cek kepemilikan.
+type AlumniClaim struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	AlumniID   primitive.ObjectID  `bson:"alumni_id" json:"alumni_id"`
+	Status     string              `bson:"status" json:"status"`
+	Message    string              `bson:"message,omitempty" json:"message,omitempty"`         // keterangan dari user
+	ReviewNote string              `bson:"review_note,omitempty" json:"review_note,omitempty"` // catatan admin
+	ReviewedBy *primitive.ObjectID `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time          `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// Request body user saat mengajukan klaim
+type CreateAlumniClaimRequest struct {
+	AlumniID string `json:"alumni_id" example:"6710c5c2f8f4a385cd123456"`
+	Message  string `json:"message" example:"Saya Budi, NIM 2020101234"`
+}
+
+// Request body admin saat menyetujui / menolak klaim
+type ReviewAlumniClaimRequest struct {
+	Note string `json:"note" example:"NIM dan email cocok"`
+}
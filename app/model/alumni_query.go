@@ -0,0 +1,19 @@
+package model
+
+// Batas limit per halaman list alumni (termasuk laporan status alumni)
+const MaxAlumniLimit = 100
+
+// Field yang boleh dipakai untuk sortBy di GET /alumni
+var AlumniSortFields = []string{"nama", "nim", "jurusan", "angkatan", "tahun_lulus", "created_at", "updated_at"}
+
+// Parameter list alumni: pencarian bebas, filter persis, urutan, dan paginasi
+type AlumniQuery struct {
+	Search     string // dicari di nama, NIM, dan email
+	Jurusan    string
+	Angkatan   int // 0 berarti tanpa filter
+	TahunLulus int // 0 berarti tanpa filter
+	SortBy     string
+	Order      string // ASC atau DESC
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,65 @@
+package model
+
+import "strings"
+
+// ================== CREATE REQUEST ==================
+// Field sistem (id, user_id, created_at, deleted_at) sengaja tidak ada agar tidak bisa diisi client
+type CreateAlumniReq struct {
+	NIM        string `json:"nim" example:"2020101234"`
+	Nama       string `json:"nama" example:"Budi Santoso"`
+	Jurusan    string `json:"jurusan" example:"Teknik Informatika"`
+	Angkatan   int    `json:"angkatan" example:"2020"`
+	TahunLulus int    `json:"tahun_lulus" example:"2024"`
+	Email      string `json:"email" example:"budi@example.com"`
+	NoTelepon  string `json:"no_telepon,omitempty" example:"081234567890"`
+	Alamat     string `json:"alamat,omitempty" example:"Jl. Mawar No. 5"`
+}
+
+// ================== UPDATE REQUEST ==================
+// PUT mengganti seluruh data alumni, jadi field wajib sama dengan create
+type UpdateAlumniReq struct {
+	NIM        string `json:"nim" example:"2020101234"`
+	Nama       string `json:"nama" example:"Budi Santoso"`
+	Jurusan    string `json:"jurusan" example:"Teknik Informatika"`
+	Angkatan   int    `json:"angkatan" example:"2020"`
+	TahunLulus int    `json:"tahun_lulus" example:"2024"`
+	Email      string `json:"email" example:"budi@example.com"`
+	NoTelepon  string `json:"no_telepon,omitempty" example:"081234567890"`
+	Alamat     string `json:"alamat,omitempty" example:"Jl. Mawar No. 5"`
+}
+
+// Field alumni yang bisa diubah lewat PATCH /alumni/:id (JSON Merge Patch)
+var PatchableAlumniFields = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "no_telepon", "alamat"}
+
+// NewUpdateAlumniReq menyalin data alumni ke bentuk request, dipakai sebagai dasar merge patch
+func NewUpdateAlumniReq(a Alumni) UpdateAlumniReq {
+	return UpdateAlumniReq{
+		NIM:        a.NIM,
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+		NoTelepon:  a.NoTelepon,
+		Alamat:     a.Alamat,
+	}
+}
+
+// ToAlumni menyalin field request (sudah dirapikan) ke model Alumni
+func (r CreateAlumniReq) ToAlumni() Alumni {
+	return Alumni{
+		NIM:        strings.TrimSpace(r.NIM),
+		Nama:       strings.TrimSpace(r.Nama),
+		Jurusan:    strings.TrimSpace(r.Jurusan),
+		Angkatan:   r.Angkatan,
+		TahunLulus: r.TahunLulus,
+		Email:      strings.ToLower(strings.TrimSpace(r.Email)),
+		NoTelepon:  strings.TrimSpace(r.NoTelepon),
+		Alamat:     strings.TrimSpace(r.Alamat),
+	}
+}
+
+// ToAlumni menyalin field request (sudah dirapikan) ke model Alumni
+func (r UpdateAlumniReq) ToAlumni() Alumni {
+	return CreateAlumniReq(r).ToAlumni()
+}
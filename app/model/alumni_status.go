@@ -7,14 +7,27 @@ import (
 )
 
 type AlumniPekerjaanReport struct {
-	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Nama              string             `bson:"nama" json:"nama"`
-	Jurusan           string             `bson:"jurusan" json:"jurusan"`
-	Angkatan          int                `bson:"angkatan" json:"angkatan"`
-	BidangIndustri    string             `bson:"bidang_industri" json:"bidang_industri"`
-	NamaPerusahaan    string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
-	PosisiJabatan     string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
-	TanggalMulaiKerja time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
-	GajiRange         string             `bson:"gaji_range" json:"gaji_range"`
-	LebihDariSatuTahun bool              `bson:"lebih_dari_satu_tahun" json:"lebih_dari_satu_tahun"`
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Nama               string             `bson:"nama" json:"nama"`
+	Jurusan            string             `bson:"jurusan" json:"jurusan"`
+	Angkatan           int                `bson:"angkatan" json:"angkatan"`
+	BidangIndustri     string             `bson:"bidang_industri" json:"bidang_industri"`
+	NamaPerusahaan     string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
+	PosisiJabatan      string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
+	TanggalMulaiKerja  time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
+	GajiRange          string             `bson:"gaji_range" json:"gaji_range"`
+	LebihDariSatuTahun bool               `bson:"lebih_dari_satu_tahun" json:"lebih_dari_satu_tahun"`
+}
+
+// Parameter laporan status: aktif, tidak-aktif, atau selain itu berarti semua
+type AlumniStatusQuery struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+// Ringkasan laporan status, dihitung di database atas seluruh hasil (bukan per halaman)
+type AlumniStatusCounts struct {
+	Total              int `bson:"total" json:"total"`
+	LebihDariSatuTahun int `bson:"lebih_dari_satu_tahun" json:"lebih_dari_satu_tahun"`
 }
@@ -0,0 +1,40 @@
+package model
+
+// Pengelompokan yang didukung endpoint analitik (kosong berarti keseluruhan)
+var AnalyticsGroupFields = []string{"angkatan", "jurusan"}
+
+// Field pekerjaan yang bisa dilihat sebarannya
+var AnalyticsDistributionFields = []string{"bidang_industri", "lokasi_kerja"}
+
+// Tingkat keterserapan kerja per kelompok alumni
+type EmploymentRate struct {
+	Kelompok      interface{} `bson:"_id" json:"kelompok"` // angkatan / jurusan, null untuk keseluruhan
+	JumlahAlumni  int         `bson:"jumlah_alumni" json:"jumlah_alumni"`
+	Bekerja       int         `bson:"bekerja" json:"bekerja"`               // punya pekerjaan berstatus aktif
+	PernahBekerja int         `bson:"pernah_bekerja" json:"pernah_bekerja"` // punya riwayat pekerjaan apa pun
+	Persentase    float64     `bson:"persentase" json:"persentase"`         // bekerja / jumlah_alumni * 100
+}
+
+// Masa tunggu dari tahun lulus sampai pekerjaan pertama, dalam bulan
+type WaitingTime struct {
+	Kelompok      interface{} `bson:"_id" json:"kelompok"`
+	JumlahAlumni  int         `bson:"jumlah_alumni" json:"jumlah_alumni"`
+	RataRataBulan float64     `bson:"rata_rata_bulan" json:"rata_rata_bulan"`
+	MinBulan      int         `bson:"min_bulan" json:"min_bulan"`
+	MaxBulan      int         `bson:"max_bulan" json:"max_bulan"`
+}
+
+// Sebaran pekerjaan untuk satu nilai bidang_industri / lokasi_kerja
+type DistributionItem struct {
+	Nilai           string  `bson:"_id" json:"nilai"` // kosong berarti tidak diisi
+	JumlahPekerjaan int     `bson:"jumlah_pekerjaan" json:"jumlah_pekerjaan"`
+	JumlahAlumni    int     `bson:"jumlah_alumni" json:"jumlah_alumni"`
+	Persentase      float64 `bson:"-" json:"persentase"` // dari seluruh pekerjaan
+}
+
+// Satu batang histogram gaji_range
+type SalaryBucket struct {
+	GajiRange  string  `bson:"_id" json:"gaji_range"` // kosong berarti tidak diisi
+	Jumlah     int     `bson:"jumlah" json:"jumlah"`
+	Persentase float64 `bson:"-" json:"persentase"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PrincipalService role yang dipasang di c.Locals untuk request yang
+// diautentikasi dengan API key (bukan user login)
+const PrincipalService = "service"
+
+// Batas masa berlaku API key
+const (
+	DefaultAPIKeyDays = 90
+	MaxAPIKeyDays     = 365
+)
+
+// ✅ API key untuk integrasi antar sistem, disimpan di koleksi "api_keys".
+// Key asli hanya ditampilkan sekali saat dibuat; yang disimpan hanya hash-nya.
+type APIKey struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name" example:"data-warehouse"`
+	Prefix      string             `bson:"prefix" json:"prefix" example:"ak_1a2b3c4d"` // untuk mengenali key tanpa membuka isinya
+	KeyHash     string             `bson:"key_hash" json:"-"`
+	Permissions []string           `bson:"permissions" json:"permissions"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt   time.Time          `bson:"expires_at" json:"expires_at"`
+	LastUsedAt  *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// IsActive true jika key belum dicabut dan belum expired
+func (k APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// Request body admin saat membuat API key
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" example:"data-warehouse"`
+	Permissions   []string `json:"permissions" example:"alumni:read,pekerjaan:read"`
+	ExpiresInDays int      `json:"expires_in_days" example:"90"` // 0 = default 90 hari, maksimal 365
+}
@@ -2,10 +2,21 @@ package model
 
 import "github.com/golang-jwt/jwt/v5"
 
-// JWTClaims menyimpan payload di dalam token JWT
+// JWTClaims menyimpan payload di dalam token JWT.
+// jti (ID unik token) ada di RegisteredClaims.ID dan dipakai untuk revocation list.
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	AlumniID  string `json:"alumni_id,omitempty"` // kosong jika akun belum terhubung ke data alumni
+	SessionID string `json:"sid,omitempty"`       // sesi login (lihat session.go), kosong untuk token lama
+	Purpose   string `json:"purpose,omitempty"`   // kosong = access token; selain itu token challenge 2FA
 	jwt.RegisteredClaims
 }
+
+// Purpose token challenge login 2FA. Token ini hanya bisa ditukar di /login/2fa,
+// tidak pernah diterima sebagai access token.
+const (
+	ChallengePurposeTwoFactor      = "2fa"       // user sudah mengaktifkan 2FA, tinggal masukkan kode
+	ChallengePurposeTwoFactorSetup = "2fa_setup" // 2FA diwajibkan tapi belum diaktifkan
+)
@@ -8,6 +8,9 @@ type LoginRequest struct {
 
 // Response body setelah login sukses
 type LoginResponse struct {
-	User  User   `json:"user"`
-	Token string `json:"token"`
+	User         User   `json:"user"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// hanya terisi sekali, saat 2FA baru diaktifkan lewat login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Jenis counter percobaan login
+const (
+	AttemptKindAccount = "account" // akun yang ditemukan, subject = ID user
+	AttemptKindUser    = "user"    // username/email yang tidak cocok dengan akun mana pun
+	AttemptKindIP      = "ip"
+)
+
+// LoginAttempt menyimpan counter login gagal per akun / per IP (koleksi "login_attempts").
+// _id berbentuk "<kind>:<subject>", misal "account:<id user>", "user:budi" atau "ip:10.0.0.1".
+type LoginAttempt struct {
+	Key           string     `bson:"_id" json:"key"`
+	Kind          string     `bson:"kind" json:"kind"`
+	Subject       string     `bson:"subject" json:"subject"`
+	Failures      int        `bson:"failures" json:"failures"`
+	LastFailureAt time.Time  `bson:"last_failure_at" json:"last_failure_at"`
+	LockedUntil   *time.Time `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	ExpiresAt     time.Time  `bson:"expires_at" json:"expires_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// Request body ganti password (user yang sedang login)
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// Request body lupa password
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"budi@example.com"`
+}
+
+// Request body reset password dengan token dari email
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
@@ -20,6 +20,7 @@ type Pekerjaan struct {
 	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
 	DeletedAt           *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	Version             int64              `bson:"version" json:"version"` // naik tiap perubahan, dikirim sebagai ETag
 }
 type PekerjaanTrash struct {
 	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// Field yang boleh dipakai untuk sortBy di GET /pekerjaan
+var PekerjaanSortFields = []string{
+	"nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja",
+	"tanggal_mulai_kerja", "tanggal_selesai_kerja", "created_at", "updated_at",
+}
+
+// Cara mencocokkan search: contains (default), prefix (awalan), exact (sama persis, tanpa beda huruf besar/kecil)
+const (
+	SearchContains = "contains"
+	SearchPrefix   = "prefix"
+	SearchExact    = "exact"
+)
+
+var PekerjaanSearchModes = []string{SearchContains, SearchPrefix, SearchExact}
+
+// Batas parameter list pekerjaan
+const (
+	MaxPekerjaanLimit  = 100
+	MaxPekerjaanSearch = 100
+)
+
+// Parameter list pekerjaan yang sudah divalidasi: search selalu dianggap teks biasa, bukan regex
+type PekerjaanQuery struct {
+	Search     string // dicari di perusahaan, posisi, dan bidang industri
+	SearchMode string // salah satu PekerjaanSearchModes
+	SortBy     string // salah satu PekerjaanSortFields
+	Order      string // ASC atau DESC
+	Limit      int
+	Offset     int
+	Filters    PekerjaanFilters
+}
+
+// Filter terstruktur list pekerjaan; nilai kosong berarti tanpa filter.
+// Rentang tanggal inklusif di kedua ujung (per hari).
+type PekerjaanFilters struct {
+	StatusPekerjaan string     `json:"status_pekerjaan,omitempty"`
+	BidangIndustri  string     `json:"bidang_industri,omitempty"`
+	LokasiKerja     string     `json:"lokasi_kerja,omitempty"`
+	MulaiDari       *time.Time `json:"mulai_dari,omitempty"`
+	MulaiSampai     *time.Time `json:"mulai_sampai,omitempty"`
+	SelesaiDari     *time.Time `json:"selesai_dari,omitempty"`
+	SelesaiSampai   *time.Time `json:"selesai_sampai,omitempty"`
+
+	// filter sisi alumni, butuh join ke koleksi alumni
+	Jurusan  string `json:"jurusan,omitempty"`
+	Angkatan int    `json:"angkatan,omitempty"`
+}
+
+// ByAlumni: true kalau ada filter yang membutuhkan data alumni
+func (f PekerjaanFilters) ByAlumni() bool {
+	return f.Jurusan != "" || f.Angkatan != 0
+}
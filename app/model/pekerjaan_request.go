@@ -28,3 +28,29 @@ type UpdatePekerjaanReq struct {
 	StatusPekerjaan     string  `json:"status_pekerjaan" bson:"status_pekerjaan"`
 	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan,omitempty" bson:"deskripsi_pekerjaan,omitempty"`
 }
+
+// Field pekerjaan yang bisa diubah lewat PATCH /pekerjaan/:id (JSON Merge Patch)
+var PatchablePekerjaanFields = []string{
+	"nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "gaji_range",
+	"tanggal_mulai_kerja", "tanggal_selesai_kerja", "status_pekerjaan", "deskripsi_pekerjaan",
+}
+
+// NewUpdatePekerjaanReq menyalin data pekerjaan ke bentuk request (tanggal YYYY-MM-DD), dipakai sebagai dasar merge patch
+func NewUpdatePekerjaanReq(p Pekerjaan) UpdatePekerjaanReq {
+	req := UpdatePekerjaanReq{
+		NamaPerusahaan:     p.NamaPerusahaan,
+		PosisiJabatan:      p.PosisiJabatan,
+		BidangIndustri:     p.BidangIndustri,
+		LokasiKerja:        p.LokasiKerja,
+		GajiRange:          p.GajiRange,
+		StatusPekerjaan:    p.StatusPekerjaan,
+		DeskripsiPekerjaan: p.DeskripsiPekerjaan,
+	}
+	if p.TanggalMulaiKerja != nil {
+		req.TanggalMulaiKerja = p.TanggalMulaiKerja.Format("2006-01-02")
+	}
+	if p.TanggalSelesaiKerja != nil {
+		req.TanggalSelesaiKerja = p.TanggalSelesaiKerja.Format("2006-01-02")
+	}
+	return req
+}
@@ -0,0 +1,43 @@
+package model
+
+// Status pekerjaan alumni
+const (
+	StatusPekerjaanAktif    = "aktif"
+	StatusPekerjaanSelesai  = "selesai"
+	StatusPekerjaanResigned = "resigned"
+)
+
+// Semua status_pekerjaan yang diterima saat create / update
+var StatusPekerjaanList = []string{StatusPekerjaanAktif, StatusPekerjaanSelesai, StatusPekerjaanResigned}
+
+// Perpindahan status yang diizinkan. Pekerjaan yang sudah berakhir tidak bisa aktif lagi
+// (catat sebagai pekerjaan baru); selesai <-> resigned boleh untuk koreksi alasan berakhir.
+var statusPekerjaanTransitions = map[string][]string{
+	StatusPekerjaanAktif:    {StatusPekerjaanSelesai, StatusPekerjaanResigned},
+	StatusPekerjaanSelesai:  {StatusPekerjaanResigned},
+	StatusPekerjaanResigned: {StatusPekerjaanSelesai},
+}
+
+// IsValidStatusPekerjaan true jika status termasuk StatusPekerjaanList
+func IsValidStatusPekerjaan(status string) bool {
+	_, ok := statusPekerjaanTransitions[status]
+	return ok
+}
+
+// CanTransitionStatusPekerjaan true jika status boleh berubah dari -> to.
+// Status lama di luar daftar (data lama yang masih teks bebas) boleh diganti ke status valid mana pun.
+func CanTransitionStatusPekerjaan(from, to string) bool {
+	if from == to {
+		return true
+	}
+	next, known := statusPekerjaanTransitions[from]
+	if !known {
+		return true
+	}
+	for _, s := range next {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
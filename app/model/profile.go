@@ -0,0 +1,21 @@
+package model
+
+// Field akun yang boleh diubah sendiri oleh pemiliknya lewat PUT /me
+var SelfEditableUserFields = []string{"email"}
+
+// Field data alumni yang boleh diubah sendiri oleh pemiliknya lewat PUT /me/alumni.
+// NIM, angkatan, dan field akademik lain tetap dikelola admin.
+var SelfEditableAlumniFields = []string{"email", "no_telepon", "alamat"}
+
+// Request body PUT /me. Ganti email wajib menyertakan password saat ini.
+type UpdateMeRequest struct {
+	Email           *string `json:"email,omitempty" example:"budi@example.com"`
+	CurrentPassword string  `json:"current_password,omitempty" example:"rahasia123"`
+}
+
+// Request body PUT /me/alumni (field yang tidak dikirim tidak diubah)
+type UpdateMyAlumniRequest struct {
+	Email     *string `json:"email,omitempty" example:"budi@example.com"`
+	NoTelepon *string `json:"no_telepon,omitempty" example:"081234567890"`
+	Alamat    *string `json:"alamat,omitempty" example:"Jl. Mawar No. 5"`
+}
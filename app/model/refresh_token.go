@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken disimpan di koleksi "refresh_tokens".
+// Setiap login membuka family baru; setiap rotasi menambah token baru di family yang sama.
+type RefreshToken struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	FamilyID   primitive.ObjectID  `bson:"family_id" json:"family_id"`
+	TokenHash  string              `bson:"token_hash" json:"-"`
+	ExpiresAt  time.Time           `bson:"expires_at" json:"expires_at"`
+	UsedAt     *time.Time          `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	RevokedAt  *time.Time          `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	ReplacedBy *primitive.ObjectID `bson:"replaced_by,omitempty" json:"replaced_by,omitempty"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// Request body untuk rotasi refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,13 @@
+package model
+
+// Request body saat register user baru
+type RegisterRequest struct {
+	Username string `json:"username" example:"budi"`
+	Email    string `json:"email" example:"budi@example.com"`
+	Password string `json:"password" example:"rahasia123"`
+}
+
+// Request body untuk verifikasi email
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
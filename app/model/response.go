@@ -8,4 +8,17 @@ type MetaInfo struct {
 	SortBy string `bson:"sort_by" json:"sortBy"`
 	Order  string `bson:"order" json:"order"`
 	Search string `bson:"search" json:"search"`
+	// filter terstruktur yang diterapkan (hanya untuk list yang mendukungnya)
+	Filters interface{} `bson:"filters,omitempty" json:"filters,omitempty"`
+}
+
+// Meta untuk mode cursor (keyset): tanpa total/halaman, lanjutkan dengan next_cursor
+type CursorMeta struct {
+	Limit      int         `json:"limit"`
+	SortBy     string      `json:"sortBy"`
+	Order      string      `json:"order"`
+	Search     string      `json:"search,omitempty"`
+	Filters    interface{} `json:"filters,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
 }
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RevokedToken adalah entri revocation list JWT (koleksi "revoked_tokens").
+// Dokumen dihapus otomatis oleh TTL index setelah token aslinya expired.
+//
+// Selain per jti, entri dengan _id "user:<id>" dan NotBefore mencabut semua
+// token user tersebut yang diterbitkan sebelum NotBefore (misal setelah reset password).
+type RevokedToken struct {
+	JTI       string             `bson:"_id" json:"jti"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	NotBefore *time.Time         `bson:"not_before,omitempty" json:"not_before,omitempty"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt time.Time          `bson:"revoked_at" json:"revoked_at"`
+}
+
+// Request body logout (refresh token opsional, ikut dicabut jika dikirim)
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
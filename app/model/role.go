@@ -0,0 +1,155 @@
+package model
+
+import "time"
+
+// Daftar permission yang dikenal sistem. Route mendeklarasikan permission yang dibutuhkan
+// (middleware.Require), role hanya memetakan nama role -> kumpulan permission.
+const (
+	PermAlumniRead       = "alumni:read"
+	PermAlumniWrite      = "alumni:write"
+	PermAlumniDelete     = "alumni:delete"
+	PermAlumniRestore    = "alumni:restore" // lihat trash & restore
+	PermAlumniHardDelete = "alumni:hard_delete"
+
+	PermPekerjaanRead       = "pekerjaan:read"
+	PermPekerjaanWrite      = "pekerjaan:write"
+	PermPekerjaanDelete     = "pekerjaan:delete"     // soft delete pekerjaan siapa pun
+	PermPekerjaanDeleteOwn  = "pekerjaan:delete_own" // soft delete pekerjaan alumni milik sendiri
+	PermPekerjaanRestore    = "pekerjaan:restore"    // lihat trash & restore
+	PermPekerjaanHardDelete = "pekerjaan:hard_delete"
+
+	PermFilesRead      = "files:read"
+	PermFilesReadAll   = "files:read_all"
+	PermFilesUploadOwn = "files:upload_own"
+	PermFilesUploadAny = "files:upload_any" // upload untuk alumni mana pun (wajib isi alumni_id)
+	PermFilesDeleteOwn = "files:delete_own"
+	PermFilesDeleteAny = "files:delete_any"
+
+	PermAlumniClaimsCreate = "alumni_claims:create"
+	PermAlumniClaimsReview = "alumni_claims:review"
+
+	PermUsersManage    = "users:manage"
+	PermRolesManage    = "roles:manage"
+	PermLockoutsManage = "lockouts:manage"
+	PermAPIKeysManage  = "api_keys:manage"
+
+	PermAnalyticsRead = "analytics:read"
+
+	PermSurveysManage  = "surveys:manage"  // buat, terbitkan, ekspor kuesioner
+	PermSurveysRespond = "surveys:respond" // isi kuesioner sebagai alumni
+)
+
+// PermissionInfo deskripsi satu permission (untuk endpoint GET /permissions)
+type PermissionInfo struct {
+	Name        string `json:"name" example:"alumni:write"`
+	Description string `json:"description" example:"Tambah & ubah data alumni"`
+}
+
+// Permissions registry seluruh permission, urut sesuai tampilan
+var Permissions = []PermissionInfo{
+	{PermAlumniRead, "Lihat data alumni"},
+	{PermAlumniWrite, "Tambah & ubah data alumni"},
+	{PermAlumniDelete, "Hapus (soft delete) data alumni"},
+	{PermAlumniRestore, "Lihat trash & restore data alumni"},
+	{PermAlumniHardDelete, "Hapus permanen data alumni"},
+	{PermPekerjaanRead, "Lihat data pekerjaan"},
+	{PermPekerjaanWrite, "Tambah & ubah data pekerjaan"},
+	{PermPekerjaanDelete, "Hapus (soft delete) pekerjaan siapa pun"},
+	{PermPekerjaanDeleteOwn, "Hapus (soft delete) pekerjaan milik sendiri"},
+	{PermPekerjaanRestore, "Lihat trash & restore pekerjaan"},
+	{PermPekerjaanHardDelete, "Hapus permanen pekerjaan"},
+	{PermFilesRead, "Lihat detail file"},
+	{PermFilesReadAll, "Lihat daftar semua file"},
+	{PermFilesUploadOwn, "Upload file untuk alumni milik sendiri"},
+	{PermFilesUploadAny, "Upload file untuk alumni mana pun"},
+	{PermFilesDeleteOwn, "Hapus file milik sendiri"},
+	{PermFilesDeleteAny, "Hapus file siapa pun"},
+	{PermAlumniClaimsCreate, "Ajukan klaim data alumni"},
+	{PermAlumniClaimsReview, "Setujui / tolak klaim data alumni"},
+	{PermUsersManage, "Kelola akun user"},
+	{PermRolesManage, "Kelola role & permission"},
+	{PermLockoutsManage, "Lihat & buka lockout login"},
+	{PermAPIKeysManage, "Kelola API key integrasi"},
+	{PermAnalyticsRead, "Lihat dashboard analitik karier alumni"},
+	{PermSurveysManage, "Kelola kuesioner tracer study & lihat jawaban"},
+	{PermSurveysRespond, "Isi kuesioner tracer study"},
+}
+
+// IsValidPermission memeriksa apakah permission ada di registry
+func IsValidPermission(perm string) bool {
+	for _, p := range Permissions {
+		if p.Name == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// AllPermissions semua nama permission (dipakai role admin)
+func AllPermissions() []string {
+	all := make([]string, 0, len(Permissions))
+	for _, p := range Permissions {
+		all = append(all, p.Name)
+	}
+	return all
+}
+
+// HasPermission memeriksa apakah perm ada di daftar permission
+func HasPermission(perms []string, perm string) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// ✅ Role disimpan di koleksi "roles", _id = nama role
+type Role struct {
+	Name              string    `bson:"_id" json:"name" example:"operator"`
+	Description       string    `bson:"description" json:"description" example:"Staf fakultas"`
+	Permissions       []string  `bson:"permissions" json:"permissions"`
+	BuiltIn           bool      `bson:"built_in" json:"built_in"`              // role bawaan tidak bisa dihapus
+	SeededPermissions []string  `bson:"seeded_permissions,omitempty" json:"-"` // permission bawaan yang sudah pernah diberikan
+	CreatedAt         time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Request body admin saat membuat / mengubah role
+type RoleRequest struct {
+	Name        string   `json:"name,omitempty" example:"operator"`
+	Description string   `json:"description" example:"Staf fakultas"`
+	Permissions []string `json:"permissions" example:"alumni:read,alumni:write"`
+}
+
+// DefaultRoles role bawaan yang di-seed saat server start.
+// Permission admin selalu AllPermissions dan tidak bisa diubah.
+func DefaultRoles() []Role {
+	return []Role{
+		{Name: RoleAdmin, Description: "Administrator, akses penuh", Permissions: AllPermissions(), BuiltIn: true},
+		{Name: RoleOperator, Description: "Staf fakultas, mengelola data alumni & pekerjaan", BuiltIn: true, Permissions: []string{
+			PermAlumniRead, PermAlumniWrite, PermAlumniDelete, PermAlumniRestore,
+			PermPekerjaanRead, PermPekerjaanWrite, PermPekerjaanDelete, PermPekerjaanRestore,
+			PermFilesRead, PermFilesReadAll, PermFilesUploadAny, PermFilesDeleteAny,
+			PermAlumniClaimsReview,
+		}},
+		{Name: RoleUser, Description: "Alumni", BuiltIn: true, Permissions: []string{
+			PermAlumniRead, PermPekerjaanRead, PermPekerjaanDeleteOwn,
+			PermFilesRead, PermFilesUploadOwn, PermFilesDeleteOwn,
+			PermAlumniClaimsCreate, PermSurveysRespond,
+		}},
+		{Name: RoleViewer, Description: "Hanya baca", BuiltIn: true, Permissions: []string{
+			PermAlumniRead, PermPekerjaanRead, PermFilesRead,
+		}},
+	}
+}
+
+// DefaultPermissions permission bawaan sebuah role (nil jika bukan role bawaan)
+func DefaultPermissions(role string) []string {
+	for _, r := range DefaultRoles() {
+		if r.Name == role {
+			return r.Permissions
+		}
+	}
+	return nil
+}
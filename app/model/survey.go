@@ -0,0 +1,118 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tipe pertanyaan kuesioner
+const (
+	QuestionSingleChoice = "single_choice"
+	QuestionMultiChoice  = "multi_choice"
+	QuestionScale        = "scale"
+	QuestionText         = "text"
+)
+
+var QuestionTypes = []string{QuestionSingleChoice, QuestionMultiChoice, QuestionScale, QuestionText}
+
+// Status kuesioner: draft masih bisa diubah, published bisa diisi alumni, closed sudah ditutup.
+// Kuesioner yang sudah published tidak bisa diubah; buat versi baru agar jawaban lama tetap konsisten.
+const (
+	SurveyStatusDraft     = "draft"
+	SurveyStatusPublished = "published"
+	SurveyStatusClosed    = "closed"
+)
+
+// ✅ Satu versi kuesioner tracer study (koleksi "surveys").
+// Code sama untuk semua versi, (code, version) unik.
+type Survey struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Code        string             `bson:"code" json:"code"`
+	Version     int                `bson:"version" json:"version"`
+	Title       string             `bson:"title" json:"title"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	Questions   []SurveyQuestion   `bson:"questions" json:"questions"`
+	Status      string             `bson:"status" json:"status"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	PublishedAt *time.Time         `bson:"published_at,omitempty" json:"published_at,omitempty"`
+	ClosedAt    *time.Time         `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+type SurveyQuestion struct {
+	ID       string   `bson:"id" json:"id" example:"q1"` // unik dalam satu versi, dipakai sebagai kunci jawaban
+	Text     string   `bson:"text" json:"text" example:"Berapa lama Anda mendapat pekerjaan pertama?"`
+	Type     string   `bson:"type" json:"type" example:"single_choice"`
+	Required bool     `bson:"required" json:"required"`
+	Options  []string `bson:"options,omitempty" json:"options,omitempty"`     // single_choice / multi_choice
+	ScaleMin int      `bson:"scale_min,omitempty" json:"scale_min,omitempty"` // scale
+	ScaleMax int      `bson:"scale_max,omitempty" json:"scale_max,omitempty"` // scale
+}
+
+// Question mencari pertanyaan berdasarkan id
+func (s *Survey) Question(id string) *SurveyQuestion {
+	for i := range s.Questions {
+		if s.Questions[i].ID == id {
+			return &s.Questions[i]
+		}
+	}
+	return nil
+}
+
+// ✅ Jawaban satu alumni untuk satu versi kuesioner (koleksi "survey_responses")
+type SurveyResponse struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SurveyID      primitive.ObjectID `bson:"survey_id" json:"survey_id"`
+	SurveyVersion int                `bson:"survey_version" json:"survey_version"`
+	AlumniID      primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Answers       []SurveyAnswer     `bson:"answers" json:"answers"`
+	SubmittedAt   time.Time          `bson:"submitted_at" json:"submitted_at"`
+}
+
+// Jawaban satu pertanyaan; field yang dipakai tergantung tipe pertanyaan
+type SurveyAnswer struct {
+	QuestionID string   `bson:"question_id" json:"question_id" example:"q1"`
+	Choices    []string `bson:"choices,omitempty" json:"choices,omitempty"` // single_choice (1 item) / multi_choice
+	Scale      *int     `bson:"scale,omitempty" json:"scale,omitempty"`
+	Text       string   `bson:"text,omitempty" json:"text,omitempty"`
+}
+
+// Request body admin saat membuat / mengubah draft kuesioner
+type SurveyRequest struct {
+	Code        string           `json:"code,omitempty" example:"tracer-2025"` // hanya dipakai saat membuat
+	Title       string           `json:"title" example:"Tracer Study 2025"`
+	Description string           `json:"description" example:"Kuesioner tahunan alumni"`
+	Questions   []SurveyQuestion `json:"questions"`
+}
+
+// Request body alumni saat mengisi kuesioner
+type SubmitSurveyRequest struct {
+	Answers []SurveyAnswer `json:"answers"`
+}
+
+// Hitungan mentah jawaban satu kuesioner, dihitung di database
+type SurveyStats struct {
+	TotalResponses int
+	Answered       map[string]int            // question_id -> jumlah responden yang menjawab
+	Choices        map[string]map[string]int // question_id -> opsi -> jumlah
+	Scales         map[string]map[int]int    // question_id -> nilai -> jumlah
+}
+
+// Ringkasan jawaban per pertanyaan untuk admin
+type QuestionAggregate struct {
+	QuestionID string        `json:"question_id"`
+	Text       string        `json:"text"`
+	Type       string        `json:"type"`
+	Answered   int           `json:"answered"`
+	Options    []OptionCount `json:"options,omitempty"` // pilihan ganda & skala
+	Average    *float64      `json:"average,omitempty"` // skala
+}
+
+type OptionCount struct {
+	Value      string  `json:"value"`
+	Count      int     `json:"count"`
+	Persentase float64 `json:"persentase"` // dari jumlah yang menjawab pertanyaan
+}
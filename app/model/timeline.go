@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Satu pekerjaan dalam riwayat karier beserta rentang waktu yang sudah diturunkan
+type TimelineEntry struct {
+	Pekerjaan
+	Berjalan              bool `json:"berjalan"`                          // masih berlangsung saat ini
+	SelesaiTidakDiketahui bool `json:"selesai_tidak_diketahui,omitempty"` // sudah tidak aktif tapi tanggal selesai kosong
+	DurasiBulan           int  `json:"durasi_bulan"`
+}
+
+// Rentang waktu dalam riwayat karier (jeda antar pekerjaan / tumpang tindih)
+type TimelinePeriod struct {
+	Dari   time.Time `json:"dari"`
+	Sampai time.Time `json:"sampai"`
+	Bulan  int       `json:"bulan"`
+}
+
+// Dua pekerjaan yang dijalani bersamaan
+type TimelineOverlap struct {
+	TimelinePeriod
+	PekerjaanIDs [2]primitive.ObjectID `json:"pekerjaan_ids"`
+}
+
+// ✅ Riwayat karier kronologis satu alumni (GET /alumni/:id/timeline)
+type AlumniTimeline struct {
+	AlumniID          primitive.ObjectID `json:"alumni_id"`
+	Riwayat           []TimelineEntry    `json:"riwayat"`        // urut tanggal_mulai_kerja
+	TanpaTanggal      []Pekerjaan        `json:"tanpa_tanggal"`  // tanpa tanggal_mulai_kerja, tidak bisa ditempatkan
+	Jeda              []TimelinePeriod   `json:"jeda"`           // tidak bekerja di antara dua pekerjaan
+	TumpangTindih     []TimelineOverlap  `json:"tumpang_tindih"` // dua pekerjaan berjalan bersamaan
+	TotalBulanBekerja int                `json:"total_bulan_bekerja"`
+	CurrentJob        *Pekerjaan         `json:"current_job"`
+}
+
+// Detail alumni beserta pekerjaan yang sedang dijalani (GET /alumni/:id)
+type AlumniDetail struct {
+	Alumni
+	CurrentJob *Pekerjaan `json:"current_job"`
+}
@@ -0,0 +1,39 @@
+package model
+
+// Response login saat password benar tetapi masih perlu langkah kedua (2FA)
+type TwoFactorChallengeResponse struct {
+	Success           bool   `json:"success" example:"true"`
+	Message           string `json:"message"`
+	TwoFactorRequired bool   `json:"two_factor_required" example:"true"`
+	SetupRequired     bool   `json:"setup_required"` // true: 2FA diwajibkan, aktifkan dulu lewat /login/2fa/setup
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in" example:"300"` // detik
+}
+
+// Request body langkah kedua login
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code" example:"123456"` // kode TOTP 6 digit atau kode pemulihan
+}
+
+// Request body setup 2FA saat login (2FA diwajibkan tapi belum aktif)
+type TwoFactorSetupRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+// Response setup 2FA: secret untuk dimasukkan / di-scan ke aplikasi authenticator
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Alumni%20API:budi?secret=JBSWY3DPEHPK3PXP"`
+}
+
+// Request body aktivasi 2FA (user yang sudah login)
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// Request body mematikan 2FA
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code" example:"123456"`
+}
@@ -6,14 +6,54 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Status akun user. Dokumen lama yang belum punya field status dianggap aktif.
+const (
+	UserStatusPending = "pending" // baru register, email belum diverifikasi
+	UserStatusActive  = "active"
+)
+
+// Role bawaan. Role lain bisa dibuat admin lewat /roles (lihat role.go)
+const (
+	RoleAdmin    = "admin"
+	RoleUser     = "user"
+	RoleOperator = "operator" // staf fakultas
+	RoleViewer   = "viewer"
+)
+
 // ✅ Struktur user untuk koleksi "users" di MongoDB
 type User struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Username     string             `bson:"username" json:"username"`
-	Email        string             `bson:"email" json:"email"`
-	PasswordHash string             `bson:"password_hash" json:"-"` // penting! field ini wajib ada
-	Role         string             `bson:"role" json:"role"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
-	DeletedAt    *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Username          string              `bson:"username" json:"username"`
+	Email             string              `bson:"email" json:"email"`
+	PasswordHash      string              `bson:"password_hash" json:"-"` // penting! field ini wajib ada
+	Role              string              `bson:"role" json:"role"`
+	Status            string              `bson:"status,omitempty" json:"status,omitempty"`
+	AlumniID          *primitive.ObjectID `bson:"alumni_id,omitempty" json:"alumni_id,omitempty"` // data alumni milik user (lewat klaim yang disetujui admin)
+	EmailVerifiedAt   *time.Time          `bson:"email_verified_at,omitempty" json:"email_verified_at,omitempty"`
+	PasswordChangedAt *time.Time          `bson:"password_changed_at,omitempty" json:"password_changed_at,omitempty"`
+	TOTPSecret        string              `bson:"totp_secret,omitempty" json:"-"` // terisi sejak setup, aktif setelah TOTPEnabled
+	TOTPEnabled       bool                `bson:"totp_enabled,omitempty" json:"totp_enabled"`
+	TOTPLastStep      int64               `bson:"totp_last_step,omitempty" json:"-"` // langkah waktu kode terakhir, anti replay
+	RecoveryCodes     []string            `bson:"recovery_codes,omitempty" json:"-"` // hash kode pemulihan yang belum dipakai
+	CreatedAt         time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time           `bson:"updated_at" json:"updated_at"`
+	DeletedAt         *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+// IsPending true jika user belum memverifikasi email-nya
+func (u User) IsPending() bool {
+	return u.Status == UserStatusPending
+}
+
+// Request body admin saat membuat user
+type CreateUserRequest struct {
+	Username string `json:"username" example:"budi"`
+	Email    string `json:"email" example:"budi@example.com"`
+	Password string `json:"password" example:"rahasia123"`
+	Role     string `json:"role" example:"user"`
+}
+
+// Request body admin saat mengganti role user
+type UpdateRoleRequest struct {
+	Role string `json:"role" example:"admin"`
 }
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrClaimPending dikembalikan saat user masih punya klaim yang menunggu review
+var ErrClaimPending = errors.New("masih ada klaim alumni yang menunggu persetujuan")
+
+type AlumniClaimRepository interface {
+	Create(ctx context.Context, claim *model.AlumniClaim) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error)
+	FindByUser(ctx context.Context, userID primitive.ObjectID) ([]model.AlumniClaim, error)
+	FindAll(ctx context.Context, status string) ([]model.AlumniClaim, error)
+	Resolve(ctx context.Context, id primitive.ObjectID, status string, reviewer primitive.ObjectID, note string) (bool, error)
+}
+
+type alumniClaimRepository struct {
+	col *mongo.Collection
+}
+
+func NewAlumniClaimRepository(db *mongo.Database) AlumniClaimRepository {
+	r := &alumniClaimRepository{
+		col: db.Collection("alumni_claims"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ✅ Satu user hanya boleh punya satu klaim pending
+func (r *alumniClaimRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": model.ClaimStatusPending}),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index alumni_claims:", err)
+	}
+}
+
+func (r *alumniClaimRepository) Create(ctx context.Context, claim *model.AlumniClaim) error {
+	if claim.ID.IsZero() {
+		claim.ID = primitive.NewObjectID()
+	}
+	claim.Status = model.ClaimStatusPending
+	claim.CreatedAt = time.Now()
+
+	_, err := r.col.InsertOne(ctx, claim)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrClaimPending
+	}
+	return err
+}
+
+func (r *alumniClaimRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error) {
+	var claim model.AlumniClaim
+	err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&claim)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// ✅ Riwayat klaim milik satu user (terbaru dulu)
+func (r *alumniClaimRepository) FindByUser(ctx context.Context, userID primitive.ObjectID) ([]model.AlumniClaim, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+// ✅ Semua klaim, opsional difilter status
+func (r *alumniClaimRepository) FindAll(ctx context.Context, status string) ([]model.AlumniClaim, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *alumniClaimRepository) find(ctx context.Context, filter bson.M) ([]model.AlumniClaim, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AlumniClaim
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ✅ Setujui / tolak klaim. Atomik: hanya klaim yang masih pending yang bisa di-resolve,
+// false berarti klaim sudah diproses admin lain.
+func (r *alumniClaimRepository) Resolve(ctx context.Context, id primitive.ObjectID, status string, reviewer primitive.ObjectID, note string) (bool, error) {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":      status,
+			"review_note": note,
+			"reviewed_by": reviewer,
+			"reviewed_at": now,
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": model.ClaimStatusPending}, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
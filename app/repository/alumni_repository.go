@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"log"
+	"regexp"
 	"time"
 
 	"praktikum3/app/model"
@@ -10,14 +12,23 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrNIMExists dikembalikan saat NIM sudah dipakai data alumni lain
+var ErrNIMExists = errors.New("NIM sudah terdaftar")
+
 type AlumniRepository interface {
-	GetAll() ([]model.Alumni, error)
+	GetAll(q model.AlumniQuery) ([]model.Alumni, error)
+	Count(q model.AlumniQuery) (int, error)
+	GetAllByCursor(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error)
 	GetByID(id primitive.ObjectID) (*model.Alumni, error)
+	FindByNIM(nim string) (*model.Alumni, error)
 	Create(alumni *model.Alumni) error
-	Update(id primitive.ObjectID, alumni *model.Alumni) error
-	SoftDelete(id primitive.ObjectID) error
+	Update(id primitive.ObjectID, alumni *model.Alumni, version int64) error
+	UpdateContact(id primitive.ObjectID, req model.UpdateMyAlumniRequest) error
+	Patch(id primitive.ObjectID, alumni *model.Alumni, set, unset []string, version int64) error
+	SoftDelete(id primitive.ObjectID, version int64) error
 	GetTrashed() ([]model.Alumni, error)
 	GetTrashedByID(id primitive.ObjectID) (*model.Alumni, error)
 	Restore(id primitive.ObjectID) error
@@ -29,29 +40,120 @@ type alumniRepository struct {
 }
 
 func NewAlumniRepository(db *mongo.Database) AlumniRepository {
-	return &alumniRepository{
+	r := &alumniRepository{
 		col: db.Collection("alumni"),
 	}
+	r.ensureIndexes()
+	return r
 }
 
-func (r *alumniRepository) GetAll() ([]model.Alumni, error) {
+// ✅ Index unik untuk NIM; data lama tanpa NIM tidak ikut di-index
+func (r *alumniRepository) ensureIndexes() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "nim", Value: 1}},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"nim": bson.M{"$gt": ""}}),
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index alumni:", err)
+	}
+}
+
+// filter alumni aktif sesuai query (pencarian + filter persis)
+func alumniFilter(q model.AlumniQuery) bson.M {
 	filter := bson.M{"deleted_at": bson.M{"$eq": nil}}
-	cur, err := r.col.Find(ctx, filter)
+	if q.Search != "" {
+		pattern := regexp.QuoteMeta(q.Search)
+		filter["$or"] = []bson.M{
+			{"nama": bson.M{"$regex": pattern, "$options": "i"}},
+			{"nim": bson.M{"$regex": pattern, "$options": "i"}},
+			{"email": bson.M{"$regex": pattern, "$options": "i"}},
+		}
+	}
+	if q.Jurusan != "" {
+		filter["jurusan"] = q.Jurusan
+	}
+	if q.Angkatan != 0 {
+		filter["angkatan"] = q.Angkatan
+	}
+	if q.TahunLulus != 0 {
+		filter["tahun_lulus"] = q.TahunLulus
+	}
+	return filter
+}
+
+// ✅ List alumni aktif dengan pencarian, filter, urutan & paginasi
+func (r *alumniRepository) GetAll(q model.AlumniQuery) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := -1
+	if q.Order == "ASC" {
+		dir = 1
+	}
+	// _id sebagai urutan kedua agar paginasi stabil saat nilai sortBy sama
+	opts := options.Find().
+		SetSort(bson.D{{Key: q.SortBy, Value: dir}, {Key: "_id", Value: dir}}).
+		SetSkip(int64(q.Offset)).
+		SetLimit(int64(q.Limit))
+
+	cur, err := r.col.Find(ctx, alumniFilter(q), opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cur.Close(ctx)
 
-	var alumniList []model.Alumni
+	alumniList := []model.Alumni{}
 	if err = cur.All(ctx, &alumniList); err != nil {
 		return nil, err
 	}
 	return alumniList, nil
 }
 
+// ✅ List alumni aktif mode cursor (keyset): lanjut setelah posisi cursor, tanpa skip.
+// Offset di q diabaikan; cursor kosong berarti halaman pertama.
+func (r *alumniRepository) GetAllByCursor(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	after, err := decodeCursor(cursor, q.SortBy, q.Order)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cur, err := r.col.Find(ctx, keysetFilter(alumniFilter(q), after), keysetOptions(q.SortBy, q.Order, q.Limit))
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	alumniList := []model.Alumni{}
+	if err = cur.All(ctx, &alumniList); err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(alumniList) > q.Limit {
+		alumniList = alumniList[:q.Limit]
+		if next, err = nextCursor(alumniList[q.Limit-1], q.SortBy, q.Order); err != nil {
+			return nil, "", err
+		}
+	}
+	return alumniList, next, nil
+}
+
+// ✅ Jumlah alumni aktif yang cocok dengan query (untuk meta paginasi)
+func (r *alumniRepository) Count(q model.AlumniQuery) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := r.col.CountDocuments(ctx, alumniFilter(q))
+	return int(n), err
+}
+
 func (r *alumniRepository) GetByID(id primitive.ObjectID) (*model.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -64,22 +166,44 @@ func (r *alumniRepository) GetByID(id primitive.ObjectID) (*model.Alumni, error)
 	return &alumni, err
 }
 
+// ✅ Cari alumni berdasarkan NIM, termasuk yang ada di trash (NIM tetap terpakai sampai dihapus permanen)
+func (r *alumniRepository) FindByNIM(nim string) (*model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var alumni model.Alumni
+	err := r.col.FindOne(ctx, bson.M{"nim": nim}).Decode(&alumni)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alumni, nil
+}
+
 func (r *alumniRepository) Create(alumni *model.Alumni) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	alumni.CreatedAt = time.Now()
 	alumni.UpdatedAt = time.Now()
+	alumni.Version = 1
 	_, err := r.col.InsertOne(ctx, alumni)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrNIMExists
+	}
 	return err
 }
 
-func (r *alumniRepository) Update(id primitive.ObjectID, alumni *model.Alumni) error {
+// ✅ Ganti data alumni, hanya kalau versinya masih sama dengan yang dibaca client
+func (r *alumniRepository) Update(id primitive.ObjectID, alumni *model.Alumni, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	update := bson.M{
 		"$set": bson.M{
+			"nim":         alumni.NIM,
 			"nama":        alumni.Nama,
 			"jurusan":     alumni.Jurusan,
 			"angkatan":    alumni.Angkatan,
@@ -89,13 +213,75 @@ func (r *alumniRepository) Update(id primitive.ObjectID, alumni *model.Alumni) e
 			"alamat":      alumni.Alamat,
 			"updated_at":  time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
-	return err
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrNIMExists
+	}
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return versionMismatch(ctx, r.col, filter)
+	}
+	return nil
 }
 
-func (r *alumniRepository) SoftDelete(id primitive.ObjectID) error {
+// ✅ Ubah data kontak alumni (hanya field yang dikirim), dipakai alumni untuk data miliknya sendiri
+func (r *alumniRepository) UpdateContact(id primitive.ObjectID, req model.UpdateMyAlumniRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"updated_at": time.Now()}
+	if req.Email != nil {
+		set["email"] = *req.Email
+	}
+	if req.NoTelepon != nil {
+		set["no_telepon"] = *req.NoTelepon
+	}
+	if req.Alamat != nil {
+		set["alamat"] = *req.Alamat
+	}
+
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("data alumni tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Partial update: hanya field di set yang diubah (nilai diambil dari alumni), field di unset dihapus
+func (r *alumniRepository) Patch(id primitive.ObjectID, alumni *model.Alumni, set, unset []string, version int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update, err := buildPatchUpdate(alumni, set, unset)
+	if err != nil {
+		return err
+	}
+
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrNIMExists
+	}
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return versionMismatch(ctx, r.col, filter)
+	}
+	return nil
+}
+
+func (r *alumniRepository) SoftDelete(id primitive.ObjectID, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -103,9 +289,17 @@ func (r *alumniRepository) SoftDelete(id primitive.ObjectID) error {
 		"$set": bson.M{
 			"deleted_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
-	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
-	return err
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return versionMismatch(ctx, r.col, filter)
+	}
+	return nil
 }
 
 func (r *alumniRepository) GetTrashed() ([]model.Alumni, error) {
@@ -147,6 +341,7 @@ func (r *alumniRepository) Restore(id primitive.ObjectID) error {
 			"deleted_at": nil,
 			"updated_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}, update)
 	if err != nil {
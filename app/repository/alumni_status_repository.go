@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"praktikum3/app/model"
@@ -12,7 +11,7 @@ import (
 )
 
 type AlumniStatusRepository interface {
-	GetAlumniByStatus(status string) ([]model.AlumniPekerjaanReport, int, error)
+	GetAlumniByStatus(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error)
 }
 
 type alumniStatusRepository struct {
@@ -27,82 +26,97 @@ func NewAlumniStatusRepository(db *mongo.Database) AlumniStatusRepository {
 	}
 }
 
-func (r *alumniStatusRepository) GetAlumniByStatus(status string) ([]model.AlumniPekerjaanReport, int, error) {
+// Laporan pekerjaan + data alumni dalam satu aggregate: join ke alumni, buang data yang
+// sudah di-soft delete, lalu $facet untuk halaman data dan hitungan total sekaligus.
+func (r *alumniStatusRepository) GetAlumniByStatus(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Filter pekerjaan berdasarkan status
-	var pekerjaanFilter bson.M
-	if status == "aktif" {
-		pekerjaanFilter = bson.M{"status_pekerjaan": "aktif"}
-	} else if status == "tidak-aktif" {
-		pekerjaanFilter = bson.M{
-			"status_pekerjaan": bson.M{"$in": []string{"selesai", "resigned"}},
-		}
-	} else {
-		pekerjaanFilter = bson.M{} // semua
+	match := bson.M{"deleted_at": nil}
+	if q.Status == "aktif" {
+		match["status_pekerjaan"] = model.StatusPekerjaanAktif
+	} else if q.Status == "tidak-aktif" {
+		match["status_pekerjaan"] = bson.M{"$in": []string{model.StatusPekerjaanSelesai, model.StatusPekerjaanResigned}}
+	}
+
+	setahunLalu := time.Now().AddDate(-1, 0, 0)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "alumni",
+			// alumni_id pekerjaan lama bisa berupa string, samakan dulu ke ObjectID
+			"let": bson.M{"alumni_id": alumniObjectID("$alumni_id")},
+			"pipeline": []bson.M{
+				{"$match": bson.M{
+					"$expr":      bson.M{"$eq": []string{"$_id", "$$alumni_id"}},
+					"deleted_at": nil,
+				}},
+				{"$project": bson.M{"nama": 1, "jurusan": 1, "angkatan": 1}},
+			},
+			"as": "alumni",
+		}}},
+		// pekerjaan tanpa alumni aktif tidak ikut laporan
+		{{Key: "$unwind", Value: "$alumni"}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                 "$alumni._id",
+			"nama":                "$alumni.nama",
+			"jurusan":             "$alumni.jurusan",
+			"angkatan":            "$alumni.angkatan",
+			"bidang_industri":     1,
+			"nama_perusahaan":     1,
+			"posisi_jabatan":      1,
+			"tanggal_mulai_kerja": 1,
+			"gaji_range":          bson.M{"$ifNull": []interface{}{"$gaji_range", ""}},
+			// lebih dari 1 tahun bekerja; tanpa tanggal mulai dianggap tidak
+			"lebih_dari_satu_tahun": bson.M{"$and": []bson.M{
+				{"$gt": []interface{}{"$tanggal_mulai_kerja", nil}},
+				{"$lt": []interface{}{"$tanggal_mulai_kerja", setahunLalu}},
+			}},
+			"pekerjaan_id": "$_id",
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"data": []bson.M{
+				{"$sort": bson.D{{Key: "pekerjaan_id", Value: 1}}},
+				{"$skip": int64(q.Offset)},
+				{"$limit": int64(q.Limit)},
+			},
+			"counts": []bson.M{
+				{"$group": bson.M{
+					"_id":   nil,
+					"total": bson.M{"$sum": 1},
+					"lebih_dari_satu_tahun": bson.M{"$sum": bson.M{
+						"$cond": []interface{}{"$lebih_dari_satu_tahun", 1, 0},
+					}},
+				}},
+			},
+		}}},
 	}
 
-	cursor, err := r.pekerjaanCol.Find(ctx, pekerjaanFilter)
+	cursor, err := r.pekerjaanCol.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, 0, err
+		return nil, model.AlumniStatusCounts{}, err
 	}
 	defer cursor.Close(ctx)
 
-	var reports []model.AlumniPekerjaanReport
-	count := 0
-	now := time.Now()
-
-	for cursor.Next(ctx) {
-		var p struct {
-			AlumniID          interface{} `bson:"alumni_id"`
-			NamaPerusahaan    string      `bson:"nama_perusahaan"`
-			PosisiJabatan     string      `bson:"posisi_jabatan"`
-			BidangIndustri    string      `bson:"bidang_industri"`
-			TanggalMulaiKerja time.Time   `bson:"tanggal_mulai_kerja"`
-			GajiRange         string      `bson:"gaji_range"`
-		}
-		if err := cursor.Decode(&p); err != nil {
-			log.Println("decode error:", err)
-			continue
-		}
-
-		// cari data alumni terkait
-		var a struct {
-			ID        interface{} `bson:"_id"`
-			Nama      string      `bson:"nama"`
-			Jurusan   string      `bson:"jurusan"`
-			Angkatan  int         `bson:"angkatan"`
-		}
+	var res []struct {
+		Data   []model.AlumniPekerjaanReport `bson:"data"`
+		Counts []model.AlumniStatusCounts    `bson:"counts"`
+	}
+	if err := cursor.All(ctx, &res); err != nil {
+		return nil, model.AlumniStatusCounts{}, err
+	}
 
-		err := r.alumniCol.FindOne(ctx, bson.M{"_id": p.AlumniID}).Decode(&a)
-		if err != nil {
-			log.Printf("alumni %v tidak ditemukan: %v", p.AlumniID, err)
-			continue
+	reports := []model.AlumniPekerjaanReport{}
+	var counts model.AlumniStatusCounts
+	if len(res) > 0 {
+		if res[0].Data != nil {
+			reports = res[0].Data
 		}
-
-		// logika lama: lebih dari 1 tahun bekerja
-		lebihSetahun := p.TanggalMulaiKerja.Before(now.AddDate(-1, 0, 0))
-		if lebihSetahun {
-			count++
+		if len(res[0].Counts) > 0 {
+			counts = res[0].Counts[0]
 		}
-
-		reports = append(reports, model.AlumniPekerjaanReport{
-			Nama:               a.Nama,
-			Jurusan:            a.Jurusan,
-			Angkatan:           a.Angkatan,
-			BidangIndustri:     p.BidangIndustri,
-			NamaPerusahaan:     p.NamaPerusahaan,
-			PosisiJabatan:      p.PosisiJabatan,
-			TanggalMulaiKerja:  p.TanggalMulaiKerja,
-			GajiRange:          p.GajiRange,
-			LebihDariSatuTahun: lebihSetahun,
-		})
 	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, 0, err
-	}
-
-	return reports, count, nil
+	return reports, counts, nil
 }
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsRepository agregasi karier alumni untuk dashboard tracer study.
+// Semua perhitungan mengabaikan data alumni & pekerjaan yang sudah di-soft delete.
+type AnalyticsRepository interface {
+	EmploymentRate(groupBy string) ([]model.EmploymentRate, error)
+	WaitingTime(groupBy string) ([]model.WaitingTime, error)
+	Distribution(field string) ([]model.DistributionItem, error)
+	SalaryHistogram() ([]model.SalaryBucket, error)
+}
+
+type analyticsRepository struct {
+	alumniCol    *mongo.Collection
+	pekerjaanCol *mongo.Collection
+}
+
+func NewAnalyticsRepository(db *mongo.Database) AnalyticsRepository {
+	return &analyticsRepository{
+		alumniCol:    db.Collection("alumni"),
+		pekerjaanCol: db.Collection("pekerjaan_alumni"),
+	}
+}
+
+// groupKey: kunci $group, null berarti satu kelompok untuk semua alumni
+func groupKey(groupBy string) interface{} {
+	if groupBy == "" {
+		return nil
+	}
+	return "$" + groupBy
+}
+
+// lookupJobs menempelkan pekerjaan aktif (belum dihapus) milik alumni ke field "jobs".
+// alumni_id pekerjaan lama bisa tersimpan sebagai string, jadi dikonversi dulu ke ObjectID.
+func lookupJobs(extra ...bson.M) bson.D {
+	pipeline := []bson.M{{"$match": bson.M{
+		"$expr":      bson.M{"$eq": []interface{}{alumniObjectID("$alumni_id"), "$$alumni_id"}},
+		"deleted_at": nil,
+	}}}
+	return bson.D{{Key: "$lookup", Value: bson.M{
+		"from":     "pekerjaan_alumni",
+		"let":      bson.M{"alumni_id": "$_id"},
+		"pipeline": append(pipeline, extra...),
+		"as":       "jobs",
+	}}}
+}
+
+// lookupActiveAlumni menempelkan alumni pemilik pekerjaan ke field "alumni" lalu $unwind,
+// sehingga pekerjaan milik alumni yang sudah di-soft delete ikut terbuang
+func lookupActiveAlumni() []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from": "alumni",
+			"let":  bson.M{"alumni_id": alumniObjectID("$alumni_id")},
+			"pipeline": []bson.M{
+				{"$match": bson.M{
+					"$expr":      bson.M{"$eq": []string{"$_id", "$$alumni_id"}},
+					"deleted_at": nil,
+				}},
+				{"$project": bson.M{"_id": 1}},
+			},
+			"as": "alumni",
+		}}},
+		{{Key: "$unwind", Value: "$alumni"}},
+	}
+}
+
+func aggregateAll(col *mongo.Collection, pipeline mongo.Pipeline, out interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	cur, err := col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	return cur.All(ctx, out)
+}
+
+// ================= EMPLOYMENT RATE =================
+func (r *analyticsRepository) EmploymentRate(groupBy string) ([]model.EmploymentRate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil}}},
+		lookupJobs(bson.M{"$project": bson.M{"status_pekerjaan": 1}}),
+		{{Key: "$group", Value: bson.M{
+			"_id":           groupKey(groupBy),
+			"jumlah_alumni": bson.M{"$sum": 1},
+			"bekerja": bson.M{"$sum": bson.M{
+				"$cond": []interface{}{bson.M{"$in": []interface{}{model.StatusPekerjaanAktif, "$jobs.status_pekerjaan"}}, 1, 0},
+			}},
+			"pernah_bekerja": bson.M{"$sum": bson.M{
+				"$cond": []interface{}{bson.M{"$gt": []interface{}{bson.M{"$size": "$jobs"}, 0}}, 1, 0},
+			}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"persentase": bson.M{"$round": []interface{}{
+				bson.M{"$multiply": []interface{}{bson.M{"$divide": []string{"$bekerja", "$jumlah_alumni"}}, 100}}, 2,
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	list := []model.EmploymentRate{}
+	if err := aggregateAll(r.alumniCol, pipeline, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ================= WAITING TIME =================
+// Masa tunggu dihitung dalam bulan dari Januari tahun_lulus sampai bulan tanggal_mulai_kerja
+// pekerjaan pertama. Pekerjaan yang dimulai sebelum lulus dihitung 0 bulan.
+func (r *analyticsRepository) WaitingTime(groupBy string) ([]model.WaitingTime, error) {
+	mulai := "$first_job.tanggal_mulai_kerja"
+	bulanMulai := bson.M{"$add": []interface{}{
+		bson.M{"$multiply": []interface{}{bson.M{"$year": mulai}, 12}},
+		bson.M{"$month": mulai},
+	}}
+	bulanLulus := bson.M{"$add": []interface{}{
+		bson.M{"$multiply": []interface{}{"$tahun_lulus", 12}}, 1,
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil, "tahun_lulus": bson.M{"$gt": 0}}}},
+		lookupJobs(
+			bson.M{"$match": bson.M{"tanggal_mulai_kerja": bson.M{"$ne": nil}}},
+			bson.M{"$sort": bson.M{"tanggal_mulai_kerja": 1}},
+			bson.M{"$limit": 1},
+			bson.M{"$project": bson.M{"tanggal_mulai_kerja": 1}},
+		),
+		{{Key: "$unwind", Value: bson.M{"path": "$jobs"}}},
+		{{Key: "$set", Value: bson.M{"first_job": "$jobs"}}},
+		{{Key: "$set", Value: bson.M{
+			"tunggu": bson.M{"$max": []interface{}{0, bson.M{"$subtract": []interface{}{bulanMulai, bulanLulus}}}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             groupKey(groupBy),
+			"jumlah_alumni":   bson.M{"$sum": 1},
+			"rata_rata_bulan": bson.M{"$avg": "$tunggu"},
+			"min_bulan":       bson.M{"$min": "$tunggu"},
+			"max_bulan":       bson.M{"$max": "$tunggu"},
+		}}},
+		{{Key: "$set", Value: bson.M{"rata_rata_bulan": bson.M{"$round": []interface{}{"$rata_rata_bulan", 1}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	list := []model.WaitingTime{}
+	if err := aggregateAll(r.alumniCol, pipeline, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ================= DISTRIBUTION =================
+// field harus salah satu model.AnalyticsDistributionFields (divalidasi di service)
+func (r *analyticsRepository) Distribution(field string) ([]model.DistributionItem, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"deleted_at": nil}}}}
+	pipeline = append(pipeline, lookupActiveAlumni()...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":              bson.M{"$ifNull": []interface{}{"$" + field, ""}},
+			"jumlah_pekerjaan": bson.M{"$sum": 1},
+			"alumni":           bson.M{"$addToSet": "$alumni._id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"jumlah_pekerjaan": 1,
+			"jumlah_alumni":    bson.M{"$size": "$alumni"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "jumlah_pekerjaan", Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+
+	list := []model.DistributionItem{}
+	if err := aggregateAll(r.pekerjaanCol, pipeline, &list); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, item := range list {
+		total += item.JumlahPekerjaan
+	}
+	for i := range list {
+		list[i].Persentase = percent(list[i].JumlahPekerjaan, total)
+	}
+	return list, nil
+}
+
+// ================= SALARY HISTOGRAM =================
+func (r *analyticsRepository) SalaryHistogram() ([]model.SalaryBucket, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"deleted_at": nil}}}}
+	pipeline = append(pipeline, lookupActiveAlumni()...)
+	pipeline = append(pipeline, bson.D{{Key: "$group", Value: bson.M{
+		"_id":    bson.M{"$ifNull": []interface{}{"$gaji_range", ""}},
+		"jumlah": bson.M{"$sum": 1},
+	}}})
+
+	list := []model.SalaryBucket{}
+	if err := aggregateAll(r.pekerjaanCol, pipeline, &list); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, b := range list {
+		total += b.Jumlah
+	}
+	for i := range list {
+		list[i].Persentase = percent(list[i].Jumlah, total)
+	}
+	// gaji_range teks bebas ("3-5 juta", "Rp3.000.000 - 5.000.000"), jadi diurutkan dari batas bawahnya; yang tidak diisi paling akhir
+	sort.SliceStable(list, func(i, j int) bool {
+		li, lok := utils.SalaryLowerBound(list[i].GajiRange)
+		lj, rok := utils.SalaryLowerBound(list[j].GajiRange)
+		if lok != rok {
+			return lok
+		}
+		if li != lj {
+			return li < lj
+		}
+		return list[i].GajiRange < list[j].GajiRange
+	})
+	return list, nil
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*10000) / 100
+}
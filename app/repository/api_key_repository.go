@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// last_used_at cukup diperbarui sekali per interval ini, agar setiap request
+// dengan API key tidak selalu berujung write ke MongoDB
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	FindAll(ctx context.Context) ([]model.APIKey, error)
+	Revoke(ctx context.Context, id primitive.ObjectID) (bool, error)
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+type apiKeyRepository struct {
+	col *mongo.Collection
+}
+
+func NewAPIKeyRepository(db *mongo.Database) APIKeyRepository {
+	r := &apiKeyRepository{
+		col: db.Collection("api_keys"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *apiKeyRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index api_keys:", err)
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	if key.ID.IsZero() {
+		key.ID = primitive.NewObjectID()
+	}
+	key.CreatedAt = time.Now()
+
+	_, err := r.col.InsertOne(ctx, key)
+	return err
+}
+
+// ✅ Cari API key berdasarkan hash (termasuk yang dicabut/expired, dicek di middleware)
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	var k model.APIKey
+	err := r.col.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&k)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *apiKeyRepository) FindAll(ctx context.Context) ([]model.APIKey, error) {
+	cursor, err := r.col.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []model.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ✅ Cabut API key. false berarti key tidak ada atau sudah dicabut sebelumnya.
+func (r *apiKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// ✅ Catat waktu terakhir key dipakai (paling sering sekali per apiKeyTouchInterval)
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "$or": bson.A{
+			bson.M{"last_used_at": nil},
+			bson.M{"last_used_at": bson.M{"$lt": at.Add(-apiKeyTouchInterval)}},
+		}},
+		bson.M{"$set": bson.M{"last_used_at": at}},
+	)
+	return err
+}
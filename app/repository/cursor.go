@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidCursor dikembalikan saat cursor rusak atau dibuat untuk urutan yang berbeda
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// isi cursor: posisi dokumen terakhir pada halaman sebelumnya.
+// Disimpan sebagai BSON agar tipe nilai sort (tanggal, angka, string) tetap utuh.
+type pageCursor struct {
+	SortBy string             `bson:"s"`
+	Order  string             `bson:"o"`
+	Value  interface{}        `bson:"v"`
+	ID     primitive.ObjectID `bson:"id"`
+}
+
+func encodeCursor(c pageCursor) (string, error) {
+	raw, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor membaca cursor dari client; cursor kosong berarti halaman pertama
+func decodeCursor(token, sortBy, order string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := bson.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.SortBy != sortBy || c.Order != order || c.ID.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// keysetFilter menambahkan syarat "setelah cursor" ke filter untuk urutan (sortBy, _id).
+// Nilai null/kosong diurutkan MongoDB paling kecil, jadi ditangani terpisah.
+func keysetFilter(filter bson.M, c *pageCursor) bson.M {
+	if c == nil {
+		return filter
+	}
+
+	op := "$lt"
+	if c.Order == "ASC" {
+		op = "$gt"
+	}
+
+	var after []bson.M
+	switch {
+	case c.Value == nil && c.Order == "ASC":
+		after = []bson.M{
+			{c.SortBy: bson.M{"$ne": nil}},
+			{c.SortBy: nil, "_id": bson.M{op: c.ID}},
+		}
+	case c.Value == nil:
+		after = []bson.M{
+			{c.SortBy: nil, "_id": bson.M{op: c.ID}},
+		}
+	default:
+		after = []bson.M{
+			{c.SortBy: bson.M{op: c.Value}},
+			{c.SortBy: c.Value, "_id": bson.M{op: c.ID}},
+		}
+		if c.Order != "ASC" {
+			after = append(after, bson.M{c.SortBy: nil})
+		}
+	}
+
+	// filter asal bisa sudah memakai $or (pencarian), jadi digabung lewat $and
+	return bson.M{"$and": []bson.M{filter, {"$or": after}}}
+}
+
+// keysetOptions mengurutkan berdasarkan (sortBy, _id) dan mengambil satu dokumen ekstra
+// untuk mengetahui apakah masih ada halaman berikutnya.
+func keysetOptions(sortBy, order string, limit int) *options.FindOptions {
+	return options.Find().
+		SetSort(keysetSort(sortBy, order)).
+		SetLimit(int64(limit) + 1)
+}
+
+// keysetSort: urutan (sortBy, _id) yang sama dengan keysetOptions, untuk dipakai di pipeline aggregate
+func keysetSort(sortBy, order string) bson.D {
+	dir := -1
+	if order == "ASC" {
+		dir = 1
+	}
+	return bson.D{{Key: sortBy, Value: dir}, {Key: "_id", Value: dir}}
+}
+
+// nextCursor membuat cursor dari dokumen terakhir halaman ini (doc = struct model dengan tag bson)
+func nextCursor(doc interface{}, sortBy, order string) (string, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	var values bson.M
+	if err := bson.Unmarshal(raw, &values); err != nil {
+		return "", err
+	}
+	id, _ := values["_id"].(primitive.ObjectID)
+	return encodeCursor(pageCursor{SortBy: sortBy, Order: order, Value: values[sortBy], ID: id})
+}
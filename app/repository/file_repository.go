@@ -14,6 +14,7 @@ import (
 type FileRepository interface {
 	Create(file *model.File) (primitive.ObjectID, error)
 	FindAll() ([]model.File, error)
+	FindAllByCursor(limit int, cursor string) ([]model.File, string, error)
 	FindByID(id primitive.ObjectID) (*model.File, error)
 	DeleteByID(id primitive.ObjectID) error
 	FindByUploadedBy(userID primitive.ObjectID) ([]model.File, error)
@@ -67,6 +68,38 @@ func (r *fileRepository) FindAll() ([]model.File, error) {
 	return list, nil
 }
 
+// ✅ Ambil file per halaman (mode cursor), terbaru dulu
+func (r *fileRepository) FindAllByCursor(limit int, cursor string) ([]model.File, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const sortBy, order = "uploaded_at", "DESC"
+	after, err := decodeCursor(cursor, sortBy, order)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cur, err := r.col.Find(ctx, keysetFilter(bson.M{}, after), keysetOptions(sortBy, order, limit))
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	list := []model.File{}
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(list) > limit {
+		list = list[:limit]
+		if next, err = nextCursor(list[limit-1], sortBy, order); err != nil {
+			return nil, "", err
+		}
+	}
+	return list, next, nil
+}
+
 // ✅ Ambil file berdasarkan ID
 func (r *fileRepository) FindByID(id primitive.ObjectID) (*model.File, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
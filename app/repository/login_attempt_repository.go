@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// counter yang tidak bertambah selama durasi ini dihapus otomatis (TTL index)
+const loginAttemptRetention = 24 * time.Hour
+
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, key string) (*model.LoginAttempt, error)
+	RegisterFailure(ctx context.Context, kind, subject string) (*model.LoginAttempt, error)
+	SetLockedUntil(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	ListLocked(ctx context.Context) ([]model.LoginAttempt, error)
+}
+
+type loginAttemptRepository struct {
+	col *mongo.Collection
+}
+
+func NewLoginAttemptRepository(db *mongo.Database) LoginAttemptRepository {
+	r := &loginAttemptRepository{
+		col: db.Collection("login_attempts"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *loginAttemptRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		{Keys: bson.D{{Key: "locked_until", Value: 1}}},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index login_attempts:", err)
+	}
+}
+
+// AttemptKey membentuk _id counter dari jenis dan subjek
+func AttemptKey(kind, subject string) string {
+	return kind + ":" + subject
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*model.LoginAttempt, error) {
+	var a model.LoginAttempt
+	err := r.col.FindOne(ctx, bson.M{"_id": key}).Decode(&a)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// ✅ Tambah counter gagal secara atomik dan kembalikan kondisi terbaru
+func (r *loginAttemptRepository) RegisterFailure(ctx context.Context, kind, subject string) (*model.LoginAttempt, error) {
+	now := time.Now()
+	key := AttemptKey(kind, subject)
+
+	update := bson.M{
+		"$inc": bson.M{"failures": 1},
+		"$set": bson.M{
+			"kind":            kind,
+			"subject":         subject,
+			"last_failure_at": now,
+			"expires_at":      now.Add(loginAttemptRetention),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var a model.LoginAttempt
+	if err := r.col.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *loginAttemptRepository) SetLockedUntil(ctx context.Context, key string, until time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"locked_until": until,
+		// counter tetap disimpan minimal sampai lockout selesai
+		"expires_at": until.Add(loginAttemptRetention),
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": key}, update)
+	return err
+}
+
+// ✅ Hapus counter (login sukses atau dibuka admin)
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+// ✅ Semua akun / IP yang sedang terkunci
+func (r *loginAttemptRepository) ListLocked(ctx context.Context) ([]model.LoginAttempt, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "locked_until", Value: -1}})
+	cur, err := r.col.Find(ctx, bson.M{"locked_until": bson.M{"$gt": time.Now()}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.LoginAttempt
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
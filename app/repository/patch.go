@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// buildPatchUpdate menyusun $set/$unset dari dokumen doc (struct model dengan tag bson).
+// Nilai $set diambil lewat encoding bson agar tipenya sama dengan saat insert; version ikut dinaikkan.
+func buildPatchUpdate(doc interface{}, set, unset []string) (bson.M, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var values bson.M
+	if err := bson.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	setDoc := bson.M{"updated_at": time.Now()}
+	unsetDoc := bson.M{}
+	for _, field := range set {
+		value, ok := values[field]
+		if !ok {
+			// field omitempty yang kosong berarti dihapus
+			unsetDoc[field] = ""
+			continue
+		}
+		setDoc[field] = value
+	}
+	for _, field := range unset {
+		if _, ok := setDoc[field]; ok {
+			return nil, fmt.Errorf("field %s tidak bisa di-set dan di-unset sekaligus", field)
+		}
+		unsetDoc[field] = ""
+	}
+
+	update := bson.M{"$set": setDoc, "$inc": bson.M{"version": 1}}
+	if len(unsetDoc) > 0 {
+		update["$unset"] = unsetDoc
+	}
+	return update, nil
+}
@@ -2,8 +2,8 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"praktikum3/app/model"
@@ -15,20 +15,23 @@ import (
 )
 
 type PekerjaanRepository interface {
-	GetAllWithQuery(search, sortBy, order string, limit, offset int) ([]model.Pekerjaan, error)
-	Count(search string) (int, error)
+	GetAllWithQuery(q model.PekerjaanQuery) ([]model.Pekerjaan, error)
+	Count(q model.PekerjaanQuery) (int, error)
+	GetAllByCursor(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error)
 	GetByID(id primitive.ObjectID) (*model.Pekerjaan, error)
 	GetByAlumniID(alumniID primitive.ObjectID, includeDeleted bool) ([]model.Pekerjaan, error)
 	Create(in model.CreatePekerjaanReq, mulai, selesai *time.Time) (primitive.ObjectID, error)
-	Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time) error
-	SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID) error
-	SoftDeleteByAdmin(id primitive.ObjectID) error
+	Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time, version int64) error
+	Patch(id primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error
+	SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID, version int64) error
+	SoftDeleteByAdmin(id primitive.ObjectID, version int64) error
 	RestoreByID(id primitive.ObjectID) error
 	RestoreByIDAndUser(id primitive.ObjectID, alumniID primitive.ObjectID) error
 	HardDeleteByID(id primitive.ObjectID) error
 	HardDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID) error
 	GetAllTrash() ([]model.PekerjaanTrash, error)
 	GetUserTrash(alumniID primitive.ObjectID) ([]model.PekerjaanTrash, error)
+	ExpireContracts(before time.Time) (int64, error)
 }
 
 type pekerjaanRepository struct {
@@ -41,30 +44,107 @@ func NewPekerjaanRepository(db *mongo.Database) PekerjaanRepository {
 	}
 }
 
-// ================= GET ALL =================
-func (r *pekerjaanRepository) GetAllWithQuery(search, sortBy, order string, limit, offset int) ([]model.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// filter pekerjaan aktif dengan pencarian di perusahaan/posisi/bidang industri dan filter sisi pekerjaan.
+// Search di-escape dulu, jadi karakter regex dari client dicocokkan apa adanya.
+func pekerjaanFilter(q model.PekerjaanQuery) bson.M {
+	filter := bson.M{"deleted_at": nil}
+	if q.Search != "" {
+		pattern := regexp.QuoteMeta(q.Search)
+		switch q.SearchMode {
+		case model.SearchPrefix:
+			pattern = "^" + pattern
+		case model.SearchExact:
+			pattern = "^" + pattern + "$"
+		}
+		filter["$or"] = []bson.M{
+			{"nama_perusahaan": bson.M{"$regex": pattern, "$options": "i"}},
+			{"posisi_jabatan": bson.M{"$regex": pattern, "$options": "i"}},
+			{"bidang_industri": bson.M{"$regex": pattern, "$options": "i"}},
+		}
+	}
 
-	filter := bson.M{
-		"deleted_at": nil,
-		"$or": []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-		},
+	f := q.Filters
+	if f.StatusPekerjaan != "" {
+		filter["status_pekerjaan"] = f.StatusPekerjaan
+	}
+	if f.BidangIndustri != "" {
+		filter["bidang_industri"] = f.BidangIndustri
+	}
+	if f.LokasiKerja != "" {
+		filter["lokasi_kerja"] = f.LokasiKerja
+	}
+	if r := dateRange(f.MulaiDari, f.MulaiSampai); r != nil {
+		filter["tanggal_mulai_kerja"] = r
+	}
+	if r := dateRange(f.SelesaiDari, f.SelesaiSampai); r != nil {
+		filter["tanggal_selesai_kerja"] = r
 	}
+	return filter
+}
+
+// dateRange: kondisi rentang tanggal per hari, "sampai" ikut dihitung sampai akhir hari
+func dateRange(from, to *time.Time) bson.M {
+	if from == nil && to == nil {
+		return nil
+	}
+	r := bson.M{}
+	if from != nil {
+		r["$gte"] = *from
+	}
+	if to != nil {
+		r["$lt"] = to.AddDate(0, 0, 1)
+	}
+	return r
+}
 
-	opts := options.Find()
-	if order == "ASC" {
-		opts.SetSort(bson.D{{Key: sortBy, Value: 1}})
-	} else {
-		opts.SetSort(bson.D{{Key: sortBy, Value: -1}})
+// alumniObjectID: alumni_id sebagai ObjectID untuk $lookup ke alumni. Data lama yang menyimpan
+// alumni_id sebagai hex string ikut dikonversi, nilai yang tidak valid menjadi null.
+func alumniObjectID(field string) bson.M {
+	return bson.M{"$convert": bson.M{"input": field, "to": "objectId", "onError": nil, "onNull": nil}}
+}
+
+// pekerjaanPipeline: $match filter pekerjaan, lalu join ke alumni hanya kalau ada filter jurusan/angkatan
+func pekerjaanPipeline(q model.PekerjaanQuery, match bson.M) mongo.Pipeline {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	if !q.Filters.ByAlumni() {
+		return pipeline
+	}
+
+	alumniMatch := bson.M{}
+	if q.Filters.Jurusan != "" {
+		alumniMatch["alumni.jurusan"] = q.Filters.Jurusan
+	}
+	if q.Filters.Angkatan != 0 {
+		alumniMatch["alumni.angkatan"] = q.Filters.Angkatan
 	}
-	opts.SetLimit(int64(limit))
-	opts.SetSkip(int64(offset))
+	return append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": "alumni",
+			"let":  bson.M{"alumni_id": alumniObjectID("$alumni_id")},
+			"pipeline": []bson.M{
+				{"$match": bson.M{"$expr": bson.M{"$eq": []string{"$_id", "$$alumni_id"}}}},
+				{"$project": bson.M{"jurusan": 1, "angkatan": 1}},
+			},
+			"as": "alumni",
+		}}},
+		bson.D{{Key: "$match", Value: alumniMatch}},
+		bson.D{{Key: "$project", Value: bson.M{"alumni": 0}}},
+	)
+}
 
-	cursor, err := r.col.Find(ctx, filter, opts)
+// ================= GET ALL =================
+func (r *pekerjaanRepository) GetAllWithQuery(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// _id sebagai urutan kedua agar paginasi stabil saat nilai sortBy sama
+	pipeline := append(pekerjaanPipeline(q, pekerjaanFilter(q)),
+		bson.D{{Key: "$sort", Value: keysetSort(q.SortBy, q.Order)}},
+		bson.D{{Key: "$skip", Value: int64(q.Offset)}},
+		bson.D{{Key: "$limit", Value: int64(q.Limit)}},
+	)
+
+	cursor, err := r.col.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -77,22 +157,71 @@ func (r *pekerjaanRepository) GetAllWithQuery(search, sortBy, order string, limi
 	return list, nil
 }
 
+// ================= GET ALL (CURSOR) =================
+// Mode keyset: lanjut setelah posisi cursor tanpa skip, cursor kosong berarti halaman pertama
+func (r *pekerjaanRepository) GetAllByCursor(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	after, err := decodeCursor(cursor, q.SortBy, q.Order)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// ambil satu dokumen ekstra untuk mengetahui apakah masih ada halaman berikutnya
+	pipeline := append(pekerjaanPipeline(q, keysetFilter(pekerjaanFilter(q), after)),
+		bson.D{{Key: "$sort", Value: keysetSort(q.SortBy, q.Order)}},
+		bson.D{{Key: "$limit", Value: int64(q.Limit) + 1}},
+	)
+
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	list := []model.Pekerjaan{}
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(list) > q.Limit {
+		list = list[:q.Limit]
+		if next, err = nextCursor(list[q.Limit-1], q.SortBy, q.Order); err != nil {
+			return nil, "", err
+		}
+	}
+	return list, next, nil
+}
+
 // ================= COUNT =================
-func (r *pekerjaanRepository) Count(search string) (int, error) {
+func (r *pekerjaanRepository) Count(q model.PekerjaanQuery) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{
-		"deleted_at": nil,
-		"$or": []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-		},
+	if !q.Filters.ByAlumni() {
+		count, err := r.col.CountDocuments(ctx, pekerjaanFilter(q))
+		return int(count), err
+	}
+
+	pipeline := append(pekerjaanPipeline(q, pekerjaanFilter(q)), bson.D{{Key: "$count", Value: "total"}})
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
 	}
+	defer cur.Close(ctx)
 
-	count, err := r.col.CountDocuments(ctx, filter)
-	return int(count), err
+	var res []struct {
+		Total int `bson:"total"`
+	}
+	if err := cur.All(ctx, &res); err != nil {
+		return 0, err
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].Total, nil
 }
 
 // ================= GET BY ID =================
@@ -123,7 +252,9 @@ func (r *pekerjaanRepository) GetByAlumniID(alumniID primitive.ObjectID, include
 		filter["deleted_at"] = nil
 	}
 
-	cur, err := r.col.Find(ctx, filter)
+	// urut kronologis; pekerjaan tanpa tanggal mulai (null) muncul paling awal
+	opts := options.Find().SetSort(bson.D{{Key: "tanggal_mulai_kerja", Value: 1}, {Key: "_id", Value: 1}})
+	cur, err := r.col.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +285,7 @@ func (r *pekerjaanRepository) Create(in model.CreatePekerjaanReq, mulai, selesai
 		"deskripsi_pekerjaan":   in.DeskripsiPekerjaan,
 		"created_at":            time.Now(),
 		"updated_at":            time.Now(),
+		"version":               1,
 	}
 
 	res, err := r.col.InsertOne(ctx, doc)
@@ -169,7 +301,7 @@ func (r *pekerjaanRepository) Create(in model.CreatePekerjaanReq, mulai, selesai
 }
 
 // ================= UPDATE =================
-func (r *pekerjaanRepository) Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time) error {
+func (r *pekerjaanRepository) Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -184,47 +316,88 @@ func (r *pekerjaanRepository) Update(id primitive.ObjectID, in model.UpdatePeker
 		"status_pekerjaan":      in.StatusPekerjaan,
 		"deskripsi_pekerjaan":   in.DeskripsiPekerjaan,
 		"updated_at":            time.Now(),
-	}}
+	}, "$inc": bson.M{"version": 1}}
 
-	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
-	return err
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return versionMismatch(ctx, r.col, filter)
+	}
+	return nil
+}
+
+// ================= PATCH =================
+// Hanya field di set yang diubah (nilai diambil dari p), field di unset dihapus
+func (r *pekerjaanRepository) Patch(id primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update, err := buildPatchUpdate(p, set, unset)
+	if err != nil {
+		return err
+	}
+
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return versionMismatch(ctx, r.col, filter)
+	}
+	return nil
 }
 
 // ================= SOFT DELETE =================
-func (r *pekerjaanRepository) SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID) error {
+func (r *pekerjaanRepository) SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{
+	filter := withVersion(bson.M{
 		"_id": id,
 		"$or": []bson.M{
 			{"alumni_id": alumniID},
 			{"alumni_id": alumniID.Hex()},
 		},
 		"deleted_at": nil,
-	}
-	update := bson.M{"$set": bson.M{"deleted_at": time.Now(), "updated_at": time.Now()}}
+	}, version)
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now(), "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
 	res, err := r.col.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return errors.New("data tidak ditemukan atau bukan milik user")
+		if err := versionMismatch(ctx, r.col, filter); err != ErrNotFound {
+			return err
+		}
+		// tidak cocok dengan pemiliknya: bedakan data milik alumni lain dengan data yang memang tidak ada
+		n, err := r.col.CountDocuments(ctx, bson.M{"_id": id, "deleted_at": nil})
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrNotOwner
+		}
+		return ErrNotFound
 	}
 	return nil
 }
 
-func (r *pekerjaanRepository) SoftDeleteByAdmin(id primitive.ObjectID) error {
+func (r *pekerjaanRepository) SoftDeleteByAdmin(id primitive.ObjectID, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	update := bson.M{"$set": bson.M{"deleted_at": time.Now(), "updated_at": time.Now()}}
-	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now(), "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	filter := withVersion(bson.M{"_id": id, "deleted_at": nil}, version)
+	res, err := r.col.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return errors.New("data tidak ditemukan")
+		return versionMismatch(ctx, r.col, filter)
 	}
 	return nil
 }
@@ -233,7 +406,7 @@ func (r *pekerjaanRepository) SoftDeleteByAdmin(id primitive.ObjectID) error {
 func (r *pekerjaanRepository) RestoreByID(id primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	update := bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": time.Now()}}
+	update := bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
 	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
 	return err
 }
@@ -248,7 +421,7 @@ func (r *pekerjaanRepository) RestoreByIDAndUser(id, alumniID primitive.ObjectID
 			{"alumni_id": alumniID.Hex()},
 		},
 	}
-	update := bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": time.Now()}}
+	update := bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
 	_, err := r.col.UpdateOne(ctx, filter, update)
 	return err
 }
@@ -315,3 +488,26 @@ func (r *pekerjaanRepository) GetUserTrash(alumniID primitive.ObjectID) ([]model
 	}
 	return trash, nil
 }
+
+// ================= EXPIRE CONTRACTS =================
+// Pekerjaan aktif yang tanggal selesainya sebelum `before` diubah menjadi selesai.
+// Versi ikut naik, jadi client yang masih memegang ETag lama akan dapat 412.
+func (r *pekerjaanRepository) ExpireContracts(before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"deleted_at":            nil,
+		"status_pekerjaan":      model.StatusPekerjaanAktif,
+		"tanggal_selesai_kerja": bson.M{"$lt": before},
+	}
+	update := bson.M{
+		"$set": bson.M{"status_pekerjaan": model.StatusPekerjaanSelesai, "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	}
+	res, err := r.col.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
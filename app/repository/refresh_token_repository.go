@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *model.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	MarkUsed(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error)
+	RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error
+	RevokeByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type refreshTokenRepository struct {
+	col *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *mongo.Database) RefreshTokenRepository {
+	r := &refreshTokenRepository{
+		col: db.Collection("refresh_tokens"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *refreshTokenRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index refresh_tokens:", err)
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *model.RefreshToken) error {
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	token.CreatedAt = time.Now()
+
+	_, err := r.col.InsertOne(ctx, token)
+	return err
+}
+
+// ✅ Cari refresh token berdasarkan hash (termasuk yang sudah dipakai/dicabut, untuk deteksi reuse)
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	var t model.RefreshToken
+	err := r.col.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ✅ Tandai token sudah dirotasi. false berarti token sudah dipakai/dicabut lebih dulu.
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "used_at": nil, "revoked_at": nil},
+		bson.M{"$set": bson.M{"used_at": time.Now(), "replaced_by": replacedBy}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// ✅ Cabut semua token dalam satu family (dipakai saat terdeteksi reuse)
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	_, err := r.col.UpdateMany(ctx,
+		bson.M{"family_id": familyID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
+
+// ✅ Cabut semua refresh token milik user
+func (r *refreshTokenRepository) RevokeByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.col.UpdateMany(ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
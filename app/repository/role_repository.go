@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Permission role di-cache per proses; perubahan dari instance lain berlaku
+// paling lambat setelah durasi ini.
+const roleCacheTTL = 30 * time.Second
+
+// ErrRoleExists dikembalikan saat nama role sudah dipakai
+var ErrRoleExists = errors.New("role sudah ada")
+
+// ErrRoleNotFound dikembalikan saat role tidak ada
+var ErrRoleNotFound = errors.New("role tidak ditemukan")
+
+type RoleRepository interface {
+	FindAll(ctx context.Context) ([]model.Role, error)
+	FindByName(ctx context.Context, name string) (*model.Role, error)
+	Create(ctx context.Context, role *model.Role) error
+	Update(ctx context.Context, name, description string, permissions []string) error
+	Delete(ctx context.Context, name string) error
+	EnsureDefaults(ctx context.Context) error
+	Permissions(ctx context.Context, role string) ([]string, error)
+}
+
+type roleRepository struct {
+	col   *mongo.Collection
+	cache *roleCache
+}
+
+// cache dibagi satu proses, sehingga middleware dan service melihat data yang sama
+var sharedRoleCache = &roleCache{entries: map[string]roleCacheEntry{}}
+
+func NewRoleRepository(db *mongo.Database) RoleRepository {
+	return &roleRepository{
+		col:   db.Collection("roles"),
+		cache: sharedRoleCache,
+	}
+}
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]model.Role, error) {
+	cur, err := r.col.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var roles []model.Role
+	if err := cur.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*model.Role, error) {
+	var role model.Role
+	err := r.col.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *model.Role) error {
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = role.CreatedAt
+
+	_, err := r.col.InsertOne(ctx, role)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrRoleExists
+	}
+	if err != nil {
+		return err
+	}
+	r.cache.invalidate(role.Name)
+	return nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, name, description string, permissions []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"description": description,
+			"permissions": permissions,
+			"updated_at":  time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": name}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRoleNotFound
+	}
+	r.cache.invalidate(name)
+	return nil
+}
+
+// ✅ Role bawaan tidak ikut terhapus
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": name, "built_in": bson.M{"$ne": true}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrRoleNotFound
+	}
+	r.cache.invalidate(name)
+	return nil
+}
+
+// ✅ Seed role bawaan. Role yang sudah ada tidak ditimpa (perubahan admin tetap dipakai),
+// tapi permission bawaan yang ditambahkan di versi baru (belum ada di seeded_permissions)
+// disisipkan dengan $addToSet. Permission yang sengaja dicabut admin setelahnya tidak dikembalikan.
+func (r *roleRepository) EnsureDefaults(ctx context.Context) error {
+	now := time.Now()
+	for _, role := range model.DefaultRoles() {
+		update := bson.M{"$setOnInsert": bson.M{
+			"description":        role.Description,
+			"permissions":        role.Permissions,
+			"seeded_permissions": role.Permissions,
+			"built_in":           true,
+			"created_at":         now,
+			"updated_at":         now,
+		}}
+		res, err := r.col.UpdateOne(ctx, bson.M{"_id": role.Name}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		if res.UpsertedCount > 0 {
+			continue
+		}
+		if err := r.addMissingDefaults(ctx, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMissingDefaults menambahkan permission bawaan yang belum pernah diberikan ke role yang sudah ada
+func (r *roleRepository) addMissingDefaults(ctx context.Context, role model.Role) error {
+	existing, err := r.FindByName(ctx, role.Name)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	var missing []string
+	for _, perm := range role.Permissions {
+		if !model.HasPermission(existing.SeededPermissions, perm) {
+			missing = append(missing, perm)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	update := bson.M{
+		"$addToSet": bson.M{
+			"permissions":        bson.M{"$each": missing},
+			"seeded_permissions": bson.M{"$each": missing},
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	if _, err := r.col.UpdateOne(ctx, bson.M{"_id": role.Name}, update); err != nil {
+		return err
+	}
+	r.cache.invalidate(role.Name)
+	return nil
+}
+
+// ✅ Permission milik role (cache dulu, baru MongoDB).
+// Admin selalu punya semua permission agar tidak bisa terkunci dari sistem.
+func (r *roleRepository) Permissions(ctx context.Context, role string) ([]string, error) {
+	if role == model.RoleAdmin {
+		return model.AllPermissions(), nil
+	}
+	if perms, ok := r.cache.get(role); ok {
+		return perms, nil
+	}
+
+	doc, err := r.FindByName(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	var perms []string
+	if doc != nil {
+		perms = doc.Permissions
+	} else {
+		// belum di-seed: pakai permission bawaan (nil untuk role tak dikenal)
+		perms = model.DefaultPermissions(role)
+	}
+	r.cache.set(role, perms)
+	return perms, nil
+}
+
+type roleCacheEntry struct {
+	perms []string
+	until time.Time
+}
+
+type roleCache struct {
+	mu      sync.RWMutex
+	entries map[string]roleCacheEntry
+}
+
+func (c *roleCache) get(role string) ([]string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[role]
+	if !ok || time.Now().After(e.until) {
+		return nil, false
+	}
+	return e.perms, true
+}
+
+func (c *roleCache) set(role string, perms []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[role] = roleCacheEntry{perms: perms, until: time.Now().Add(roleCacheTTL)}
+}
+
+func (c *roleCache) invalidate(role string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, role)
+}
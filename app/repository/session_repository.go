@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// last_seen_at cukup diperbarui sekali per interval ini per sesi
+const sessionTouchInterval = time.Minute
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session) error
+	FindActiveByUser(ctx context.Context, userID primitive.ObjectID) ([]model.Session, error)
+	Refreshed(ctx context.Context, id primitive.ObjectID, ip, userAgent string, expiresAt time.Time) error
+	Touch(ctx context.Context, id primitive.ObjectID) error
+	Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type sessionRepository struct {
+	col     *mongo.Collection
+	touched *sync.Map // sid -> waktu terakhir last_seen_at ditulis
+}
+
+// dibagi satu proses, sehingga middleware dan service memakai throttle yang sama
+var sharedSessionTouches = &sync.Map{}
+
+func NewSessionRepository(db *mongo.Database) SessionRepository {
+	r := &sessionRepository{
+		col:     db.Collection("sessions"),
+		touched: sharedSessionTouches,
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// SessionRevocationKey kunci revocation list untuk seluruh access token milik satu sesi
+func SessionRevocationKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func (r *sessionRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index sessions:", err)
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *model.Session) error {
+	now := time.Now()
+	session.CreatedAt = now
+	session.LastSeenAt = now
+
+	_, err := r.col.InsertOne(ctx, session)
+	return err
+}
+
+// ✅ Sesi user yang masih berlaku, terbaru dipakai di atas
+func (r *sessionRepository) FindActiveByUser(ctx context.Context, userID primitive.ObjectID) ([]model.Session, error) {
+	filter := bson.M{"user_id": userID, "revoked_at": nil, "expires_at": bson.M{"$gt": time.Now()}}
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []model.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ✅ Catat rotasi refresh token: perangkat terakhir dan perpanjang masa berlaku
+func (r *sessionRepository) Refreshed(ctx context.Context, id primitive.ObjectID, ip, userAgent string, expiresAt time.Time) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": nil},
+		bson.M{"$set": bson.M{
+			"ip":           ip,
+			"user_agent":   userAgent,
+			"last_seen_at": time.Now(),
+			"expires_at":   expiresAt,
+		}},
+	)
+	return err
+}
+
+// ✅ Perbarui last_seen_at (paling sering sekali per sessionTouchInterval per sesi)
+func (r *sessionRepository) Touch(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	if last, ok := r.touched.Load(id); ok && now.Sub(last.(time.Time)) < sessionTouchInterval {
+		return nil
+	}
+	r.touched.Store(id, now)
+
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": nil},
+		bson.M{"$set": bson.M{"last_seen_at": now}},
+	)
+	return err
+}
+
+// ✅ Akhiri satu sesi milik user. false jika sesi tidak ada / bukan milik user / sudah berakhir.
+func (r *sessionRepository) Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	r.touched.Delete(id)
+	return res.MatchedCount > 0, nil
+}
+
+// ✅ Akhiri semua sesi user (sign out everywhere / reset password)
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.col.UpdateMany(ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrSurveyExists dikembalikan saat (code, version) kuesioner sudah dipakai
+	ErrSurveyExists = errors.New("kode & versi kuesioner sudah ada")
+	// ErrSurveyNotDraft dikembalikan saat mengubah / menerbitkan kuesioner yang bukan draft
+	ErrSurveyNotDraft = errors.New("kuesioner bukan draft")
+	// ErrAlreadyResponded dikembalikan saat alumni mengisi versi kuesioner yang sama dua kali
+	ErrAlreadyResponded = errors.New("alumni sudah mengisi kuesioner ini")
+	// ErrSurveyPublishConflict dikembalikan saat versi lain dengan code yang sama terbit bersamaan
+	ErrSurveyPublishConflict = errors.New("versi lain kuesioner ini sedang diterbitkan, muat ulang lalu coba lagi")
+)
+
+type SurveyRepository interface {
+	Create(ctx context.Context, s *model.Survey) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.Survey, error)
+	FindAll(ctx context.Context, code, status string) ([]model.Survey, error)
+	LatestVersion(ctx context.Context, code string) (int, error)
+	UpdateDraft(ctx context.Context, id primitive.ObjectID, req model.SurveyRequest) error
+	Publish(ctx context.Context, id primitive.ObjectID, code string) error
+	Close(ctx context.Context, id primitive.ObjectID) (bool, error)
+
+	CreateResponse(ctx context.Context, r *model.SurveyResponse) error
+	FindResponses(ctx context.Context, surveyID primitive.ObjectID) ([]model.SurveyResponse, error)
+	FindResponseByAlumni(ctx context.Context, surveyID, alumniID primitive.ObjectID) (*model.SurveyResponse, error)
+	Stats(ctx context.Context, surveyID primitive.ObjectID) (model.SurveyStats, error)
+}
+
+type surveyRepository struct {
+	col         *mongo.Collection
+	responseCol *mongo.Collection
+}
+
+func NewSurveyRepository(db *mongo.Database) SurveyRepository {
+	r := &surveyRepository{
+		col:         db.Collection("surveys"),
+		responseCol: db.Collection("survey_responses"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ✅ (code, version) unik, hanya satu versi terbit per code, dan satu alumni hanya sekali mengisi tiap versi
+func (r *surveyRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Println("⚠️  gagal membuat index surveys:", err)
+	}
+	if _, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("code_published_unique").
+			SetPartialFilterExpression(bson.M{"status": model.SurveyStatusPublished}),
+	}); err != nil {
+		log.Println("⚠️  gagal membuat index surveys (satu versi terbit per code):", err)
+	}
+	if _, err := r.responseCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "survey_id", Value: 1}, {Key: "alumni_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Println("⚠️  gagal membuat index survey_responses:", err)
+	}
+}
+
+// ================= SURVEY =================
+func (r *surveyRepository) Create(ctx context.Context, s *model.Survey) error {
+	if s.ID.IsZero() {
+		s.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	s.Status = model.SurveyStatusDraft
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	_, err := r.col.InsertOne(ctx, s)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrSurveyExists
+	}
+	return err
+}
+
+func (r *surveyRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Survey, error) {
+	var s model.Survey
+	err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// FindAll: filter code / status opsional, urut per code lalu versi terbaru dulu
+func (r *surveyRepository) FindAll(ctx context.Context, code, status string) ([]model.Survey, error) {
+	filter := bson.M{}
+	if code != "" {
+		filter["code"] = code
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}, {Key: "version", Value: -1}})
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	list := []model.Survey{}
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// LatestVersion versi tertinggi untuk code, 0 jika belum ada
+func (r *surveyRepository) LatestVersion(ctx context.Context, code string) (int, error) {
+	var s model.Survey
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	err := r.col.FindOne(ctx, bson.M{"code": code}, opts).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return s.Version, nil
+}
+
+func (r *surveyRepository) UpdateDraft(ctx context.Context, id primitive.ObjectID, req model.SurveyRequest) error {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.SurveyStatusDraft},
+		bson.M{"$set": bson.M{
+			"title":       req.Title,
+			"description": req.Description,
+			"questions":   req.Questions,
+			"updated_at":  time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrSurveyNotDraft
+	}
+	return nil
+}
+
+// Publish menerbitkan draft dan menutup versi lain dengan code yang sama,
+// jadi hanya satu versi yang bisa diisi alumni pada satu waktu. Versi lama ditutup lebih dulu
+// (index unik parsial hanya mengizinkan satu versi terbit per code) dan dibuka lagi jika
+// draft gagal diterbitkan, supaya code tidak tertinggal tanpa versi yang terbit.
+func (r *surveyRepository) Publish(ctx context.Context, id primitive.ObjectID, code string) error {
+	n, err := r.col.CountDocuments(ctx, bson.M{"_id": id, "status": model.SurveyStatusDraft})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSurveyNotDraft
+	}
+
+	var current struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = r.col.FindOne(ctx,
+		bson.M{"code": code, "_id": bson.M{"$ne": id}, "status": model.SurveyStatusPublished},
+		options.FindOne().SetProjection(bson.M{"_id": 1}),
+	).Decode(&current)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	now := time.Now()
+	closed := false
+	if !current.ID.IsZero() {
+		res, err := r.col.UpdateOne(ctx,
+			bson.M{"_id": current.ID, "status": model.SurveyStatusPublished},
+			bson.M{"$set": bson.M{"status": model.SurveyStatusClosed, "closed_at": now, "updated_at": now}},
+		)
+		if err != nil {
+			return err
+		}
+		closed = res.ModifiedCount == 1
+	}
+
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.SurveyStatusDraft},
+		bson.M{"$set": bson.M{"status": model.SurveyStatusPublished, "published_at": now, "updated_at": now}},
+	)
+	if err == nil && res.MatchedCount == 0 {
+		err = ErrSurveyNotDraft
+	}
+	if err != nil {
+		if closed {
+			r.reopen(ctx, current.ID, now)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrSurveyPublishConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// reopen membatalkan penutupan versi oleh Publish yang gagal. Hanya berlaku jika versi itu
+// masih tertutup oleh Publish yang sama (closed_at sama); gagal dibuka hanya dicatat.
+func (r *surveyRepository) reopen(ctx context.Context, id primitive.ObjectID, closedAt time.Time) {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.SurveyStatusClosed, "closed_at": closedAt},
+		bson.M{"$set": bson.M{"status": model.SurveyStatusPublished, "updated_at": time.Now()}, "$unset": bson.M{"closed_at": ""}},
+	)
+	if err != nil {
+		log.Printf("⚠️  gagal membuka kembali kuesioner %s setelah publish gagal: %v", id.Hex(), err)
+	}
+}
+
+func (r *surveyRepository) Close(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	now := time.Now()
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "status": model.SurveyStatusPublished},
+		bson.M{"$set": bson.M{"status": model.SurveyStatusClosed, "closed_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// ================= RESPONSES =================
+func (r *surveyRepository) CreateResponse(ctx context.Context, resp *model.SurveyResponse) error {
+	if resp.ID.IsZero() {
+		resp.ID = primitive.NewObjectID()
+	}
+	resp.SubmittedAt = time.Now()
+
+	_, err := r.responseCol.InsertOne(ctx, resp)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyResponded
+	}
+	return err
+}
+
+func (r *surveyRepository) FindResponses(ctx context.Context, surveyID primitive.ObjectID) ([]model.SurveyResponse, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "submitted_at", Value: 1}})
+	cur, err := r.responseCol.Find(ctx, bson.M{"survey_id": surveyID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	list := []model.SurveyResponse{}
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *surveyRepository) FindResponseByAlumni(ctx context.Context, surveyID, alumniID primitive.ObjectID) (*model.SurveyResponse, error) {
+	var resp model.SurveyResponse
+	err := r.responseCol.FindOne(ctx, bson.M{"survey_id": surveyID, "alumni_id": alumniID}).Decode(&resp)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats menghitung jumlah jawaban per pertanyaan, per opsi, dan per nilai skala dalam satu aggregate
+func (r *surveyRepository) Stats(ctx context.Context, surveyID primitive.ObjectID) (model.SurveyStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"survey_id": surveyID}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": []bson.M{{"$count": "n"}},
+			"answered": []bson.M{
+				{"$unwind": "$answers"},
+				{"$group": bson.M{"_id": "$answers.question_id", "n": bson.M{"$sum": 1}}},
+			},
+			"choices": []bson.M{
+				{"$unwind": "$answers"},
+				{"$unwind": "$answers.choices"},
+				{"$group": bson.M{
+					"_id": bson.M{"q": "$answers.question_id", "v": "$answers.choices"},
+					"n":   bson.M{"$sum": 1},
+				}},
+			},
+			"scales": []bson.M{
+				{"$unwind": "$answers"},
+				{"$match": bson.M{"answers.scale": bson.M{"$ne": nil}}},
+				{"$group": bson.M{
+					"_id": bson.M{"q": "$answers.question_id", "v": "$answers.scale"},
+					"n":   bson.M{"$sum": 1},
+				}},
+			},
+		}}},
+	}
+
+	stats := model.SurveyStats{
+		Answered: map[string]int{},
+		Choices:  map[string]map[string]int{},
+		Scales:   map[string]map[int]int{},
+	}
+
+	cur, err := r.responseCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return stats, err
+	}
+	defer cur.Close(ctx)
+
+	var res []struct {
+		Total []struct {
+			N int `bson:"n"`
+		} `bson:"total"`
+		Answered []struct {
+			Q string `bson:"_id"`
+			N int    `bson:"n"`
+		} `bson:"answered"`
+		Choices []struct {
+			ID struct {
+				Q string `bson:"q"`
+				V string `bson:"v"`
+			} `bson:"_id"`
+			N int `bson:"n"`
+		} `bson:"choices"`
+		Scales []struct {
+			ID struct {
+				Q string `bson:"q"`
+				V int    `bson:"v"`
+			} `bson:"_id"`
+			N int `bson:"n"`
+		} `bson:"scales"`
+	}
+	if err := cur.All(ctx, &res); err != nil {
+		return stats, err
+	}
+	if len(res) == 0 {
+		return stats, nil
+	}
+
+	f := res[0]
+	if len(f.Total) > 0 {
+		stats.TotalResponses = f.Total[0].N
+	}
+	for _, a := range f.Answered {
+		stats.Answered[a.Q] = a.N
+	}
+	for _, ch := range f.Choices {
+		if stats.Choices[ch.ID.Q] == nil {
+			stats.Choices[ch.ID.Q] = map[string]int{}
+		}
+		stats.Choices[ch.ID.Q][ch.ID.V] = ch.N
+	}
+	for _, sc := range f.Scales {
+		if stats.Scales[sc.ID.Q] == nil {
+			stats.Scales[sc.ID.Q] = map[int]int{}
+		}
+		stats.Scales[sc.ID.Q][sc.ID.V] = sc.N
+	}
+	return stats, nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Hasil "tidak dicabut" hanya di-cache sebentar agar logout dari instance lain
+// tetap berlaku paling lambat setelah durasi ini.
+const revocationNegativeCacheTTL = 10 * time.Second
+
+type TokenRevocationRepository interface {
+	Revoke(ctx context.Context, jti string, userID primitive.ObjectID, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error
+	IsUserRevoked(ctx context.Context, userID primitive.ObjectID, issuedAt time.Time) (bool, error)
+}
+
+type tokenRevocationRepository struct {
+	col   *mongo.Collection
+	cache *revocationCache
+}
+
+// cache dibagi satu proses, sehingga middleware dan service melihat data yang sama
+var sharedRevocationCache = &revocationCache{entries: map[string]revocationEntry{}}
+
+func NewTokenRevocationRepository(db *mongo.Database) TokenRevocationRepository {
+	r := &tokenRevocationRepository{
+		col:   db.Collection("revoked_tokens"),
+		cache: sharedRevocationCache,
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ✅ Entri dihapus MongoDB begitu token aslinya expired
+func (r *tokenRevocationRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index revoked_tokens:", err)
+	}
+}
+
+// ✅ Masukkan jti ke revocation list sampai token aslinya expired
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, jti string, userID primitive.ObjectID, expiresAt time.Time) error {
+	doc := model.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		RevokedAt: time.Now(),
+	}
+	_, err := r.col.ReplaceOne(ctx, bson.M{"_id": jti}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	r.cache.set(jti, true, expiresAt)
+	return nil
+}
+
+// ✅ Cek apakah jti sudah dicabut (cache dulu, baru MongoDB)
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if revoked, ok := r.cache.get(jti); ok {
+		return revoked, nil
+	}
+
+	var doc model.RevokedToken
+	err := r.col.FindOne(ctx, bson.M{"_id": jti}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		r.cache.set(jti, false, time.Now().Add(revocationNegativeCacheTTL))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	r.cache.set(jti, true, doc.ExpiresAt)
+	return true, nil
+}
+
+// ✅ Cabut semua token user yang diterbitkan sebelum notBefore
+func (r *tokenRevocationRepository) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error {
+	// iat di JWT berpresisi detik
+	notBefore = notBefore.Truncate(time.Second)
+	key := userRevocationKey(userID)
+
+	doc := model.RevokedToken{
+		JTI:       key,
+		UserID:    userID,
+		NotBefore: &notBefore,
+		// setelah ini semua token lama sudah expired dengan sendirinya
+		ExpiresAt: notBefore.Add(utils.AccessTokenTTL),
+		RevokedAt: time.Now(),
+	}
+	_, err := r.col.ReplaceOne(ctx, bson.M{"_id": key}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	r.cache.setNotBefore(key, notBefore, doc.ExpiresAt)
+	return nil
+}
+
+// ✅ Cek apakah token user (berdasarkan iat) terkena pencabutan massal
+func (r *tokenRevocationRepository) IsUserRevoked(ctx context.Context, userID primitive.ObjectID, issuedAt time.Time) (bool, error) {
+	key := userRevocationKey(userID)
+	if notBefore, ok := r.cache.getNotBefore(key); ok {
+		return !notBefore.IsZero() && issuedAt.Before(notBefore), nil
+	}
+
+	var doc model.RevokedToken
+	err := r.col.FindOne(ctx, bson.M{"_id": key}).Decode(&doc)
+	if err == mongo.ErrNoDocuments || (err == nil && doc.NotBefore == nil) {
+		r.cache.setNotBefore(key, time.Time{}, time.Now().Add(revocationNegativeCacheTTL))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	r.cache.setNotBefore(key, *doc.NotBefore, doc.ExpiresAt)
+	return issuedAt.Before(*doc.NotBefore), nil
+}
+
+func userRevocationKey(userID primitive.ObjectID) string {
+	return "user:" + userID.Hex()
+}
+
+// ================= IN-MEMORY CACHE =================
+type revocationEntry struct {
+	revoked   bool
+	notBefore time.Time // hanya untuk entri "user:<id>"
+	until     time.Time
+}
+
+type revocationCache struct {
+	mu        sync.RWMutex
+	entries   map[string]revocationEntry
+	lastSweep time.Time
+}
+
+func (c *revocationCache) get(key string) (bool, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.until) {
+		return false, false
+	}
+	return e.revoked, true
+}
+
+func (c *revocationCache) getNotBefore(key string) (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.until) {
+		return time.Time{}, false
+	}
+	return e.notBefore, true
+}
+
+func (c *revocationCache) set(key string, revoked bool, until time.Time) {
+	c.put(key, revocationEntry{revoked: revoked, until: until})
+}
+
+func (c *revocationCache) setNotBefore(key string, notBefore, until time.Time) {
+	c.put(key, revocationEntry{notBefore: notBefore, until: until})
+}
+
+func (c *revocationCache) put(key string, entry revocationEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+
+	// buang entri kadaluarsa paling sering sekali per menit
+	now := time.Now()
+	if now.Sub(c.lastSweep) > time.Minute {
+		for k, e := range c.entries {
+			if now.After(e.until) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+}
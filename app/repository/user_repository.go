@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"log"
+	"regexp"
 	"time"
 
 	"praktikum3/app/model"
@@ -9,12 +12,46 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrUserExists dikembalikan saat username atau email sudah dipakai user lain
+var ErrUserExists = errors.New("username atau email sudah digunakan")
+
+// ErrAlumniLinked dikembalikan saat data alumni sudah terhubung ke akun lain
+var ErrAlumniLinked = errors.New("data alumni sudah terhubung dengan akun lain")
+
+// ErrUserLinked dikembalikan saat akun sudah terhubung ke data alumni
+var ErrUserLinked = errors.New("akun sudah terhubung dengan data alumni")
+
+// ErrTOTPEnabled dikembalikan saat 2FA sudah aktif (atau setup belum dimulai)
+var ErrTOTPEnabled = errors.New("status 2FA akun tidak sesuai")
+
 // ✅ Interface (kontrak) untuk dipakai di layer service
 type IUserRepository interface {
 	FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*model.User, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.User, error)
+	FindAll(ctx context.Context, search string, limit, offset int) ([]model.User, error)
+	Count(ctx context.Context, search string) (int, error)
+	CountByRole(ctx context.Context, role string) (int, error)
+	Create(ctx context.Context, user *model.User) error
+	MarkEmailVerified(ctx context.Context, id primitive.ObjectID) error
+	UpdateRole(ctx context.Context, id primitive.ObjectID, role string) error
+	UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error
+	UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error
+	FindByAlumniID(ctx context.Context, alumniID primitive.ObjectID) (*model.User, error)
+	LinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error
+	UnlinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error
+	SetTOTPSecret(ctx context.Context, id primitive.ObjectID, secret string) error
+	EnableTOTP(ctx context.Context, id primitive.ObjectID, step int64, recoveryHashes []string) error
+	DisableTOTP(ctx context.Context, id primitive.ObjectID) error
+	UseTOTPStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error)
 	SoftDeleteUser(ctx context.Context, id primitive.ObjectID) error
+	GetTrashed(ctx context.Context) ([]model.User, error)
+	FindTrashedByID(ctx context.Context, id primitive.ObjectID) (*model.User, error)
+	Restore(ctx context.Context, id primitive.ObjectID) error
+	HardDelete(ctx context.Context, id primitive.ObjectID) error
 }
 
 // ✅ Implementasi interface di bawah
@@ -24,12 +61,34 @@ type userRepository struct {
 
 // ✅ Constructor — mengembalikan interface (bukan struct langsung)
 func NewUserRepository(db *mongo.Database) IUserRepository {
-	return &userRepository{
+	r := &userRepository{
 		col: db.Collection("users"),
 	}
+	r.ensureIndexes()
+	return r
+}
+
+// ✅ Index unik untuk username, email & alumni_id (idempotent, aman dipanggil berulang)
+func (r *userRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "username", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// satu data alumni hanya boleh dimiliki satu akun; user tanpa alumni_id tidak ikut di-index
+		{
+			Keys: bson.D{{Key: "alumni_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"alumni_id": bson.M{"$type": "objectId"}}),
+		},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index users:", err)
+	}
 }
 
-// ✅ Cari user berdasarkan username atau email
+// ✅ Cari user berdasarkan username atau email (user yang di-soft delete diabaikan)
 func (r *userRepository) FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*model.User, error) {
 	var user model.User
 	err := r.col.FindOne(ctx, bson.M{
@@ -37,6 +96,7 @@ func (r *userRepository) FindByUsernameOrEmail(ctx context.Context, usernameOrEm
 			{"username": usernameOrEmail},
 			{"email": usernameOrEmail},
 		},
+		"deleted_at": nil,
 	}).Decode(&user)
 
 	if err != nil {
@@ -49,6 +109,304 @@ func (r *userRepository) FindByUsernameOrEmail(ctx context.Context, usernameOrEm
 	return &user, nil
 }
 
+// ✅ Cari user aktif (belum di-soft delete) berdasarkan ObjectID
+func (r *userRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	var user model.User
+	err := r.col.FindOne(ctx, bson.M{"_id": id, "deleted_at": nil}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// filter user aktif, dengan pencarian opsional pada username/email
+func activeUserFilter(search string) bson.M {
+	filter := bson.M{"deleted_at": nil}
+	if search != "" {
+		pattern := regexp.QuoteMeta(search)
+		filter["$or"] = []bson.M{
+			{"username": bson.M{"$regex": pattern, "$options": "i"}},
+			{"email": bson.M{"$regex": pattern, "$options": "i"}},
+		}
+	}
+	return filter
+}
+
+// ✅ List user aktif dengan pencarian & paginasi
+func (r *userRepository) FindAll(ctx context.Context, search string, limit, offset int) ([]model.User, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cur, err := r.col.Find(ctx, activeUserFilter(search), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var users []model.User
+	if err := cur.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ✅ Hitung user aktif (untuk meta paginasi)
+func (r *userRepository) Count(ctx context.Context, search string) (int, error) {
+	count, err := r.col.CountDocuments(ctx, activeUserFilter(search))
+	return int(count), err
+}
+
+// ✅ Simpan user baru, username & email wajib unik
+func (r *userRepository) Create(ctx context.Context, user *model.User) error {
+	count, err := r.col.CountDocuments(ctx, bson.M{
+		"$or": []bson.M{
+			{"username": user.Username},
+			{"email": user.Email},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrUserExists
+	}
+
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	_, err = r.col.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		// race dengan request register lain, tertahan oleh unique index
+		return ErrUserExists
+	}
+	return err
+}
+
+// ✅ Tandai email user sudah diverifikasi dan aktifkan akun
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":            model.UserStatusActive,
+			"email_verified_at": time.Now(),
+			"updated_at":        time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Ganti role user aktif
+func (r *userRepository) UpdateRole(ctx context.Context, id primitive.ObjectID, role string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"role":       role,
+			"updated_at": time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Simpan hash password baru
+func (r *userRepository) UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"password_hash":       passwordHash,
+			"password_changed_at": time.Now(),
+			"updated_at":          time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Ganti email akun setelah alamat baru dikonfirmasi lewat token, jadi langsung ditandai terverifikasi.
+func (r *userRepository) UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error {
+	count, err := r.col.CountDocuments(ctx, bson.M{"email": email, "_id": bson.M{"$ne": id}})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrUserExists
+	}
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"email": email, "email_verified_at": now, "updated_at": now}}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrUserExists
+	}
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Jumlah user (termasuk yang di trash) yang memakai role tertentu
+func (r *userRepository) CountByRole(ctx context.Context, role string) (int, error) {
+	n, err := r.col.CountDocuments(ctx, bson.M{"role": role})
+	return int(n), err
+}
+
+// ✅ Cari user aktif yang terhubung ke data alumni tertentu
+func (r *userRepository) FindByAlumniID(ctx context.Context, alumniID primitive.ObjectID) (*model.User, error) {
+	var user model.User
+	err := r.col.FindOne(ctx, bson.M{"alumni_id": alumniID, "deleted_at": nil}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ✅ Hubungkan user ke data alumni. Hanya berhasil jika user belum terhubung;
+// alumni yang sudah dimiliki akun lain ditolak oleh index unik.
+func (r *userRepository) LinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"alumni_id":  alumniID,
+			"updated_at": time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "alumni_id": nil, "deleted_at": nil}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlumniLinked
+		}
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrUserLinked
+	}
+	return nil
+}
+
+// ✅ Lepas hubungan user dengan data alumni, hanya jika masih terhubung ke alumni yang sama
+func (r *userRepository) UnlinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error {
+	update := bson.M{
+		"$unset": bson.M{"alumni_id": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "alumni_id": alumniID}, update)
+	return err
+}
+
+// ✅ Simpan secret TOTP baru (belum aktif). Ditolak jika 2FA sudah aktif.
+func (r *userRepository) SetTOTPSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"totp_secret": secret,
+			"updated_at":  time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "totp_enabled": bson.M{"$ne": true}, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrTOTPEnabled
+	}
+	return nil
+}
+
+// ✅ Aktifkan 2FA sekaligus simpan hash kode pemulihan
+func (r *userRepository) EnableTOTP(ctx context.Context, id primitive.ObjectID, step int64, recoveryHashes []string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"totp_enabled":   true,
+			"totp_last_step": step,
+			"recovery_codes": recoveryHashes,
+			"updated_at":     time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "totp_secret": bson.M{"$exists": true}, "totp_enabled": bson.M{"$ne": true}, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrTOTPEnabled
+	}
+	return nil
+}
+
+// ✅ Matikan 2FA dan hapus secret + kode pemulihan
+func (r *userRepository) DisableTOTP(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$unset": bson.M{
+			"totp_secret":    "",
+			"totp_enabled":   "",
+			"totp_last_step": "",
+			"recovery_codes": "",
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ Tandai langkah waktu TOTP sudah dipakai. false berarti kode ini (atau yang lebih baru)
+// sudah pernah dipakai, sehingga kode yang sama tidak bisa di-replay.
+func (r *userRepository) UseTOTPStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error) {
+	filter := bson.M{"_id": id, "$or": bson.A{
+		bson.M{"totp_last_step": bson.M{"$exists": false}},
+		bson.M{"totp_last_step": bson.M{"$lt": step}},
+	}}
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totp_last_step": step}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// ✅ Pakai satu kode pemulihan (dihapus dari daftar). false jika kode tidak ada.
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "recovery_codes": codeHash},
+		bson.M{"$pull": bson.M{"recovery_codes": codeHash}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 // ✅ Soft delete user berdasarkan ObjectID
 func (r *userRepository) SoftDeleteUser(ctx context.Context, id primitive.ObjectID) error {
 	update := bson.M{
@@ -57,6 +415,70 @@ func (r *userRepository) SoftDeleteUser(ctx context.Context, id primitive.Object
 			"updated_at": time.Now(),
 		},
 	}
-	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
-	return err
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// ✅ List user di trash (soft delete)
+func (r *userRepository) GetTrashed(ctx context.Context) ([]model.User, error) {
+	cur, err := r.col.Find(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var users []model.User
+	if err := cur.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ✅ Cari user di trash berdasarkan ObjectID
+func (r *userRepository) FindTrashedByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	var user model.User
+	err := r.col.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ✅ Kembalikan user dari trash
+func (r *userRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user tidak ditemukan di trash")
+	}
+	return nil
+}
+
+// ✅ Hapus user permanen
+func (r *userRepository) HardDelete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
 }
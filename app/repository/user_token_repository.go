@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *model.UserToken) error
+	FindValid(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error)
+	MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error)
+	Release(ctx context.Context, id primitive.ObjectID) error
+	DeleteByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error
+}
+
+type userTokenRepository struct {
+	col *mongo.Collection
+}
+
+func NewUserTokenRepository(db *mongo.Database) UserTokenRepository {
+	r := &userTokenRepository{
+		col: db.Collection("user_tokens"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ✅ Token yang sudah expired dihapus otomatis oleh MongoDB (TTL index)
+func (r *userTokenRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Println("⚠️  gagal membuat index user_tokens:", err)
+	}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *model.UserToken) error {
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	token.CreatedAt = time.Now()
+
+	_, err := r.col.InsertOne(ctx, token)
+	return err
+}
+
+// ✅ Ambil token yang belum dipakai dan belum expired
+func (r *userTokenRepository) FindValid(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+	var t model.UserToken
+	err := r.col.FindOne(ctx, bson.M{
+		"purpose":    purpose,
+		"token_hash": tokenHash,
+		"used_at":    nil,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ✅ Tandai token sudah dipakai. false berarti token sudah lebih dulu dipakai request lain.
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "used_at": nil},
+		bson.M{"$set": bson.M{"used_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// ✅ Batalkan tanda pakai token (misal proses setelah MarkUsed gagal), agar token bisa dicoba lagi
+func (r *userTokenRepository) Release(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "used_at": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"used_at": ""}},
+	)
+	return err
+}
+
+// ✅ Hapus semua token user untuk tujuan tertentu (misal saat token baru diterbitkan)
+func (r *userTokenRepository) DeleteByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"user_id": userID, "purpose": purpose})
+	return err
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnyVersion dipakai untuk If-Match: * (tulis tanpa cek versi)
+const AnyVersion int64 = -1
+
+// ErrVersionConflict dikembalikan saat versi dokumen sudah berubah sejak dibaca client
+var ErrVersionConflict = errors.New("data sudah diubah oleh pengguna lain, muat ulang lalu coba lagi")
+
+// ErrNotFound dikembalikan saat dokumen yang mau diubah tidak ada
+var ErrNotFound = errors.New("data tidak ditemukan")
+
+// ErrNotOwner dikembalikan saat dokumen ada tapi bukan milik user yang mengubahnya
+var ErrNotOwner = errors.New("data bukan milik user")
+
+// withVersion menambahkan syarat versi ke filter update.
+// Dokumen lama yang belum punya field version dianggap versi 0.
+func withVersion(filter bson.M, version int64) bson.M {
+	switch {
+	case version == AnyVersion:
+	case version == 0:
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	default:
+		filter["version"] = version
+	}
+	return filter
+}
+
+// versionMismatch dipanggil saat update dengan syarat versi tidak mengenai dokumen apa pun:
+// kalau dokumennya ada berarti versinya sudah berubah, selain itu memang tidak ditemukan.
+func versionMismatch(ctx context.Context, col *mongo.Collection, filter bson.M) error {
+	delete(filter, "version")
+	n, err := col.CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrVersionConflict
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlumniClaimService mengelola alur klaim: user mengajukan data alumni miliknya,
+// admin menyetujui / menolak. Klaim yang disetujui mengisi users.alumni_id.
+type AlumniClaimService struct {
+	claimRepo  repository.AlumniClaimRepository
+	userRepo   repository.IUserRepository
+	alumniRepo repository.AlumniRepository
+}
+
+func NewAlumniClaimService(claimRepo repository.AlumniClaimRepository, userRepo repository.IUserRepository, alumniRepo repository.AlumniRepository) *AlumniClaimService {
+	return &AlumniClaimService{claimRepo: claimRepo, userRepo: userRepo, alumniRepo: alumniRepo}
+}
+
+// Create godoc
+// @Summary Ajukan klaim data alumni
+// @Description User mengajukan permintaan agar akunnya dihubungkan dengan data alumni tertentu. Klaim harus disetujui admin.
+// @Tags Alumni Claim
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.CreateAlumniClaimRequest true "Data alumni yang diklaim"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,401,404,409,500 {object} map[string]interface{}
+// @Router /alumni-claims/ [post]
+func (s *AlumniClaimService) Create(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	var req model.CreateAlumniClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+	alumniID, err := primitive.ObjectIDFromHex(strings.TrimSpace(req.AlumniID))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "alumni_id tidak valid"})
+	}
+
+	ctx := context.Background()
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if user == nil {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+	if user.AlumniID != nil {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": repository.ErrUserLinked.Error()})
+	}
+
+	if status, msg := s.checkClaimable(ctx, alumniID, userID); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+
+	claim := &model.AlumniClaim{
+		UserID:   userID,
+		AlumniID: alumniID,
+		Message:  strings.TrimSpace(req.Message),
+	}
+	if err := s.claimRepo.Create(ctx, claim); err != nil {
+		if errors.Is(err, repository.ErrClaimPending) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Klaim berhasil diajukan, menunggu persetujuan admin",
+		"data":    claim,
+	})
+}
+
+// GetMine godoc
+// @Summary Riwayat klaim alumni saya
+// @Description Mengambil semua klaim alumni yang pernah diajukan user login
+// @Tags Alumni Claim
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401,500 {object} map[string]interface{}
+// @Router /alumni-claims/me [get]
+func (s *AlumniClaimService) GetMine(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	data, err := s.claimRepo.FindByUser(context.Background(), userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// GetAll godoc
+// @Summary Get semua klaim alumni
+// @Description Mengambil daftar klaim alumni, bisa difilter status (khusus admin)
+// @Tags Alumni Claim
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "pending / approved / rejected"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /alumni-claims/ [get]
+func (s *AlumniClaimService) GetAll(c *fiber.Ctx) error {
+	status := c.Query("status", "")
+	switch status {
+	case "", model.ClaimStatusPending, model.ClaimStatusApproved, model.ClaimStatusRejected:
+	default:
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "status tidak valid"})
+	}
+
+	data, err := s.claimRepo.FindAll(context.Background(), status)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// Approve godoc
+// @Summary Setujui klaim alumni
+// @Description Menghubungkan akun pengaju dengan data alumni yang diklaim (khusus admin). User perlu refresh token / login ulang agar alumni_id masuk ke token.
+// @Tags Alumni Claim
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Klaim"
+// @Param body body model.ReviewAlumniClaimRequest false "Catatan admin"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401,404,409,500 {object} map[string]interface{}
+// @Router /alumni-claims/{id}/approve [put]
+func (s *AlumniClaimService) Approve(c *fiber.Ctx) error {
+	claim, reviewer, note, err := s.loadPendingClaim(c)
+	if claim == nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if status, msg := s.checkClaimable(ctx, claim.AlumniID, claim.UserID); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+
+	// link dulu: index unik users.alumni_id mencegah satu alumni dimiliki dua akun
+	if err := s.userRepo.LinkAlumni(ctx, claim.UserID, claim.AlumniID); err != nil {
+		if errors.Is(err, repository.ErrAlumniLinked) || errors.Is(err, repository.ErrUserLinked) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	// klaim bisa lebih dulu diproses admin lain (misal ditolak); link dibatalkan agar tetap konsisten
+	ok, err := s.claimRepo.Resolve(ctx, claim.ID, model.ClaimStatusApproved, reviewer, note)
+	if err != nil || !ok {
+		if uerr := s.userRepo.UnlinkAlumni(ctx, claim.UserID, claim.AlumniID); uerr != nil {
+			log.Printf("klaim %s: gagal membatalkan link alumni: %v", claim.ID.Hex(), uerr)
+		}
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Klaim sudah diproses"})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Klaim disetujui, akun berhasil dihubungkan dengan data alumni"})
+}
+
+// Reject godoc
+// @Summary Tolak klaim alumni
+// @Description Menolak klaim alumni (khusus admin)
+// @Tags Alumni Claim
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Klaim"
+// @Param body body model.ReviewAlumniClaimRequest false "Alasan penolakan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401,404,409,500 {object} map[string]interface{}
+// @Router /alumni-claims/{id}/reject [put]
+func (s *AlumniClaimService) Reject(c *fiber.Ctx) error {
+	claim, reviewer, note, err := s.loadPendingClaim(c)
+	if claim == nil {
+		return err
+	}
+
+	ok, err := s.claimRepo.Resolve(context.Background(), claim.ID, model.ClaimStatusRejected, reviewer, note)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if !ok {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Klaim sudah diproses"})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Klaim ditolak"})
+}
+
+// loadPendingClaim membaca :id, body review, dan memastikan klaim masih pending.
+// claim == nil berarti response error sudah dikirim.
+func (s *AlumniClaimService) loadPendingClaim(c *fiber.Ctx) (*model.AlumniClaim, primitive.ObjectID, string, error) {
+	_, reviewer, ok := currentClaims(c)
+	if !ok {
+		return nil, reviewer, "", c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, reviewer, "", c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	var req model.ReviewAlumniClaimRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return nil, reviewer, "", c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+		}
+	}
+
+	claim, err := s.claimRepo.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, reviewer, "", c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if claim == nil {
+		return nil, reviewer, "", c.Status(404).JSON(fiber.Map{"success": false, "message": "Klaim tidak ditemukan"})
+	}
+	if claim.Status != model.ClaimStatusPending {
+		return nil, reviewer, "", c.Status(409).JSON(fiber.Map{"success": false, "message": "Klaim sudah diproses"})
+	}
+	return claim, reviewer, strings.TrimSpace(req.Note), nil
+}
+
+// checkClaimable memastikan data alumni ada dan belum dimiliki akun lain.
+// Mengembalikan status HTTP != 0 beserta pesan jika tidak bisa diklaim.
+func (s *AlumniClaimService) checkClaimable(ctx context.Context, alumniID, userID primitive.ObjectID) (int, string) {
+	alumni, err := s.alumniRepo.GetByID(alumniID)
+	if err != nil {
+		return 500, err.Error()
+	}
+	if alumni == nil {
+		return 404, "Data alumni tidak ditemukan"
+	}
+
+	owner, err := s.userRepo.FindByAlumniID(ctx, alumniID)
+	if err != nil {
+		return 500, err.Error()
+	}
+	if owner != nil && owner.ID != userID {
+		return 409, repository.ErrAlumniLinked.Error()
+	}
+	return 0, ""
+}
+
+// linkedAlumniID mengambil alumni_id milik user login (dari token).
+// false jika akun belum terhubung dengan data alumni.
+func linkedAlumniID(c *fiber.Ctx) (primitive.ObjectID, bool) {
+	var raw string
+	switch u := c.Locals("user").(type) {
+	case *model.User:
+		if u.AlumniID != nil {
+			return *u.AlumniID, true
+		}
+	case map[string]interface{}:
+		raw, _ = u["alumni_id"].(string)
+	}
+
+	id, err := primitive.ObjectIDFromHex(raw)
+	if err != nil {
+		return primitive.NilObjectID, false
+	}
+	return id, true
+}
@@ -1,85 +1,209 @@
 package service
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"praktikum3/app/model"
 	"praktikum3/app/repository"
+	"praktikum3/app/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AlumniService struct {
-	alumniRepo repository.AlumniRepository
+	alumniRepo    repository.AlumniRepository
+	pekerjaanRepo repository.PekerjaanRepository
 }
 
-func NewAlumniService(repo repository.AlumniRepository) *AlumniService {
-	return &AlumniService{alumniRepo: repo}
+func NewAlumniService(repo repository.AlumniRepository, pekerjaanRepo repository.PekerjaanRepository) *AlumniService {
+	return &AlumniService{alumniRepo: repo, pekerjaanRepo: pekerjaanRepo}
 }
 
 // GetAll godoc
 // @Summary Get semua alumni
-// @Description Mengambil semua data alumni aktif
+// @Description Mengambil data alumni aktif dengan paginasi, urutan, pencarian (nama/NIM/email), dan filter jurusan, angkatan, tahun_lulus.
+// @Description Kirim parameter cursor (kosong untuk halaman pertama) untuk mode cursor: page diabaikan dan halaman berikutnya diambil dengan meta.next_cursor.
 // @Tags Alumni
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Produce json
+// @Param search query string false "Cari nama / NIM / email"
+// @Param jurusan query string false "Filter jurusan (persis)"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param sortBy query string false "Urutkan berdasarkan" Enums(nama, nim, jurusan, angkatan, tahun_lulus, created_at, updated_at) default(created_at)
+// @Param order query string false "Arah urutan" Enums(ASC, DESC) default(DESC)
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(10)
+// @Param cursor query string false "Cursor dari meta.next_cursor (mode cursor)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
 // @Router /alumni/ [get]
 func (s *AlumniService) GetAll(c *fiber.Ctx) error {
-	data, err := s.alumniRepo.GetAll()
+	q := model.AlumniQuery{
+		Search:  strings.TrimSpace(c.Query("search", "")),
+		Jurusan: strings.TrimSpace(c.Query("jurusan", "")),
+		SortBy:  c.Query("sortBy", "created_at"),
+		Order:   strings.ToUpper(c.Query("order", "DESC")),
+	}
+	if !contains(model.AlumniSortFields, q.SortBy) {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "sortBy harus salah satu dari: " + strings.Join(model.AlumniSortFields, ", ")})
+	}
+	if q.Order != "ASC" && q.Order != "DESC" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "order harus ASC atau DESC"})
+	}
+
+	var err error
+	if q.Angkatan, err = queryYear(c, "angkatan"); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if q.TahunLulus, err = queryYear(c, "tahun_lulus"); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	page, limit, err := queryPagination(c, model.MaxAlumniLimit)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	q.Limit = limit
+	q.Offset = (page - 1) * limit
+
+	if cursor, ok := cursorParam(c); ok {
+		data, next, err := s.alumniRepo.GetAllByCursor(q, cursor)
+		if err != nil {
+			return cursorFailed(c, err)
+		}
+		return cursorPage(c, data, next, model.CursorMeta{Limit: limit, SortBy: q.SortBy, Order: q.Order, Search: q.Search})
+	}
+
+	data, err := s.alumniRepo.GetAll(q)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
-	return c.JSON(fiber.Map{"success": true, "data": data})
+
+	total, err := s.alumniRepo.Count(q)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: q.SortBy,
+			Order:  q.Order,
+			Search: q.Search,
+		},
+	})
+}
+
+// queryYear membaca parameter tahun opsional; kosong berarti 0 (tanpa filter)
+func queryYear(c *fiber.Ctx, key string) (int, error) {
+	raw := strings.TrimSpace(c.Query(key, ""))
+	if raw == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil || year < 1 {
+		return 0, fmt.Errorf("%s harus berupa tahun", key)
+	}
+	return year, nil
 }
 
 // GetByID godoc
 // @Summary Get alumni by ID
-// @Description Mendapatkan detail alumni berdasarkan ID
+// @Description Mendapatkan detail alumni berdasarkan ID beserta current_job (pekerjaan yang sedang berjalan, null jika tidak ada). Versi data dikirim di header ETag untuk dipakai sebagai If-Match saat mengubah.
 // @Tags Alumni
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Produce json
 // @Param id path string true "ID Alumni"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400,404,500 {object} map[string]interface{}
 // @Router /alumni/{id} [get]
 func (s *AlumniService) GetByID(c *fiber.Ctx) error {
-	idParam := c.Params("id")
-	id, err := primitive.ObjectIDFromHex(idParam)
+	data, jobs, err := s.loadWithJobs(c)
+	if data == nil {
+		return err
+	}
+	setETag(c, data.Version)
+	return c.JSON(fiber.Map{"success": true, "data": model.AlumniDetail{Alumni: *data, CurrentJob: currentJob(jobs, time.Now())}})
+}
+
+// Timeline godoc
+// @Summary Riwayat karier alumni
+// @Description Riwayat pekerjaan kronologis beserta jeda antar pekerjaan, pekerjaan yang tumpang tindih, total bulan bekerja (tanpa hitung ganda), dan current_job. Pekerjaan tanpa tanggal mulai ditaruh di tanpa_tanggal.
+// @Tags Alumni
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /alumni/{id}/timeline [get]
+func (s *AlumniService) Timeline(c *fiber.Ctx) error {
+	data, jobs, err := s.loadWithJobs(c)
+	if data == nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "data": buildTimeline(data.ID, jobs, time.Now())})
+}
+
+// loadWithJobs mengambil alumni dari parameter :id beserta pekerjaannya yang belum dihapus;
+// alumni nil berarti respon error sudah dikirim
+func (s *AlumniService) loadWithJobs(c *fiber.Ctx) (*model.Alumni, []model.Pekerjaan, error) {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+		return nil, nil, c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
 	}
 
 	data, err := s.alumniRepo.GetByID(id)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return nil, nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 	if data == nil {
-		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
+		return nil, nil, c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
+	}
+
+	jobs, err := s.pekerjaanRepo.GetByAlumniID(id, false)
+	if err != nil {
+		return nil, nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
-	return c.JSON(fiber.Map{"success": true, "data": data})
+	return data, jobs, nil
 }
 
 // Create godoc
 // @Summary Tambah alumni
-// @Description Menambahkan data alumni baru
+// @Description Menambahkan data alumni baru. NIM (8-15 digit, unik) dan email divalidasi; kesalahan dikembalikan per field dengan status 422.
 // @Tags Alumni
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param alumni body model.Alumni true "Data Alumni"
+// @Param alumni body model.CreateAlumniReq true "Data Alumni"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,500 {object} map[string]interface{}
+// @Failure 400,422,500 {object} map[string]interface{}
 // @Router /alumni/ [post]
 func (s *AlumniService) Create(c *fiber.Ctx) error {
-	var alumni model.Alumni
-	if err := c.BodyParser(&alumni); err != nil {
+	var req model.CreateAlumniReq
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 
-	if alumni.Nama == "" || alumni.Email == "" {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Nama dan Email wajib diisi"})
+	alumni := req.ToAlumni()
+	errs, err := s.validateAlumni(&alumni, primitive.NilObjectID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
 	}
 
 	// ✅ Set default value
@@ -87,25 +211,29 @@ func (s *AlumniService) Create(c *fiber.Ctx) error {
 	alumni.CreatedAt = time.Now()
 	alumni.UpdatedAt = time.Now()
 
-	err := s.alumniRepo.Create(&alumni)
+	err = s.alumniRepo.Create(&alumni)
+	if err == repository.ErrNIMExists {
+		return validationFailed(c, utils.FieldErrors{"nim": "NIM sudah terdaftar"})
+	}
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Alumni berhasil ditambahkan"})
+	return c.JSON(fiber.Map{"success": true, "message": "Alumni berhasil ditambahkan", "data": alumni})
 }
 
 // Update godoc
 // @Summary Update alumni
-// @Description Mengupdate data alumni berdasarkan ID
+// @Description Mengganti data alumni berdasarkan ID. Validasi sama dengan tambah alumni (422 per field). Wajib kirim If-Match berisi ETag dari GET; 412 kalau data sudah diubah pihak lain.
 // @Tags Alumni
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "ID Alumni"
-// @Param alumni body model.Alumni true "Data Alumni"
+// @Param If-Match header string true "ETag dari GET /alumni/{id}"
+// @Param alumni body model.UpdateAlumniReq true "Data Alumni"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,404,500 {object} map[string]interface{}
+// @Failure 400,404,412,422,428,500 {object} map[string]interface{}
 // @Router /alumni/{id} [put]
 func (s *AlumniService) Update(c *fiber.Ctx) error {
 	idParam := c.Params("id")
@@ -114,8 +242,8 @@ func (s *AlumniService) Update(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
 	}
 
-	var alumni model.Alumni
-	if err := c.BodyParser(&alumni); err != nil {
+	var req model.UpdateAlumniReq
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 
@@ -127,26 +255,136 @@ func (s *AlumniService) Update(c *fiber.Ctx) error {
 		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+	if !versionMatches(version, existing.Version) {
+		return preconditionFailed(c)
+	}
+
+	alumni := req.ToAlumni()
+	errs, err := s.validateAlumni(&alumni, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
 	// ✅ Update timestamp
 	alumni.UpdatedAt = time.Now()
 
-	err = s.alumniRepo.Update(id, &alumni)
+	err = s.alumniRepo.Update(id, &alumni, version)
+	if err == repository.ErrNIMExists {
+		return validationFailed(c, utils.FieldErrors{"nim": "NIM sudah terdaftar"})
+	}
+	if err != nil {
+		return versionedWriteFailed(c, err)
+	}
+
+	return s.respondUpdated(c, id)
+}
+
+// Patch godoc
+// @Summary Ubah sebagian data alumni
+// @Description Partial update dengan JSON Merge Patch: hanya field yang dikirim yang berubah, null mengosongkan field opsional (jurusan, angkatan, tahun_lulus, no_telepon, alamat). Wajib kirim If-Match berisi ETag dari GET.
+// @Tags Alumni
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Param If-Match header string true "ETag dari GET /alumni/{id}"
+// @Param alumni body model.UpdateAlumniReq true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,412,422,428,500 {object} map[string]interface{}
+// @Router /alumni/{id} [patch]
+func (s *AlumniService) Patch(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	patch, errs, err := parseMergePatch(c.Body(), model.PatchableAlumniFields)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	existing, err := s.alumniRepo.GetByID(id)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+	if !versionMatches(version, existing.Version) {
+		return preconditionFailed(c)
+	}
+
+	var req model.UpdateAlumniReq
+	if err := applyMergePatch(model.NewUpdateAlumniReq(*existing), patch, &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	alumni := req.ToAlumni()
 
-	return c.JSON(fiber.Map{"success": true, "message": "Data berhasil diperbarui"})
+	all, err := s.validateAlumni(&alumni, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	// hanya field yang dikirim yang dinilai, agar data lama yang belum lengkap tetap bisa di-patch
+	for field, msg := range all {
+		_, patched := patch[field]
+		_, angkatanPatched := patch["angkatan"]
+		if patched || (field == "tahun_lulus" && angkatanPatched) {
+			errs.Add(field, msg)
+		}
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	set, unset := patchFields(patch)
+	err = s.alumniRepo.Patch(id, &alumni, set, unset, version)
+	if err == repository.ErrNIMExists {
+		return validationFailed(c, utils.FieldErrors{"nim": "NIM sudah terdaftar"})
+	}
+	if err != nil {
+		return versionedWriteFailed(c, err)
+	}
+
+	return s.respondUpdated(c, id)
+}
+
+// respondUpdated mengirim data alumni terbaru beserta ETag versi barunya
+func (s *AlumniService) respondUpdated(c *fiber.Ctx, id primitive.ObjectID) error {
+	updated, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if updated != nil {
+		setETag(c, updated.Version)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Data berhasil diperbarui", "data": updated})
 }
 
 // SoftDelete godoc
 // @Summary Soft delete alumni
-// @Description Menghapus alumni (soft delete)
+// @Description Menghapus alumni (soft delete). Wajib kirim If-Match berisi ETag dari GET.
 // @Tags Alumni
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "ID Alumni"
+// @Param If-Match header string true "ETag dari GET /alumni/{id}"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,500 {object} map[string]interface{}
+// @Failure 400,404,412,428,500 {object} map[string]interface{}
 // @Router /alumni/{id} [delete]
 func (s *AlumniService) SoftDelete(c *fiber.Ctx) error {
 	idParam := c.Params("id")
@@ -155,9 +393,14 @@ func (s *AlumniService) SoftDelete(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
 	}
 
-	err = s.alumniRepo.SoftDelete(id)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+
+	err = s.alumniRepo.SoftDelete(id, version)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return versionedWriteFailed(c, err)
 	}
 	return c.JSON(fiber.Map{"success": true, "message": "Data berhasil dihapus (soft delete)"})
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"praktikum3/app/model"
 	"praktikum3/app/repository"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,11 +16,20 @@ func NewAlumniStatusService(repo repository.AlumniStatusRepository) *AlumniStatu
 	return &AlumniStatusService{statusRepo: repo}
 }
 
-// ✅ Handler untuk mendapatkan laporan berdasarkan status pekerjaan
+// ✅ Handler untuk mendapatkan laporan berdasarkan status pekerjaan (dengan paginasi)
 func (s *AlumniStatusService) GetAlumniByStatus(c *fiber.Ctx) error {
 	status := c.Query("status", "aktif") // default: aktif
 
-	data, count, err := s.statusRepo.GetAlumniByStatus(status)
+	page, limit, err := queryPagination(c, model.MaxAlumniLimit)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	data, counts, err := s.statusRepo.GetAlumniByStatus(model.AlumniStatusQuery{
+		Status: status,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"success": false,
@@ -28,9 +38,15 @@ func (s *AlumniStatusService) GetAlumniByStatus(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"success":  true,
-		"status":   status,
-		"jumlah_bekerja_lebih_dari_satu_tahun": count,
-		"data":     data,
+		"success":                              true,
+		"status":                               status,
+		"jumlah_bekerja_lebih_dari_satu_tahun": counts.LebihDariSatuTahun,
+		"data":                                 data,
+		"meta": model.MetaInfo{
+			Page:  page,
+			Limit: limit,
+			Total: counts.Total,
+			Pages: (counts.Total + limit - 1) / limit,
+		},
 	})
 }
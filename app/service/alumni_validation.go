@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	minTahunAlumni   = 1950
+	maxNamaLength    = 100
+	maxJurusanLength = 100
+)
+
+// validateAlumni memeriksa data alumni dari request create/update.
+// excludeID diisi saat update agar NIM milik data itu sendiri tidak dianggap bentrok.
+func (s *AlumniService) validateAlumni(a *model.Alumni, excludeID primitive.ObjectID) (utils.FieldErrors, error) {
+	errs := utils.FieldErrors{}
+
+	switch {
+	case a.NIM == "":
+		errs.Add("nim", "NIM wajib diisi")
+	case !utils.IsValidNIM(a.NIM):
+		errs.Add("nim", "NIM harus 8-15 digit angka")
+	}
+
+	switch {
+	case a.Nama == "":
+		errs.Add("nama", "Nama wajib diisi")
+	case len(a.Nama) > maxNamaLength:
+		errs.Add("nama", "Nama maksimal 100 karakter")
+	}
+
+	if len(a.Jurusan) > maxJurusanLength {
+		errs.Add("jurusan", "Jurusan maksimal 100 karakter")
+	}
+
+	switch {
+	case a.Email == "":
+		errs.Add("email", "Email wajib diisi")
+	case !utils.IsValidEmail(a.Email):
+		errs.Add("email", "Format email tidak valid")
+	}
+
+	if a.NoTelepon != "" && !utils.IsValidPhone(a.NoTelepon) {
+		errs.Add("no_telepon", "Format nomor telepon tidak valid")
+	}
+	if len(a.Alamat) > maxAlamatLength {
+		errs.Add("alamat", "Alamat maksimal 255 karakter")
+	}
+
+	// angkatan & tahun_lulus boleh kosong (0), tapi kalau diisi harus masuk akal
+	maxTahun := time.Now().Year() + 1
+	if a.Angkatan != 0 && (a.Angkatan < minTahunAlumni || a.Angkatan > maxTahun) {
+		errs.Add("angkatan", "Angkatan tidak valid")
+	}
+	if a.TahunLulus != 0 && (a.TahunLulus < minTahunAlumni || a.TahunLulus > maxTahun+10) {
+		errs.Add("tahun_lulus", "Tahun lulus tidak valid")
+	}
+	if a.Angkatan != 0 && a.TahunLulus != 0 && a.Angkatan > a.TahunLulus {
+		errs.Add("tahun_lulus", "Tahun lulus tidak boleh sebelum angkatan")
+	}
+
+	// cek NIM unik hanya kalau formatnya sudah benar
+	if _, invalid := errs["nim"]; !invalid {
+		existing, err := s.alumniRepo.FindByNIM(a.NIM)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.ID != excludeID {
+			errs.Add("nim", "NIM sudah terdaftar")
+		}
+	}
+
+	return errs, nil
+}
+
+// validationFailed mengirim 422 beserta pesan error per field
+func validationFailed(c *fiber.Ctx, errs utils.FieldErrors) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"success": false,
+		"message": "Validasi gagal",
+		"errors":  errs,
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsService struct {
+	repo repository.AnalyticsRepository
+}
+
+func NewAnalyticsService(repo repository.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{repo: repo}
+}
+
+// groupByParam membaca ?group_by=; kosong berarti keseluruhan
+func groupByParam(c *fiber.Ctx) (string, bool) {
+	groupBy := strings.TrimSpace(c.Query("group_by", ""))
+	return groupBy, groupBy == "" || contains(model.AnalyticsGroupFields, groupBy)
+}
+
+func invalidGroupBy(c *fiber.Ctx) error {
+	return c.Status(400).JSON(fiber.Map{"success": false, "message": "group_by harus salah satu dari: " + strings.Join(model.AnalyticsGroupFields, ", ")})
+}
+
+// ================== EMPLOYMENT RATE ==================
+// @Summary Tingkat keterserapan kerja alumni
+// @Description Jumlah alumni, yang sedang bekerja (punya pekerjaan aktif), yang pernah bekerja, dan persentasenya, per angkatan / jurusan atau keseluruhan
+// @Tags Analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Produce json
+// @Param group_by query string false "Kelompokkan berdasarkan" Enums(angkatan, jurusan)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /analytics/employment-rate [get]
+func (s *AnalyticsService) EmploymentRate(c *fiber.Ctx) error {
+	groupBy, ok := groupByParam(c)
+	if !ok {
+		return invalidGroupBy(c)
+	}
+
+	data, err := s.repo.EmploymentRate(groupBy)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "group_by": groupBy, "data": data})
+}
+
+// ================== WAITING TIME ==================
+// @Summary Rata-rata masa tunggu kerja
+// @Description Masa tunggu (bulan) dari tahun_lulus sampai tanggal_mulai_kerja pekerjaan pertama, dihitung dari Januari tahun lulus. Alumni tanpa tahun_lulus atau tanpa tanggal mulai kerja tidak dihitung.
+// @Tags Analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Produce json
+// @Param group_by query string false "Kelompokkan berdasarkan" Enums(angkatan, jurusan)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /analytics/waiting-time [get]
+func (s *AnalyticsService) WaitingTime(c *fiber.Ctx) error {
+	groupBy, ok := groupByParam(c)
+	if !ok {
+		return invalidGroupBy(c)
+	}
+
+	data, err := s.repo.WaitingTime(groupBy)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "group_by": groupBy, "data": data})
+}
+
+// ================== DISTRIBUTION ==================
+// @Summary Sebaran pekerjaan alumni
+// @Description Jumlah pekerjaan dan alumni per bidang_industri atau lokasi_kerja, urut dari yang terbanyak
+// @Tags Analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Produce json
+// @Param field path string true "Field sebaran" Enums(bidang_industri, lokasi_kerja)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /analytics/distribution/{field} [get]
+func (s *AnalyticsService) Distribution(c *fiber.Ctx) error {
+	field := c.Params("field")
+	if !contains(model.AnalyticsDistributionFields, field) {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "field harus salah satu dari: " + strings.Join(model.AnalyticsDistributionFields, ", ")})
+	}
+
+	data, err := s.repo.Distribution(field)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "field": field, "data": data})
+}
+
+// ================== SALARY ==================
+// @Summary Histogram rentang gaji
+// @Description Jumlah pekerjaan per gaji_range, urut dari rentang terendah; pekerjaan tanpa gaji_range dikelompokkan sebagai string kosong di akhir
+// @Tags Analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /analytics/salary [get]
+func (s *AnalyticsService) SalaryHistogram(c *fiber.Ctx) error {
+	data, err := s.repo.SalaryHistogram()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
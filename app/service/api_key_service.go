@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Format key: "ak_" + 48 karakter hex. Prefix (ak_ + 8 hex) disimpan apa adanya
+// supaya admin bisa mengenali key di daftar tanpa menyimpan key aslinya.
+const (
+	apiKeyScheme    = "ak_"
+	apiKeyPrefixLen = len(apiKeyScheme) + 8
+)
+
+type APIKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+// GetAll godoc
+// @Summary Daftar API key
+// @Description Mengambil semua API key beserta permission, masa berlaku, dan waktu terakhir dipakai (key asli tidak pernah ditampilkan lagi)
+// @Tags API Key
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api-keys/ [get]
+func (s *APIKeyService) GetAll(c *fiber.Ctx) error {
+	keys, err := s.repo.FindAll(context.Background())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": keys})
+}
+
+// Create godoc
+// @Summary Buat API key
+// @Description Membuat API key untuk integrasi antar sistem (header X-API-Key). Key hanya ditampilkan sekali di response ini. Permission key tidak boleh melebihi permission pembuatnya.
+// @Tags API Key
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.CreateAPIKeyRequest true "Nama, permission, dan masa berlaku"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,401,403,500 {object} map[string]interface{}
+// @Router /api-keys/ [post]
+func (s *APIKeyService) Create(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Nama API key wajib diisi"})
+	}
+	if len(req.Permissions) == 0 {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Minimal satu permission wajib diisi"})
+	}
+	perms, msg := normalizePermissions(req.Permissions)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": msg})
+	}
+	for _, p := range perms {
+		// key tidak boleh dipakai untuk menerbitkan key lain
+		if p == model.PermAPIKeysManage {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "API key tidak boleh punya permission " + p})
+		}
+		if !hasPermission(c, p) {
+			return c.Status(403).JSON(fiber.Map{"success": false, "message": "Tidak bisa memberikan permission yang tidak Anda miliki: " + p})
+		}
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = model.DefaultAPIKeyDays
+	}
+	if days < 0 || days > model.MaxAPIKeyDays {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "expires_in_days harus antara 1 dan 365"})
+	}
+
+	secret, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal membuat API key"})
+	}
+	raw := apiKeyScheme + secret
+
+	key := &model.APIKey{
+		Name:        req.Name,
+		Prefix:      raw[:apiKeyPrefixLen],
+		KeyHash:     utils.HashToken(raw),
+		Permissions: perms,
+		CreatedBy:   userID,
+		ExpiresAt:   time.Now().AddDate(0, 0, days),
+	}
+	if err := s.repo.Create(context.Background(), key); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi",
+		"data": fiber.Map{
+			"key":     raw,
+			"api_key": key,
+		},
+	})
+}
+
+// Revoke godoc
+// @Summary Cabut API key
+// @Description Mencabut API key; request berikutnya dengan key ini langsung ditolak
+// @Tags API Key
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID API key"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /api-keys/{id} [delete]
+func (s *APIKeyService) Revoke(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	revoked, err := s.repo.Revoke(context.Background(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if !revoked {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "API key tidak ditemukan atau sudah dicabut"})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "API key berhasil dicabut"})
+}
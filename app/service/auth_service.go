@@ -2,18 +2,39 @@ package service
 
 import (
 	"context"
+	"log"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"praktikum3/app/model"
 	"praktikum3/app/repository"
 	"praktikum3/app/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const (
+	verificationTokenTTL = 24 * time.Hour     // masa berlaku link verifikasi email
+	passwordResetTTL     = time.Hour          // masa berlaku link reset password
+	refreshTokenTTL      = 7 * 24 * time.Hour // masa berlaku refresh token
+	minPasswordLength    = 8
+)
+
 type AuthService struct {
-	userRepo repository.IUserRepository
-	password utils.PasswordChecker
-	tokenGen utils.TokenGenerator
+	userRepo    repository.IUserRepository
+	tokenRepo   repository.UserTokenRepository
+	refreshRepo repository.RefreshTokenRepository
+	revocations repository.TokenRevocationRepository
+	sessions    repository.SessionRepository
+	lockout     *LockoutService
+	password    utils.PasswordChecker
+	tokenGen    utils.TokenGenerator
+	mailer      utils.EmailSender
 }
 
 // ===============================
@@ -23,9 +44,15 @@ func NewAuthService(db *mongo.Database) *AuthService {
 	repo := repository.NewUserRepository(db)
 
 	return &AuthService{
-		userRepo: repo,
-		password: utils.RealPasswordChecker{},
-		tokenGen: utils.RealTokenGenerator{},
+		userRepo:    repo,
+		tokenRepo:   repository.NewUserTokenRepository(db),
+		refreshRepo: repository.NewRefreshTokenRepository(db),
+		revocations: repository.NewTokenRevocationRepository(db),
+		sessions:    repository.NewSessionRepository(db),
+		lockout:     NewLockoutService(repository.NewLoginAttemptRepository(db)),
+		password:    utils.RealPasswordChecker{},
+		tokenGen:    utils.RealTokenGenerator{},
+		mailer:      utils.NewEmailSender(),
 	}
 }
 
@@ -34,26 +61,41 @@ func NewAuthService(db *mongo.Database) *AuthService {
 // ===============================
 func NewAuthServiceMock(
 	repo repository.IUserRepository,
+	tokenRepo repository.UserTokenRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+	sessions repository.SessionRepository,
+	lockout *LockoutService,
 	pw utils.PasswordChecker,
 	tg utils.TokenGenerator,
+	mailer utils.EmailSender,
 ) *AuthService {
 	return &AuthService{
-		userRepo: repo,
-		password: pw,
-		tokenGen: tg,
+		userRepo:    repo,
+		tokenRepo:   tokenRepo,
+		refreshRepo: refreshRepo,
+		revocations: revocations,
+		sessions:    sessions,
+		lockout:     lockout,
+		password:    pw,
+		tokenGen:    tg,
+		mailer:      mailer,
 	}
 }
 
 // ========================================
 // @Summary Login user
-// @Description Login dan mendapatkan JWT token dari sistem
+// @Description Login dan mendapatkan JWT access token + refresh token dari sistem. Jika akun memakai 2FA (atau 2FA diwajibkan untuk role-nya), response berisi challenge_token yang ditukar lewat /login/2fa.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param login body model.LoginRequest true "Login credentials"
 // @Success 200 {object} model.LoginResponse
+// @Success 202 {object} model.TwoFactorChallengeResponse "Password benar, lanjutkan dengan kode 2FA"
 // @Failure 400 {object} map[string]interface{} "Body tidak valid"
 // @Failure 401 {object} map[string]interface{} "Username atau password salah"
+// @Failure 403 {object} map[string]interface{} "Email belum diverifikasi"
+// @Failure 423 {object} map[string]interface{} "Terlalu banyak percobaan gagal, akun/IP dikunci sementara"
 // @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
 // @Router /login [post]
 // ========================================
@@ -66,8 +108,11 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	ctx := context.Background()
+	ip := c.IP()
+
 	// ambil user dari database
-	user, err := s.userRepo.FindByUsernameOrEmail(context.Background(), req.Username)
+	user, err := s.userRepo.FindByUsernameOrEmail(ctx, req.Username)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -75,23 +120,67 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	if user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+	// akun / IP yang sedang dikunci langsung ditolak (sebelum cek password)
+	account := accountFor(user, req.Username)
+	lockedUntil, locked, err := s.lockout.Check(ctx, account, ip)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
-			"message": "Username atau password salah",
+			"message": "Kesalahan database: " + err.Error(),
 		})
 	}
+	if locked {
+		return lockedResponse(c, lockedUntil)
+	}
 
-	// verify password menggunakan dependency injection
-	if !s.password.Check(user.PasswordHash, req.Password) {
+	// verify password menggunakan dependency injection.
+	// username tidak dikenal dan password salah dicatat & dijawab sama persis
+	if user == nil || !s.password.Check(user.PasswordHash, req.Password) {
+		s.lockout.RecordFailure(ctx, account, ip)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"message": "Username atau password salah",
 		})
 	}
 
+	// akun hasil register wajib verifikasi email dulu
+	if user.IsPending() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Email belum diverifikasi, cek inbox untuk link verifikasi",
+		})
+	}
+
+	// akun dengan 2FA (atau admin yang diwajibkan 2FA) lanjut ke langkah kedua
+	// counter akun baru direset setelah kode 2FA benar, agar password benar tidak
+	// bisa dipakai untuk menghapus jejak tebakan kode 2FA
+	if user.TOTPEnabled || requireTwoFactor(user.Role) {
+		return s.twoFactorChallenge(c, user)
+	}
+
+	s.lockout.RecordSuccess(ctx, account)
+	return s.completeLogin(ctx, c, user, nil)
+}
+
+// completeLogin menerbitkan access token + refresh token untuk user yang lolos autentikasi
+func (s *AuthService) completeLogin(ctx context.Context, c *fiber.Ctx, user *model.User, recoveryCodes []string) error {
+	// setiap login membuka sesi baru; ID sesi = family refresh token-nya
+	session := &model.Session{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat sesi: " + err.Error(),
+		})
+	}
+
 	// generate JWT token menggunakan dependency injection
-	token, err := s.tokenGen.Generate(*user)
+	token, err := s.tokenGen.Generate(*user, session.ID.Hex())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -99,7 +188,127 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	refreshToken, err := s.issueRefreshToken(ctx, user.ID, session.ID, primitive.NewObjectID())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat refresh token",
+		})
+	}
+
 	// response sukses
+	return c.JSON(model.LoginResponse{
+		User: model.User{
+			ID:          user.ID,
+			Username:    user.Username,
+			Email:       user.Email,
+			Role:        user.Role,
+			TOTPEnabled: user.TOTPEnabled || len(recoveryCodes) > 0,
+			CreatedAt:   user.CreatedAt,
+		},
+		Token:         token,
+		RefreshToken:  refreshToken,
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// ========================================
+// @Summary Refresh token
+// @Description Menukar refresh token dengan access token + refresh token baru (rotasi). Refresh token yang sudah pernah dipakai akan mencabut seluruh family-nya.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} map[string]interface{} "Body tidak valid"
+// @Failure 401 {object} map[string]interface{} "Refresh token tidak valid, expired, atau sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /refresh [post]
+// ========================================
+func (s *AuthService) Refresh(c *fiber.Ctx) error {
+	var req model.RefreshRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	ctx := context.Background()
+	current, err := s.refreshRepo.FindByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if current == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Refresh token tidak valid",
+		})
+	}
+
+	// token yang sudah dirotasi/dicabut dipakai lagi -> kemungkinan bocor, cabut satu family
+	if current.UsedAt != nil || current.RevokedAt != nil {
+		return s.rejectReusedRefreshToken(c, current)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Refresh token sudah expired, silakan login ulang",
+		})
+	}
+
+	user, err := s.userRepo.FindByID(ctx, current.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if user == nil || user.DeletedAt != nil || user.IsPending() {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "User tidak aktif",
+		})
+	}
+
+	// tandai token lama terpakai secara atomik; kalau kalah balapan berarti ada reuse
+	nextID := primitive.NewObjectID()
+	ok, err := s.refreshRepo.MarkUsed(ctx, current.ID, nextID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if !ok {
+		return s.rejectReusedRefreshToken(c, current)
+	}
+
+	refreshToken, err := s.issueRefreshToken(ctx, user.ID, current.FamilyID, nextID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat refresh token",
+		})
+	}
+
+	// family refresh token = sesi login; token lama (sebelum ada sesi) tetap jalan tanpa sesi
+	if err := s.sessions.Refreshed(ctx, current.FamilyID, c.IP(), c.Get(fiber.HeaderUserAgent), time.Now().Add(refreshTokenTTL)); err != nil {
+		log.Printf("refresh: gagal memperbarui sesi %s: %v", current.FamilyID.Hex(), err)
+	}
+
+	token, err := s.tokenGen.Generate(*user, current.FamilyID.Hex())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat token",
+		})
+	}
+
 	return c.JSON(model.LoginResponse{
 		User: model.User{
 			ID:        user.ID,
@@ -108,6 +317,601 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 			Role:      user.Role,
 			CreatedAt: user.CreatedAt,
 		},
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
+
+// ========================================
+// @Summary Logout
+// @Description Mencabut access token yang sedang dipakai (masuk revocation list) dan mengakhiri sesinya. Jika refresh_token dikirim, family refresh token tersebut ikut dicabut.
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.LogoutRequest false "Refresh token (opsional)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{} "Token tidak valid"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /logout [post]
+// ========================================
+func (s *AuthService) Logout(c *fiber.Ctx) error {
+	claims, userID, ok := currentClaims(c)
+	if !ok || claims.ID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid",
+		})
+	}
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	ctx := context.Background()
+	if err := s.revocations.Revoke(ctx, claims.ID, userID, expiresAt); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal logout: " + err.Error(),
+		})
+	}
+
+	// sesi yang sedang dipakai ikut berakhir (beserta refresh token-nya)
+	if sessionID, err := primitive.ObjectIDFromHex(claims.SessionID); err == nil {
+		if _, err := endSession(ctx, s.sessions, s.refreshRepo, s.revocations, sessionID, userID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"success": false,
+				"message": "Gagal mengakhiri sesi: " + err.Error(),
+			})
+		}
+	}
+
+	// refresh token opsional; hanya dicabut jika memang milik user ini
+	var req model.LogoutRequest
+	if err := c.BodyParser(&req); err == nil && req.RefreshToken != "" {
+		rt, err := s.refreshRepo.FindByHash(ctx, utils.HashToken(req.RefreshToken))
+		if err == nil && rt != nil && rt.UserID == userID {
+			if err := s.refreshRepo.RevokeFamily(ctx, rt.FamilyID); err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"message": "Gagal mencabut refresh token: " + err.Error(),
+				})
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Logout berhasil",
+	})
+}
+
+// ========================================
+// @Summary Ganti password
+// @Description Mengganti password user yang sedang login, wajib menyertakan password lama
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.ChangePasswordRequest true "Password lama & baru"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Body / password baru tidak valid"
+// @Failure 401 {object} map[string]interface{} "Password lama salah"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /me/password [put]
+// ========================================
+func (s *AuthService) ChangePassword(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid",
+		})
+	}
+
+	var req model.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Password baru minimal 8 karakter",
+		})
+	}
+	if req.NewPassword == req.OldPassword {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Password baru harus berbeda dari password lama",
+		})
+	}
+
+	ctx := context.Background()
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if user == nil || !s.password.Check(user.PasswordHash, req.OldPassword) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Password lama salah",
+		})
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal memproses password",
+		})
+	}
+	if err := s.userRepo.UpdatePassword(ctx, userID, hash); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal menyimpan password: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Password berhasil diganti",
+	})
+}
+
+// ========================================
+// @Summary Lupa password
+// @Description Mengirim link reset password ke email. Response selalu sama agar tidak membocorkan email yang terdaftar.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.ForgotPasswordRequest true "Email akun"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Body tidak valid"
+// @Router /forgot-password [post]
+// ========================================
+func (s *AuthService) ForgotPassword(c *fiber.Ctx) error {
+	var req model.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Email) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	ctx := context.Background()
+
+	user, err := s.userRepo.FindByUsernameOrEmail(ctx, email)
+	if err != nil {
+		log.Printf("forgot-password: gagal mencari user %s: %v", email, err)
+	} else if user != nil && user.Email == email {
+		if err := s.sendPasswordResetEmail(ctx, user); err != nil {
+			log.Printf("forgot-password: gagal mengirim email ke %s: %v", email, err)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Jika email terdaftar, link reset password sudah dikirim",
+	})
+}
+
+// ========================================
+// @Summary Reset password
+// @Description Mengganti password memakai token dari email. Token hanya bisa dipakai sekali dan semua sesi user dicabut.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.ResetPasswordRequest true "Token reset & password baru"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Token tidak valid atau password baru tidak valid"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /reset-password [post]
+// ========================================
+func (s *AuthService) ResetPassword(c *fiber.Ctx) error {
+	var req model.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Password baru minimal 8 karakter",
+		})
+	}
+
+	ctx := context.Background()
+	t, err := s.tokenRepo.FindValid(ctx, model.TokenPurposePasswordReset, utils.HashToken(req.Token))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if t == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid atau sudah expired",
+		})
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal memproses password",
+		})
+	}
+
+	// token diklaim dulu agar request bersamaan tidak memakai token yang sama dua kali,
+	// lalu dilepas lagi jika password gagal disimpan supaya token tidak hangus sia-sia
+	used, err := s.tokenRepo.MarkUsed(ctx, t.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if !used {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid atau sudah expired",
+		})
+	}
+	if err := s.userRepo.UpdatePassword(ctx, t.UserID, hash); err != nil {
+		if rerr := s.tokenRepo.Release(ctx, t.ID); rerr != nil {
+			log.Printf("reset-password: gagal melepas token %s: %v", t.ID.Hex(), rerr)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal menyimpan password: " + err.Error(),
+		})
+	}
+
+	// semua sesi lama tidak berlaku lagi: refresh token dan access token
+	if err := s.refreshRepo.RevokeByUser(ctx, t.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mencabut sesi: " + err.Error(),
+		})
+	}
+	if err := s.revocations.RevokeAllForUser(ctx, t.UserID, time.Now()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mencabut sesi: " + err.Error(),
+		})
+	}
+	if err := s.sessions.RevokeAllForUser(ctx, t.UserID); err != nil {
+		log.Printf("reset-password: gagal mengakhiri sesi user %s: %v", t.UserID.Hex(), err)
+	}
+	if err := s.tokenRepo.DeleteByUser(ctx, t.UserID, model.TokenPurposePasswordReset); err != nil {
+		log.Printf("reset-password: gagal membersihkan token user %s: %v", t.UserID.Hex(), err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Password berhasil direset, silakan login ulang",
+	})
+}
+
+// sendPasswordResetEmail menerbitkan token reset baru dan mengirimkannya ke email user
+func (s *AuthService) sendPasswordResetEmail(ctx context.Context, user *model.User) error {
+	token, err := issueUserToken(ctx, s.tokenRepo, &model.UserToken{
+		UserID:  user.ID,
+		Purpose: model.TokenPurposePasswordReset,
+	}, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := appBaseURL() + "/reset-password?token=" + token
+	body := "Halo " + user.Username + ",\n\n" +
+		"Kami menerima permintaan reset password. Gunakan token berikut (berlaku 1 jam):\n" + link + "\n\n" +
+		"Abaikan email ini jika kamu tidak meminta reset password."
+	return s.mailer.Send(user.Email, "Reset password akun Alumni", body)
+}
+
+// lockedResponse membalas 423 dengan header Retry-After (detik)
+func lockedResponse(c *fiber.Ctx, until time.Time) error {
+	retryAfter := int(time.Until(until).Seconds()) + 1
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	return c.Status(fiber.StatusLocked).JSON(fiber.Map{
+		"success":     false,
+		"message":     "Terlalu banyak percobaan login gagal, coba lagi nanti",
+		"retry_after": retryAfter,
+	})
+}
+
+// currentClaims mengambil klaim JWT dan ObjectID user dari context (diisi AuthRequired)
+func currentClaims(c *fiber.Ctx) (*model.JWTClaims, primitive.ObjectID, bool) {
+	claims, ok := c.Locals("claims").(*model.JWTClaims)
+	if !ok {
+		return nil, primitive.NilObjectID, false
+	}
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, primitive.NilObjectID, false
+	}
+	return claims, userID, true
+}
+
+// rejectReusedRefreshToken mencabut seluruh family lalu menolak request
+func (s *AuthService) rejectReusedRefreshToken(c *fiber.Ctx, t *model.RefreshToken) error {
+	if err := s.refreshRepo.RevokeFamily(context.Background(), t.FamilyID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	log.Printf("⚠️  refresh token reuse terdeteksi (user=%s family=%s), family dicabut", t.UserID.Hex(), t.FamilyID.Hex())
+
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"success": false,
+		"message": "Refresh token sudah pernah dipakai, silakan login ulang",
+	})
+}
+
+// issueRefreshToken membuat refresh token baru (opaque) dan menyimpan hash-nya
+func (s *AuthService) issueRefreshToken(ctx context.Context, userID, familyID, id primitive.ObjectID) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.refreshRepo.Create(ctx, &model.RefreshToken{
+		ID:        id,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ========================================
+// @Summary Register user
+// @Description Mendaftarkan user baru (role user). Akun berstatus pending sampai email diverifikasi.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param register body model.RegisterRequest true "Data register"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Body tidak valid"
+// @Failure 409 {object} map[string]interface{} "Username atau email sudah digunakan"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /register [post]
+// ========================================
+func (s *AuthService) Register(c *fiber.Ctx) error {
+	var req model.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	if len(req.Username) < 3 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Username minimal 3 karakter",
+		})
+	}
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Format email tidak valid",
+		})
+	}
+	if len(req.Password) < minPasswordLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Password minimal 8 karakter",
+		})
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal memproses password",
+		})
+	}
+
+	user := &model.User{
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: hash,
+		Role:         "user",
+		Status:       model.UserStatusPending,
+	}
+
+	ctx := context.Background()
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if err == repository.ErrUserExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		// user tetap terdaftar, cukup dicatat di log
+		log.Printf("gagal mengirim email verifikasi ke %s: %v", user.Email, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Registrasi berhasil, silakan cek email untuk verifikasi akun",
+		"data":    user,
 	})
 }
+
+// ========================================
+// @Summary Verifikasi email
+// @Description Menukar token verifikasi (dari email) untuk mengaktifkan akun, atau untuk mengonfirmasi email baru dari PUT /me. Token bisa dikirim lewat query ?token= atau body JSON.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token query string false "Token verifikasi"
+// @Param body body model.VerifyEmailRequest false "Token verifikasi"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Token tidak valid atau sudah expired"
+// @Failure 409 {object} map[string]interface{} "Email baru sudah dipakai akun lain"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /verify-email [post]
+// ========================================
+func (s *AuthService) VerifyEmail(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		var req model.VerifyEmailRequest
+		if err := c.BodyParser(&req); err == nil {
+			token = req.Token
+		}
+	}
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Token verifikasi diperlukan",
+		})
+	}
+
+	ctx := context.Background()
+	t, err := s.tokenRepo.FindValid(ctx, model.TokenPurposeEmailVerification, utils.HashToken(token))
+	if err == nil && t == nil {
+		// link konfirmasi ganti email memakai endpoint yang sama
+		t, err = s.tokenRepo.FindValid(ctx, model.TokenPurposeEmailChange, utils.HashToken(token))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if t == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid atau sudah expired",
+		})
+	}
+	if t.Purpose == model.TokenPurposeEmailChange {
+		return s.confirmEmailChange(ctx, c, t)
+	}
+
+	// token sekali pakai: hanya request pertama yang berhasil menandai used
+	used, err := s.tokenRepo.MarkUsed(ctx, t.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if !used {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid atau sudah expired",
+		})
+	}
+
+	if err := s.userRepo.MarkEmailVerified(ctx, t.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal memverifikasi akun: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Email berhasil diverifikasi, silakan login",
+	})
+}
+
+// confirmEmailChange memindahkan akun ke email baru yang tercatat di token.
+// Token baru ditandai terpakai setelah email berhasil diganti, jadi error database tidak membakar link-nya.
+func (s *AuthService) confirmEmailChange(ctx context.Context, c *fiber.Ctx, t *model.UserToken) error {
+	if err := s.userRepo.UpdateEmail(ctx, t.UserID, t.Email); err != nil {
+		if err == repository.ErrUserExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"message": "Email sudah digunakan akun lain",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengganti email: " + err.Error(),
+		})
+	}
+	if _, err := s.tokenRepo.MarkUsed(ctx, t.ID); err != nil {
+		log.Printf("ganti email: gagal menandai token %s terpakai: %v", t.ID.Hex(), err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Email berhasil diganti",
+	})
+}
+
+// sendVerificationEmail menerbitkan token verifikasi baru dan mengirimkannya ke email user
+func (s *AuthService) sendVerificationEmail(ctx context.Context, user *model.User) error {
+	token, err := issueUserToken(ctx, s.tokenRepo, &model.UserToken{
+		UserID:  user.ID,
+		Purpose: model.TokenPurposeEmailVerification,
+	}, verificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := appBaseURL() + "/verify-email?token=" + token
+	body := "Halo " + user.Username + ",\n\n" +
+		"Klik link berikut untuk memverifikasi akun kamu (berlaku 24 jam):\n" + link + "\n\n" +
+		"Abaikan email ini jika kamu tidak merasa mendaftar."
+	return s.mailer.Send(user.Email, "Verifikasi akun Alumni", body)
+}
+
+// issueUserToken menerbitkan token sekali pakai baru untuk t.UserID dan t.Purpose.
+// Token lama dengan tujuan yang sama tidak berlaku lagi; yang disimpan hanya hash-nya.
+func issueUserToken(ctx context.Context, repo repository.UserTokenRepository, t *model.UserToken, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	if err := repo.DeleteByUser(ctx, t.UserID, t.Purpose); err != nil {
+		return "", err
+	}
+
+	t.TokenHash = utils.HashToken(token)
+	t.ExpiresAt = time.Now().Add(ttl)
+	if err := repo.Create(ctx, t); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// appBaseURL adalah base URL API yang dipakai untuk link di email
+func appBaseURL() string {
+	if url := os.Getenv("APP_BASE_URL"); url != "" {
+		return strings.TrimRight(url, "/")
+	}
+	return "http://localhost:3000/api/v1"
+}
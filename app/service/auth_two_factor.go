@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const recoveryCodeCount = 10
+
+// requireTwoFactor: kebijakan REQUIRE_2FA_FOR_ADMIN=true mewajibkan 2FA untuk role admin
+func requireTwoFactor(role string) bool {
+	if role != model.RoleAdmin {
+		return false
+	}
+	required, _ := strconv.ParseBool(os.Getenv("REQUIRE_2FA_FOR_ADMIN"))
+	return required
+}
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Alumni API"
+}
+
+// twoFactorChallenge membalas login yang password-nya benar dengan token challenge 2FA
+func (s *AuthService) twoFactorChallenge(c *fiber.Ctx, user *model.User) error {
+	purpose := model.ChallengePurposeTwoFactor
+	message := "Masukkan kode dari aplikasi authenticator"
+	if !user.TOTPEnabled {
+		purpose = model.ChallengePurposeTwoFactorSetup
+		message = "2FA wajib untuk akun ini, aktifkan dulu lewat /login/2fa/setup"
+	}
+
+	token, err := utils.GenerateChallengeToken(*user, purpose)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat token",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(model.TwoFactorChallengeResponse{
+		Success:           true,
+		Message:           message,
+		TwoFactorRequired: true,
+		SetupRequired:     !user.TOTPEnabled,
+		ChallengeToken:    token,
+		ExpiresIn:         int(utils.ChallengeTokenTTL.Seconds()),
+	})
+}
+
+// challengeUser memvalidasi token challenge lalu memuat user-nya.
+// nil berarti response error sudah dikirim.
+func (s *AuthService) challengeUser(ctx context.Context, c *fiber.Ctx, token string, purposes ...string) (*model.User, string, error) {
+	claims, err := utils.ValidateChallengeToken(token, purposes...)
+	if err != nil {
+		return nil, "", c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token challenge tidak valid atau sudah expired, silakan login ulang",
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, "", c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token challenge tidak valid atau sudah expired, silakan login ulang",
+		})
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if user == nil || user.IsPending() {
+		return nil, "", c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token challenge tidak valid atau sudah expired, silakan login ulang",
+		})
+	}
+	return user, claims.Purpose, nil
+}
+
+// ========================================
+// @Summary Login langkah kedua (2FA)
+// @Description Menukar challenge_token dari /login dengan access token + refresh token. Untuk akun yang sudah mengaktifkan 2FA, code berupa kode TOTP atau kode pemulihan. Untuk setup wajib, code adalah kode TOTP pertama dari secret /login/2fa/setup; response juga berisi kode pemulihan (hanya ditampilkan sekali).
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.TwoFactorLoginRequest true "Challenge token & kode"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} map[string]interface{} "Body tidak valid / setup belum dimulai"
+// @Failure 401 {object} map[string]interface{} "Token challenge atau kode salah"
+// @Failure 423 {object} map[string]interface{} "Terlalu banyak percobaan gagal"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /login/2fa [post]
+// ========================================
+func (s *AuthService) LoginTwoFactor(c *fiber.Ctx) error {
+	var req model.TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" || strings.TrimSpace(req.Code) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	ctx := context.Background()
+	user, purpose, err := s.challengeUser(ctx, c, req.ChallengeToken,
+		model.ChallengePurposeTwoFactor, model.ChallengePurposeTwoFactorSetup)
+	if user == nil {
+		return err
+	}
+
+	// kode 2FA ikut dibatasi lockout yang sama dengan password
+	ip := c.IP()
+	account := accountFor(user, "")
+	lockedUntil, locked, err := s.lockout.Check(ctx, account, ip)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if locked {
+		return lockedResponse(c, lockedUntil)
+	}
+
+	var recoveryCodes []string
+	if purpose == model.ChallengePurposeTwoFactorSetup && !user.TOTPEnabled {
+		if user.TOTPSecret == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Setup 2FA belum dimulai, panggil /login/2fa/setup dulu",
+			})
+		}
+		var ok bool
+		recoveryCodes, ok, err = s.activateTOTP(ctx, user, req.Code)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"success": false,
+				"message": "Gagal mengaktifkan 2FA: " + err.Error(),
+			})
+		}
+		if !ok {
+			s.lockout.RecordFailure(ctx, account, ip)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "Kode 2FA salah",
+			})
+		}
+	} else {
+		if !user.TOTPEnabled {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "Token challenge tidak valid atau sudah expired, silakan login ulang",
+			})
+		}
+		ok, err := s.verifySecondFactor(ctx, user, req.Code)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"success": false,
+				"message": "Kesalahan database: " + err.Error(),
+			})
+		}
+		if !ok {
+			s.lockout.RecordFailure(ctx, account, ip)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "Kode 2FA salah",
+			})
+		}
+	}
+	s.lockout.RecordSuccess(ctx, account)
+
+	return s.completeLogin(ctx, c, user, recoveryCodes)
+}
+
+// ========================================
+// @Summary Setup 2FA saat login
+// @Description Untuk akun yang diwajibkan 2FA tetapi belum mengaktifkannya: membuat secret TOTP baru dari challenge_token (setup_required=true) hasil /login
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body model.TwoFactorSetupRequest true "Challenge token"
+// @Success 200 {object} model.TwoFactorSetupResponse
+// @Failure 400 {object} map[string]interface{} "Body tidak valid"
+// @Failure 401 {object} map[string]interface{} "Token challenge tidak valid"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /login/2fa/setup [post]
+// ========================================
+func (s *AuthService) LoginTwoFactorSetup(c *fiber.Ctx) error {
+	var req model.TwoFactorSetupRequest
+	if err := c.BodyParser(&req); err != nil || req.ChallengeToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	ctx := context.Background()
+	user, _, err := s.challengeUser(ctx, c, req.ChallengeToken, model.ChallengePurposeTwoFactorSetup)
+	if user == nil {
+		return err
+	}
+	return s.startTOTPSetup(ctx, c, user)
+}
+
+// ========================================
+// @Summary Mulai setup 2FA
+// @Description Membuat secret TOTP baru untuk user yang sedang login. 2FA baru aktif setelah kode pertama dikonfirmasi lewat /me/2fa/enable.
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.TwoFactorSetupResponse
+// @Failure 401 {object} map[string]interface{} "Token tidak valid"
+// @Failure 409 {object} map[string]interface{} "2FA sudah aktif"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /me/2fa/setup [post]
+// ========================================
+func (s *AuthService) SetupTwoFactor(c *fiber.Ctx) error {
+	ctx := context.Background()
+	user, err := s.currentUser(ctx, c)
+	if user == nil {
+		return err
+	}
+	if user.TOTPEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "2FA sudah aktif",
+		})
+	}
+	return s.startTOTPSetup(ctx, c, user)
+}
+
+// ========================================
+// @Summary Aktifkan 2FA
+// @Description Mengonfirmasi kode TOTP pertama dari secret /me/2fa/setup lalu mengaktifkan 2FA. Response berisi kode pemulihan sekali pakai (hanya ditampilkan sekali).
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.TwoFactorCodeRequest true "Kode TOTP"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Body tidak valid / setup belum dimulai"
+// @Failure 401 {object} map[string]interface{} "Kode salah"
+// @Failure 409 {object} map[string]interface{} "2FA sudah aktif"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /me/2fa/enable [post]
+// ========================================
+func (s *AuthService) EnableTwoFactor(c *fiber.Ctx) error {
+	var req model.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Code) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	ctx := context.Background()
+	user, err := s.currentUser(ctx, c)
+	if user == nil {
+		return err
+	}
+	if user.TOTPEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "2FA sudah aktif",
+		})
+	}
+	if user.TOTPSecret == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Setup 2FA belum dimulai, panggil /me/2fa/setup dulu",
+		})
+	}
+
+	codes, ok, err := s.activateTOTP(ctx, user, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengaktifkan 2FA: " + err.Error(),
+		})
+	}
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Kode 2FA salah",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":        true,
+		"message":        "2FA berhasil diaktifkan, simpan kode pemulihan di tempat aman",
+		"recovery_codes": codes,
+	})
+}
+
+// ========================================
+// @Summary Matikan 2FA
+// @Description Mematikan 2FA, wajib menyertakan password dan kode TOTP / kode pemulihan. Tidak bisa dilakukan jika 2FA diwajibkan untuk role user.
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.DisableTwoFactorRequest true "Password & kode"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{} "Body tidak valid / 2FA belum aktif"
+// @Failure 401 {object} map[string]interface{} "Password atau kode salah"
+// @Failure 403 {object} map[string]interface{} "2FA diwajibkan untuk role ini"
+// @Failure 423 {object} map[string]interface{} "Terlalu banyak percobaan gagal"
+// @Failure 500 {object} map[string]interface{} "Kesalahan server atau database"
+// @Router /me/2fa/disable [post]
+// ========================================
+func (s *AuthService) DisableTwoFactor(c *fiber.Ctx) error {
+	var req model.DisableTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil || req.Password == "" || strings.TrimSpace(req.Code) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Body tidak valid",
+		})
+	}
+
+	ctx := context.Background()
+	user, err := s.currentUser(ctx, c)
+	if user == nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "2FA belum aktif",
+		})
+	}
+	if requireTwoFactor(user.Role) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "2FA wajib untuk role " + user.Role + " dan tidak bisa dimatikan",
+		})
+	}
+
+	// password & kode 2FA di sini ikut dibatasi lockout yang sama dengan login,
+	// supaya sesi yang dicuri tidak bisa dipakai menebak kode tanpa batas
+	ip := c.IP()
+	account := accountFor(user, "")
+	lockedUntil, locked, err := s.lockout.Check(ctx, account, ip)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if locked {
+		return lockedResponse(c, lockedUntil)
+	}
+
+	if !s.password.Check(user.PasswordHash, req.Password) {
+		s.lockout.RecordFailure(ctx, account, ip)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Password atau kode 2FA salah",
+		})
+	}
+	ok, err := s.verifySecondFactor(ctx, user, req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if !ok {
+		s.lockout.RecordFailure(ctx, account, ip)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Password atau kode 2FA salah",
+		})
+	}
+	s.lockout.RecordSuccess(ctx, account)
+
+	if err := s.userRepo.DisableTOTP(ctx, user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mematikan 2FA: " + err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "2FA berhasil dimatikan",
+	})
+}
+
+// currentUser memuat user login dari database. nil berarti response error sudah dikirim.
+func (s *AuthService) currentUser(ctx context.Context, c *fiber.Ctx) (*model.User, error) {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return nil, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Token tidak valid",
+		})
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kesalahan database: " + err.Error(),
+		})
+	}
+	if user == nil {
+		return nil, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "User tidak ditemukan",
+		})
+	}
+	return user, nil
+}
+
+// startTOTPSetup membuat & menyimpan secret baru (belum aktif) lalu mengirim URI otpauth
+func (s *AuthService) startTOTPSetup(ctx context.Context, c *fiber.Ctx, user *model.User) error {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal membuat secret 2FA",
+		})
+	}
+
+	if err := s.userRepo.SetTOTPSecret(ctx, user.ID, secret); err != nil {
+		if err == repository.ErrTOTPEnabled {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"message": "2FA sudah aktif",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal menyimpan secret 2FA: " + err.Error(),
+		})
+	}
+
+	return c.JSON(model.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(totpIssuer(), user.Username, secret),
+	})
+}
+
+// activateTOTP memeriksa kode pertama dari secret yang sedang di-setup, lalu
+// mengaktifkan 2FA dan mengembalikan kode pemulihan (plaintext, hanya sekali)
+func (s *AuthService) activateTOTP(ctx context.Context, user *model.User, code string) ([]string, bool, error) {
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, strings.TrimSpace(code), time.Now(), 0)
+	if !ok {
+		return nil, false, nil
+	}
+
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, false, err
+	}
+	hashes := make([]string, len(codes))
+	for i, rc := range codes {
+		hashes[i] = utils.HashToken(rc)
+	}
+
+	if err := s.userRepo.EnableTOTP(ctx, user.ID, step, hashes); err != nil {
+		return nil, false, err
+	}
+	return codes, true, nil
+}
+
+// verifySecondFactor menerima kode TOTP (sekali pakai per langkah waktu) atau kode pemulihan
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *model.User, code string) (bool, error) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep); ok {
+		return s.userRepo.UseTOTPStep(ctx, user.ID, step)
+	}
+
+	if len(code) == 6 {
+		return false, nil
+	}
+	return s.userRepo.UseRecoveryCode(ctx, user.ID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+}
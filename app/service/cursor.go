@@ -0,0 +1,32 @@
+package service
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// cursorParam mengecek mode cursor: aktif kalau parameter cursor dikirim,
+// nilai kosong (?cursor=) berarti halaman pertama.
+func cursorParam(c *fiber.Ctx) (string, bool) {
+	if !c.Context().QueryArgs().Has("cursor") {
+		return "", false
+	}
+	return c.Query("cursor"), true
+}
+
+// cursorPage mengirim satu halaman hasil mode cursor beserta next_cursor
+func cursorPage(c *fiber.Ctx, data interface{}, next string, meta model.CursorMeta) error {
+	meta.NextCursor = next
+	meta.HasMore = next != ""
+	return c.JSON(fiber.Map{"success": true, "data": data, "meta": meta})
+}
+
+// cursorFailed memetakan error list mode cursor ke status HTTP
+func cursorFailed(c *fiber.Ctx, err error) error {
+	if err == repository.ErrInvalidCursor {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "cursor tidak valid atau tidak cocok dengan sortBy/order"})
+	}
+	return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// versi yang tidak pernah cocok, dipakai untuk If-Match yang formatnya tidak dikenali
+const unmatchableVersion int64 = -2
+
+// setETag mengirim versi dokumen sebagai ETag (strong), mis. "3"
+func setETag(c *fiber.Ctx, version int64) {
+	c.Set(fiber.HeaderETag, `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// ifMatchVersion membaca versi dari header If-Match.
+// present=false berarti header tidak dikirim; "*" berarti versi apa pun (repository.AnyVersion).
+func ifMatchVersion(c *fiber.Ctx) (version int64, present bool) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return 0, false
+	}
+	if header == "*" {
+		return repository.AnyVersion, true
+	}
+
+	// ETag weak (W/"...") tidak pernah cocok dengan perbandingan strong
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return unmatchableVersion, true
+	}
+	v, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || v < 0 {
+		return unmatchableVersion, true
+	}
+	return v, true
+}
+
+// versionMatches membandingkan If-Match dengan versi dokumen yang sudah dibaca
+func versionMatches(expected, current int64) bool {
+	return expected == repository.AnyVersion || expected == current
+}
+
+func preconditionRequired(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusPreconditionRequired).JSON(fiber.Map{
+		"success": false,
+		"message": "Header If-Match wajib diisi dengan ETag dari GET terakhir",
+	})
+}
+
+func preconditionFailed(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+		"success": false,
+		"message": repository.ErrVersionConflict.Error(),
+	})
+}
+
+// versionedWriteFailed memetakan error tulis dengan cek versi ke status HTTP
+func versionedWriteFailed(c *fiber.Ctx, err error) error {
+	switch err {
+	case repository.ErrVersionConflict:
+		return preconditionFailed(c)
+	case repository.ErrNotFound:
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
+	case repository.ErrNotOwner:
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Data bukan milik akun ini"})
+	}
+	return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+}
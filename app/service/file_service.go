@@ -25,13 +25,13 @@ func NewFileService(repo repository.FileRepository, uploadBase string) *FileServ
 
 // ====================================
 // @Summary Upload foto
-// @Description Upload file foto (jpg/png, max 1MB). Jika admin, wajib isi alumni_id.
+// @Description Upload file foto (jpg/png, max 1MB). Dengan permission files:upload_any wajib isi alumni_id, selain itu otomatis memakai alumni yang terhubung ke akunnya.
 // @Tags File
 // @Security BearerAuth
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "File Foto"
-// @Param alumni_id formData string false "ID Alumni (hanya untuk admin)"
+// @Param alumni_id formData string false "ID Alumni (hanya dengan permission files:upload_any)"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400,401,403,500 {object} map[string]interface{}
 // @Router /api/files/photo [post]
@@ -41,13 +41,13 @@ func (s *FileService) UploadPhoto(c *fiber.Ctx) error {
 
 // ====================================
 // @Summary Upload sertifikat
-// @Description Upload file sertifikat (PDF, max 2MB). Jika admin, wajib isi alumni_id.
+// @Description Upload file sertifikat (PDF, max 2MB). Dengan permission files:upload_any wajib isi alumni_id, selain itu otomatis memakai alumni yang terhubung ke akunnya.
 // @Tags File
 // @Security BearerAuth
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "File Sertifikat"
-// @Param alumni_id formData string false "ID Alumni (hanya untuk admin)"
+// @Param alumni_id formData string false "ID Alumni (hanya dengan permission files:upload_any)"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400,401,403,500 {object} map[string]interface{}
 // @Router /api/files/certificate [post]
@@ -100,29 +100,24 @@ func (s *FileService) uploadHandler(c *fiber.Ctx, category string, allowed []str
 	}
 
 	var userID primitive.ObjectID
-	var role string
 	switch u := userAny.(type) {
 	case *model.User:
 		userID = u.ID
-		role = u.Role
 	case map[string]interface{}:
 		if idStr, ok := u["id"].(string); ok {
 			userID, _ = primitive.ObjectIDFromHex(idStr)
 		}
-		if r, ok := u["role"].(string); ok {
-			role = r
-		}
 	}
 
 	// Validasi alumni_id
 	var alumniObj *primitive.ObjectID
 	alumniIDForm := c.FormValue("alumni_id", "")
 
-	if role == "admin" {
+	if hasPermission(c, model.PermFilesUploadAny) {
 		if alumniIDForm == "" {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"success": false,
-				"message": "admin wajib mengisi alumni_id",
+				"message": "alumni_id wajib diisi",
 			})
 		}
 		oid, err := primitive.ObjectIDFromHex(alumniIDForm)
@@ -140,7 +135,15 @@ func (s *FileService) uploadHandler(c *fiber.Ctx, category string, allowed []str
 				"message": "user tidak boleh menentukan alumni_id",
 			})
 		}
-		alumniObj = &userID
+		// file user biasa selalu milik alumni yang terhubung ke akunnya
+		linked, ok := linkedAlumniID(c)
+		if !ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "akun belum terhubung dengan data alumni",
+			})
+		}
+		alumniObj = &linked
 	}
 
 	// Simpan ke folder sesuai kategori
@@ -191,13 +194,28 @@ func (s *FileService) uploadHandler(c *fiber.Ctx, category string, allowed []str
 
 // ====================================
 // @Summary Get semua file
-// @Description Mengambil semua file (khusus admin)
+// @Description Mengambil semua file (permission files:read_all). Kirim parameter cursor (kosong untuk halaman pertama) untuk mode cursor, terbaru dulu, dilanjutkan dengan meta.next_cursor.
 // @Tags File
 // @Security BearerAuth
 // @Produce json
+// @Param limit query int false "Jumlah per halaman, mode cursor (maks 100)" default(20)
+// @Param cursor query string false "Cursor dari meta.next_cursor (mode cursor)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
 // @Router /api/files [get]
 func (s *FileService) GetAll(c *fiber.Ctx) error {
+	if cursor, ok := cursorParam(c); ok {
+		limit := c.QueryInt("limit", 20)
+		if limit < 1 || limit > 100 {
+			limit = 20
+		}
+		list, next, err := s.repo.FindAllByCursor(limit, cursor)
+		if err != nil {
+			return cursorFailed(c, err)
+		}
+		return cursorPage(c, list, next, model.CursorMeta{Limit: limit, SortBy: "uploaded_at", Order: "DESC"})
+	}
+
 	list, err := s.repo.FindAll()
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
@@ -230,7 +248,7 @@ func (s *FileService) GetByID(c *fiber.Ctx) error {
 
 // ====================================
 // @Summary Hapus file
-// @Description Hapus file berdasarkan ID (admin / pemilik file)
+// @Description Hapus file berdasarkan ID (permission files:delete_any, atau files:delete_own untuk alumni pemilik file)
 // @Tags File
 // @Security BearerAuth
 // @Param id path string true "ID File"
@@ -249,31 +267,18 @@ func (s *FileService) DeleteByID(c *fiber.Ctx) error {
 		return c.Status(404).JSON(fiber.Map{"success": false, "message": "File tidak ditemukan"})
 	}
 
-	userAny := c.Locals("user")
-	if userAny == nil {
+	if c.Locals("user") == nil {
 		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
 	}
 
-	var userID primitive.ObjectID
-	var role string
-	switch u := userAny.(type) {
-	case *model.User:
-		userID = u.ID
-		role = u.Role
-	case map[string]interface{}:
-		if idStr, ok := u["id"].(string); ok {
-			userID, _ = primitive.ObjectIDFromHex(idStr)
-		}
-		if r, ok := u["role"].(string); ok {
-			role = r
+	// Hanya yang punya files:delete_any atau pemilik (alumni yang terhubung ke akun) yang boleh hapus
+	if !hasPermission(c, model.PermFilesDeleteAny) {
+		alumniID, linked := linkedAlumniID(c)
+		if !linked || f.AlumniID == nil || *f.AlumniID != alumniID {
+			return c.Status(403).JSON(fiber.Map{"success": false, "message": "tidak punya akses menghapus file ini"})
 		}
 	}
 
-	// Hanya admin atau uploader yang boleh hapus
-	if role != "admin" && f.UploadedBy != userID {
-		return c.Status(403).JSON(fiber.Map{"success": false, "message": "tidak punya akses menghapus file ini"})
-	}
-
 	os.Remove(f.FilePath)
 	if err := s.repo.DeleteByID(oid); err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Public key untuk memverifikasi access token (RS256 / EdDSA), termasuk key lama yang masih berlaku saat rotasi. Kosong jika server memakai HS256.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /.well-known/jwks.json [get]
+func JWKS(c *fiber.Ctx) error {
+	keys, err := utils.PublicJWKS()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Key JWT belum dikonfigurasi"})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(fiber.Map{"keys": keys})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kebijakan lockout login: setelah batas gagal terlewati, akun/IP dikunci
+// dengan durasi yang berlipat dua setiap kegagalan berikutnya.
+const (
+	maxUserFailures = 5  // per akun (ID user, atau username/email yang dicoba jika akun tidak ditemukan)
+	maxIPFailures   = 20 // per IP, lebih longgar karena IP bisa dipakai bersama
+	baseLockout     = time.Minute
+	maxLockout      = 24 * time.Hour
+)
+
+type LockoutService struct {
+	repo repository.LoginAttemptRepository
+}
+
+func NewLockoutService(repo repository.LoginAttemptRepository) *LockoutService {
+	return &LockoutService{repo: repo}
+}
+
+// lockoutAccount: counter akun yang dipakai lockout
+type lockoutAccount struct {
+	kind    string
+	subject string
+}
+
+// accountFor memilih counter akun: user yang ditemukan selalu dihitung per ID-nya, jadi
+// username, email, dan kode 2FA berbagi satu counter. Identifier yang dicoba hanya
+// dipakai untuk akun yang tidak dikenal.
+func accountFor(user *model.User, identifier string) lockoutAccount {
+	if user != nil {
+		return lockoutAccount{kind: model.AttemptKindAccount, subject: user.ID.Hex()}
+	}
+	return lockoutAccount{kind: model.AttemptKindUser, subject: normalizeIdentifier(identifier)}
+}
+
+func (a lockoutAccount) key() string {
+	return repository.AttemptKey(a.kind, a.subject)
+}
+
+// Check mengembalikan waktu berakhirnya lockout jika akun atau IP sedang terkunci
+func (s *LockoutService) Check(ctx context.Context, account lockoutAccount, ip string) (time.Time, bool, error) {
+	var until time.Time
+	for _, key := range []string{
+		account.key(),
+		repository.AttemptKey(model.AttemptKindIP, ip),
+	} {
+		a, err := s.repo.Get(ctx, key)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if a != nil && a.LockedUntil != nil && a.LockedUntil.After(until) {
+			until = *a.LockedUntil
+		}
+	}
+	return until, until.After(time.Now()), nil
+}
+
+// RecordFailure menambah counter gagal untuk akun dan IP, lalu mengunci jika melewati batas
+func (s *LockoutService) RecordFailure(ctx context.Context, account lockoutAccount, ip string) {
+	s.registerFailure(ctx, account.kind, account.subject, maxUserFailures)
+	s.registerFailure(ctx, model.AttemptKindIP, ip, maxIPFailures)
+}
+
+// RecordSuccess mereset counter akun. Counter IP sengaja tidak direset agar
+// penyerang tidak bisa "membersihkan" IP-nya dengan login ke akun miliknya sendiri.
+func (s *LockoutService) RecordSuccess(ctx context.Context, account lockoutAccount) {
+	key := account.key()
+	if err := s.repo.Reset(ctx, key); err != nil {
+		log.Printf("lockout: gagal reset counter %s: %v", key, err)
+	}
+}
+
+func (s *LockoutService) registerFailure(ctx context.Context, kind, subject string, threshold int) {
+	a, err := s.repo.RegisterFailure(ctx, kind, subject)
+	if err != nil {
+		log.Printf("lockout: gagal mencatat login gagal %s:%s: %v", kind, subject, err)
+		return
+	}
+	if a.Failures < threshold {
+		return
+	}
+
+	until := time.Now().Add(lockoutDuration(a.Failures - threshold))
+	if err := s.repo.SetLockedUntil(ctx, a.Key, until); err != nil {
+		log.Printf("lockout: gagal mengunci %s: %v", a.Key, err)
+	}
+}
+
+// lockoutDuration: 1m, 2m, 4m, ... maksimal 24 jam
+func lockoutDuration(excess int) time.Duration {
+	d := baseLockout
+	for i := 0; i < excess && d < maxLockout; i++ {
+		d *= 2
+	}
+	if d > maxLockout {
+		d = maxLockout
+	}
+	return d
+}
+
+func normalizeIdentifier(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// GetLocked godoc
+// @Summary Daftar lockout login
+// @Description Mengambil semua akun / IP yang sedang terkunci karena login gagal berulang (khusus admin)
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lockouts/ [get]
+func (s *LockoutService) GetLocked(c *fiber.Ctx) error {
+	data, err := s.repo.ListLocked(context.Background())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// Clear godoc
+// @Summary Buka lockout login
+// @Description Menghapus counter login gagal untuk akun (kind=account, subject=ID user), username/email tidak dikenal (kind=user) atau IP (kind=ip) (khusus admin)
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Param kind path string true "account, user, atau ip"
+// @Param subject path string true "ID user, username/email, atau alamat IP"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /lockouts/{kind}/{subject} [delete]
+func (s *LockoutService) Clear(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+	subject := c.Params("subject")
+
+	switch kind {
+	case model.AttemptKindAccount:
+		subject = strings.TrimSpace(subject)
+	case model.AttemptKindUser:
+		subject = normalizeIdentifier(subject)
+	case model.AttemptKindIP:
+	default:
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "kind harus 'account', 'user', atau 'ip'"})
+	}
+	if subject == "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "subject wajib diisi"})
+	}
+
+	if err := s.repo.Reset(context.Background(), repository.AttemptKey(kind, subject)); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Lockout berhasil dibuka"})
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+
+	"praktikum3/app/utils"
+)
+
+var errPatchNotObject = errors.New("Body harus berupa object JSON")
+
+// parseMergePatch membaca body JSON Merge Patch (RFC 7396).
+// Field di luar allowed dikembalikan sebagai error per field.
+func parseMergePatch(body []byte, allowed []string) (map[string]json.RawMessage, utils.FieldErrors, error) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, nil, errPatchNotObject
+	}
+
+	errs := utils.FieldErrors{}
+	for key := range patch {
+		if !contains(allowed, key) {
+			errs.Add(key, "Field tidak bisa diubah")
+		}
+	}
+	return patch, errs, nil
+}
+
+// applyMergePatch menerapkan patch ke salinan current (bentuk request DTO) lalu hasilnya di-decode ke target.
+// Nilai null menghapus field sehingga target mendapat zero value.
+func applyMergePatch(current interface{}, patch map[string]json.RawMessage, target interface{}) error {
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return err
+	}
+
+	for key, value := range patch {
+		if isJSONNull(value) {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	raw, err = json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
+
+// patchFields memisahkan field patch menjadi yang di-$set dan yang di-$unset (nilai null)
+func patchFields(patch map[string]json.RawMessage) (set, unset []string) {
+	for key, value := range patch {
+		if isJSONNull(value) {
+			unset = append(unset, key)
+		} else {
+			set = append(set, key)
+		}
+	}
+	return set, unset
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(raw) == "null"
+}
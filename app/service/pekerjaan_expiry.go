@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"praktikum3/app/repository"
+)
+
+const defaultContractExpiryInterval = time.Hour
+
+// ContractExpiryInterval jeda antar pengecekan kontrak habis, dari CONTRACT_EXPIRY_INTERVAL (mis. "30m"), default 1 jam
+func ContractExpiryInterval() time.Duration {
+	if raw := os.Getenv("CONTRACT_EXPIRY_INTERVAL"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err == nil && d > 0 {
+			return d
+		}
+		log.Printf("⚠️ CONTRACT_EXPIRY_INTERVAL tidak valid (%q), pakai %s", raw, defaultContractExpiryInterval)
+	}
+	return defaultContractExpiryInterval
+}
+
+// ✅ StartContractExpiry menjalankan job latar yang mengubah pekerjaan aktif dengan tanggal selesai
+// yang sudah lewat menjadi selesai. Dijalankan sekali saat start, lalu tiap interval sampai ctx dibatalkan.
+func StartContractExpiry(ctx context.Context, repo repository.PekerjaanRepository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			n, err := repo.ExpireContracts(startOfDay(time.Now()))
+			if err != nil {
+				log.Println("⚠️ Gagal memperbarui kontrak yang habis:", err)
+			} else if n > 0 {
+				log.Printf("✅ %d pekerjaan dengan kontrak habis diubah menjadi selesai", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"praktikum3/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parsePekerjaanQuery membaca & memvalidasi parameter list pekerjaan.
+// Error yang dikembalikan berisi pesan untuk respon 400.
+func parsePekerjaanQuery(c *fiber.Ctx) (model.PekerjaanQuery, int, error) {
+	q := model.PekerjaanQuery{
+		Search:     strings.TrimSpace(c.Query("search", "")),
+		SearchMode: strings.ToLower(c.Query("searchMode", model.SearchContains)),
+		SortBy:     c.Query("sortBy", "created_at"),
+		Order:      strings.ToUpper(c.Query("order", "DESC")),
+	}
+	if !contains(model.PekerjaanSortFields, q.SortBy) {
+		return q, 0, fmt.Errorf("sortBy harus salah satu dari: %s", strings.Join(model.PekerjaanSortFields, ", "))
+	}
+	if q.Order != "ASC" && q.Order != "DESC" {
+		return q, 0, fmt.Errorf("order harus ASC atau DESC")
+	}
+	if !contains(model.PekerjaanSearchModes, q.SearchMode) {
+		return q, 0, fmt.Errorf("searchMode harus salah satu dari: %s", strings.Join(model.PekerjaanSearchModes, ", "))
+	}
+	if utf8.RuneCountInString(q.Search) > model.MaxPekerjaanSearch {
+		return q, 0, fmt.Errorf("search maksimal %d karakter", model.MaxPekerjaanSearch)
+	}
+
+	page, limit, err := queryPagination(c, model.MaxPekerjaanLimit)
+	if err != nil {
+		return q, 0, err
+	}
+	q.Limit = limit
+	q.Offset = (page - 1) * limit
+
+	if q.Filters, err = parsePekerjaanFilters(c); err != nil {
+		return q, 0, err
+	}
+	return q, page, nil
+}
+
+// parsePekerjaanFilters membaca filter terstruktur list pekerjaan
+func parsePekerjaanFilters(c *fiber.Ctx) (model.PekerjaanFilters, error) {
+	f := model.PekerjaanFilters{
+		StatusPekerjaan: strings.TrimSpace(c.Query("status_pekerjaan", "")),
+		BidangIndustri:  strings.TrimSpace(c.Query("bidang_industri", "")),
+		LokasiKerja:     strings.TrimSpace(c.Query("lokasi_kerja", "")),
+		Jurusan:         strings.TrimSpace(c.Query("jurusan", "")),
+	}
+
+	var err error
+	if f.Angkatan, err = queryYear(c, "angkatan"); err != nil {
+		return f, err
+	}
+	if f.MulaiDari, f.MulaiSampai, err = queryDateRange(c, "mulai_dari", "mulai_sampai"); err != nil {
+		return f, err
+	}
+	if f.SelesaiDari, f.SelesaiSampai, err = queryDateRange(c, "selesai_dari", "selesai_sampai"); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// queryDateRange membaca pasangan tanggal opsional (YYYY-MM-DD) dan memastikan awal <= akhir
+func queryDateRange(c *fiber.Ctx, fromKey, toKey string) (*time.Time, *time.Time, error) {
+	from, err := queryDate(c, fromKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := queryDate(c, toKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, fmt.Errorf("%s tidak boleh setelah %s", fromKey, toKey)
+	}
+	return from, to, nil
+}
+
+func queryDate(c *fiber.Ctx, key string) (*time.Time, error) {
+	raw := strings.TrimSpace(c.Query(key, ""))
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s harus berformat YYYY-MM-DD", key)
+	}
+	return &t, nil
+}
+
+// queryPagination membaca page & limit (default 1 & 10). Nilai bukan angka positif ditolak,
+// limit di atas maxLimit dipotong ke maxLimit.
+func queryPagination(c *fiber.Ctx, maxLimit int) (page, limit int, err error) {
+	if page, err = queryPositiveInt(c, "page", 1); err != nil {
+		return 0, 0, err
+	}
+	if limit, err = queryPositiveInt(c, "limit", 10); err != nil {
+		return 0, 0, err
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return page, limit, nil
+}
+
+// queryPositiveInt membaca parameter angka opsional yang harus >= 1
+func queryPositiveInt(c *fiber.Ctx, key string, def int) (int, error) {
+	raw := strings.TrimSpace(c.Query(key, ""))
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s harus berupa angka positif", key)
+	}
+	return n, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"praktikum3/app/model"
 	"praktikum3/app/repository"
+	"praktikum3/app/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,26 +22,51 @@ func NewPekerjaanService(repo repository.PekerjaanRepository) *PekerjaanService
 // ================== GET ALL ==================
 // GetAll godoc
 // @Summary Get semua pekerjaan
-// @Description Mengambil semua data pekerjaan alumni tanpa parameter
+// @Description Mengambil data pekerjaan alumni dengan pencarian, filter terstruktur, urutan, dan paginasi page/limit. Filter yang diterapkan dikembalikan di meta.filters.
+// @Description Kirim parameter cursor (kosong untuk halaman pertama) untuk mode cursor: page diabaikan dan halaman berikutnya diambil dengan meta.next_cursor.
 // @Tags Pekerjaan
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Produce json
+// @Param search query string false "Cari perusahaan / posisi / bidang industri (teks biasa, bukan regex)"
+// @Param searchMode query string false "Cara pencocokan search" Enums(contains, prefix, exact) default(contains)
+// @Param sortBy query string false "Urutkan berdasarkan" Enums(nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, tanggal_selesai_kerja, created_at, updated_at) default(created_at)
+// @Param order query string false "Arah urutan" Enums(ASC, DESC) default(DESC)
+// @Param status_pekerjaan query string false "Filter status pekerjaan"
+// @Param bidang_industri query string false "Filter bidang industri"
+// @Param lokasi_kerja query string false "Filter lokasi kerja"
+// @Param mulai_dari query string false "Tanggal mulai kerja dari (YYYY-MM-DD)"
+// @Param mulai_sampai query string false "Tanggal mulai kerja sampai (YYYY-MM-DD)"
+// @Param selesai_dari query string false "Tanggal selesai kerja dari (YYYY-MM-DD)"
+// @Param selesai_sampai query string false "Tanggal selesai kerja sampai (YYYY-MM-DD)"
+// @Param jurusan query string false "Filter jurusan alumni"
+// @Param angkatan query int false "Filter angkatan alumni"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(10)
+// @Param cursor query string false "Cursor dari meta.next_cursor (mode cursor)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
 // @Router /pekerjaan/ [get]
 func (s *PekerjaanService) GetAll(c *fiber.Ctx) error {
-	search := c.Query("search", "")
-	sortBy := c.Query("sortBy", "created_at")
-	order := c.Query("order", "DESC")
-	limit := c.QueryInt("limit", 10)
-	page := c.QueryInt("page", 1)
-	offset := (page - 1) * limit
-
-	data, err := s.repo.GetAllWithQuery(search, sortBy, order, limit, offset)
+	q, page, err := parsePekerjaanQuery(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	if cursor, ok := cursorParam(c); ok {
+		data, next, err := s.repo.GetAllByCursor(q, cursor)
+		if err != nil {
+			return cursorFailed(c, err)
+		}
+		return cursorPage(c, data, next, model.CursorMeta{Limit: q.Limit, SortBy: q.SortBy, Order: q.Order, Search: q.Search, Filters: q.Filters})
+	}
+
+	data, err := s.repo.GetAllWithQuery(q)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 
-	count, err := s.repo.Count(search)
+	count, err := s.repo.Count(q)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
@@ -48,19 +74,25 @@ func (s *PekerjaanService) GetAll(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    data,
-		"meta": fiber.Map{
-			"total": count,
-			"page":  page,
-			"limit": limit,
+		"meta": model.MetaInfo{
+			Page:    page,
+			Limit:   q.Limit,
+			Total:   count,
+			Pages:   (count + q.Limit - 1) / q.Limit,
+			SortBy:  q.SortBy,
+			Order:   q.Order,
+			Search:  q.Search,
+			Filters: q.Filters,
 		},
 	})
 }
 
 // ================== GET BY ID ==================
 // @Summary Get pekerjaan by ID
-// @Description Mendapatkan detail pekerjaan berdasarkan ID
+// @Description Mendapatkan detail pekerjaan berdasarkan ID. Versi data dikirim di header ETag untuk dipakai sebagai If-Match saat mengubah.
 // @Tags Pekerjaan
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path string true "ID Pekerjaan"
 // @Produce json
 // @Success 200 {object} map[string]interface{}
@@ -81,6 +113,7 @@ func (s *PekerjaanService) GetByID(c *fiber.Ctx) error {
 		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
 	}
 
+	setETag(c, data.Version)
 	return c.JSON(fiber.Map{"success": true, "data": data})
 }
 
@@ -89,6 +122,7 @@ func (s *PekerjaanService) GetByID(c *fiber.Ctx) error {
 // @Description Mendapatkan semua pekerjaan milik alumni tertentu
 // @Tags Pekerjaan
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param alumni_id path string true "ID Alumni"
 // @Produce json
 // @Success 200 {object} map[string]interface{}
@@ -101,14 +135,12 @@ func (s *PekerjaanService) GetByAlumniID(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": "alumni_id tidak valid"})
 	}
 
-	userData := c.Locals("user")
-	claimsMap, ok := userData.(map[string]interface{})
-	if !ok {
+	if _, ok := c.Locals("user").(map[string]interface{}); !ok {
 		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Invalid token data"})
 	}
 
-	role, _ := claimsMap["role"].(string)
-	includeDeleted := role == "admin"
+	// data yang sudah dihapus hanya terlihat oleh yang boleh restore
+	includeDeleted := hasPermission(c, model.PermPekerjaanRestore)
 
 	data, err := s.repo.GetByAlumniID(alumniID, includeDeleted)
 	if err != nil {
@@ -121,14 +153,14 @@ func (s *PekerjaanService) GetByAlumniID(c *fiber.Ctx) error {
 // ================== CREATE ==================
 // Create godoc
 // @Summary Tambah pekerjaan alumni
-// @Description Tambahkan data pekerjaan untuk alumni tertentu (wajib kirim alumni_id)
+// @Description Tambahkan data pekerjaan untuk alumni tertentu (wajib kirim alumni_id). status_pekerjaan: aktif, selesai, atau resigned. Tanggal selesai tidak boleh sebelum tanggal mulai; pekerjaan aktif hanya boleh punya tanggal selesai di masa depan (akhir kontrak).
 // @Tags Pekerjaan
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param body body model.CreatePekerjaanReq true "Data pekerjaan untuk alumni tertentu"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,500 {object} map[string]interface{}
+// @Failure 400,422,500 {object} map[string]interface{}
 // @Router /pekerjaan/ [post]
 func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 	var in model.CreatePekerjaanReq
@@ -150,6 +182,14 @@ func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 		end = &t
 	}
 
+	in.StatusPekerjaan = normalizeStatusPekerjaan(in.StatusPekerjaan)
+	errs := utils.FieldErrors{}
+	validateStatusPekerjaan(errs, "", in.StatusPekerjaan)
+	validateTanggalPekerjaan(errs, in.StatusPekerjaan, &start, end, time.Now())
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
 	id, err := s.repo.Create(in, &start, end)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
@@ -160,15 +200,17 @@ func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 
 // ================== UPDATE ==================
 // @Summary Update pekerjaan
-// @Description Mengupdate data pekerjaan berdasarkan ID
+// @Description Mengupdate data pekerjaan berdasarkan ID. Wajib kirim If-Match berisi ETag dari GET; 412 kalau data sudah diubah pihak lain.
+// @Description Status hanya bisa berpindah aktif -> selesai / resigned (atau selesai <-> resigned); pekerjaan yang sudah berakhir tidak bisa aktif lagi.
 // @Tags Pekerjaan
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "ID Pekerjaan"
+// @Param If-Match header string true "ETag dari GET /pekerjaan/{id}"
 // @Param pekerjaan body model.UpdatePekerjaanReq true "Data pekerjaan"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,404,500 {object} map[string]interface{}
+// @Failure 400,404,412,422,428,500 {object} map[string]interface{}
 // @Router /pekerjaan/{id} [put]
 func (s *PekerjaanService) Update(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -196,50 +238,194 @@ func (s *PekerjaanService) Update(c *fiber.Ctx) error {
 		end = &t
 	}
 
-	if err := s.repo.Update(objectID, in, &start, end); err != nil {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+
+	existing, err := s.repo.GetByID(objectID)
+	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
+	if existing == nil || existing.DeletedAt != nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
+	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil diperbarui"})
+	in.StatusPekerjaan = normalizeStatusPekerjaan(in.StatusPekerjaan)
+	errs := utils.FieldErrors{}
+	validateStatusPekerjaan(errs, existing.StatusPekerjaan, in.StatusPekerjaan)
+	validateTanggalPekerjaan(errs, in.StatusPekerjaan, &start, end, time.Now())
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	if err := s.repo.Update(objectID, in, &start, end, version); err != nil {
+		return versionedWriteFailed(c, err)
+	}
+
+	return s.respondUpdated(c, objectID)
+}
+
+// ================== PATCH ==================
+// @Summary Ubah sebagian data pekerjaan
+// @Description Partial update dengan JSON Merge Patch: hanya field yang dikirim yang berubah (tanggal format YYYY-MM-DD), null mengosongkan gaji_range, tanggal_selesai_kerja, dan deskripsi_pekerjaan. Wajib kirim If-Match berisi ETag dari GET.
+// @Tags Pekerjaan
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Pekerjaan"
+// @Param If-Match header string true "ETag dari GET /pekerjaan/{id}"
+// @Param pekerjaan body model.UpdatePekerjaanReq true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,412,422,428,500 {object} map[string]interface{}
+// @Router /pekerjaan/{id} [patch]
+func (s *PekerjaanService) Patch(c *fiber.Ctx) error {
+	objectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID pekerjaan tidak valid"})
+	}
+
+	patch, errs, err := parseMergePatch(c.Body(), model.PatchablePekerjaanFields)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if raw, ok := patch["tanggal_mulai_kerja"]; ok && isJSONNull(raw) {
+		errs.Add("tanggal_mulai_kerja", "Tanggal mulai tidak boleh dikosongkan")
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	existing, err := s.repo.GetByID(objectID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if existing == nil || existing.DeletedAt != nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan"})
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+	if !versionMatches(version, existing.Version) {
+		return preconditionFailed(c)
+	}
+
+	var in model.UpdatePekerjaanReq
+	if err := applyMergePatch(model.NewUpdatePekerjaanReq(*existing), patch, &in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	p := model.Pekerjaan{
+		NamaPerusahaan:     in.NamaPerusahaan,
+		PosisiJabatan:      in.PosisiJabatan,
+		BidangIndustri:     in.BidangIndustri,
+		LokasiKerja:        in.LokasiKerja,
+		GajiRange:          in.GajiRange,
+		StatusPekerjaan:    in.StatusPekerjaan,
+		DeskripsiPekerjaan: in.DeskripsiPekerjaan,
+	}
+	// tanggal hanya di-parse kalau ikut dikirim, nilai lama tidak divalidasi ulang
+	if _, ok := patch["tanggal_mulai_kerja"]; ok {
+		start, err := time.Parse("2006-01-02", in.TanggalMulaiKerja)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Tanggal mulai tidak valid"})
+		}
+		p.TanggalMulaiKerja = &start
+	}
+	if raw, ok := patch["tanggal_selesai_kerja"]; ok && !isJSONNull(raw) {
+		end, err := time.Parse("2006-01-02", in.TanggalSelesaiKerja)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Tanggal selesai tidak valid"})
+		}
+		p.TanggalSelesaiKerja = &end
+	}
+
+	// status & tanggal dicek ulang dengan nilai gabungan kalau salah satunya ikut diubah
+	_, statusSent := patch["status_pekerjaan"]
+	_, mulaiSent := patch["tanggal_mulai_kerja"]
+	_, selesaiSent := patch["tanggal_selesai_kerja"]
+	if statusSent {
+		p.StatusPekerjaan = normalizeStatusPekerjaan(in.StatusPekerjaan)
+		validateStatusPekerjaan(errs, existing.StatusPekerjaan, p.StatusPekerjaan)
+	}
+	if statusSent || mulaiSent || selesaiSent {
+		mulai, selesai := existing.TanggalMulaiKerja, existing.TanggalSelesaiKerja
+		if mulaiSent {
+			mulai = p.TanggalMulaiKerja
+		}
+		if selesaiSent {
+			selesai = p.TanggalSelesaiKerja
+		}
+		validateTanggalPekerjaan(errs, p.StatusPekerjaan, mulai, selesai, time.Now())
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	set, unset := patchFields(patch)
+	if err := s.repo.Patch(objectID, &p, set, unset, version); err != nil {
+		return versionedWriteFailed(c, err)
+	}
+
+	return s.respondUpdated(c, objectID)
+}
+
+// respondUpdated mengirim data pekerjaan terbaru beserta ETag versi barunya
+func (s *PekerjaanService) respondUpdated(c *fiber.Ctx, id primitive.ObjectID) error {
+	updated, err := s.repo.GetByID(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if updated != nil {
+		setETag(c, updated.Version)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil diperbarui", "data": updated})
 }
 
 // ================== SOFT DELETE ==================
 // @Summary Soft delete pekerjaan
-// @Description Menghapus pekerjaan tanpa menghapus permanen
+// @Description Menghapus pekerjaan tanpa menghapus permanen. Tanpa permission pekerjaan:delete hanya bisa menghapus pekerjaan milik alumni yang terhubung ke akunnya. Wajib kirim If-Match berisi ETag dari GET.
 // @Tags Pekerjaan
 // @Security BearerAuth
 // @Param id path string true "ID Pekerjaan"
+// @Param If-Match header string true "ETag dari GET /pekerjaan/{id}"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,500 {object} map[string]interface{}
+// @Failure 400,401,403,404,412,428,500 {object} map[string]interface{}
 // @Router /pekerjaan/{id} [delete]
 func (s *PekerjaanService) SoftDelete(c *fiber.Ctx) error {
-	userData := c.Locals("user")
-	claimsMap, ok := userData.(map[string]interface{})
-	if !ok {
+	if _, ok := c.Locals("user").(map[string]interface{}); !ok {
 		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Invalid token data"})
 	}
 
-	role, _ := claimsMap["role"].(string)
-	userIDStr, _ := claimsMap["id"].(string)
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"success": false, "message": "User ID tidak valid"})
-	}
-
 	idStr := c.Params("id")
 	objectID, err := primitive.ObjectIDFromHex(idStr)
 	if err != nil {
 		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID pekerjaan tidak valid"})
 	}
 
-	if role == "admin" {
-		err = s.repo.SoftDeleteByAdmin(objectID)
-	} else {
-		err = s.repo.SoftDeleteByUser(objectID, userID)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return preconditionRequired(c)
+	}
+
+	switch {
+	case hasPermission(c, model.PermPekerjaanDelete):
+		err = s.repo.SoftDeleteByAdmin(objectID, version)
+	case hasPermission(c, model.PermPekerjaanDeleteOwn):
+		// kepemilikan lewat alumni_id yang terhubung ke akun, bukan ID user
+		alumniID, linked := linkedAlumniID(c)
+		if !linked {
+			return c.Status(403).JSON(fiber.Map{"success": false, "message": "Akun belum terhubung dengan data alumni"})
+		}
+		err = s.repo.SoftDeleteByUser(objectID, alumniID, version)
+	default:
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Tidak punya akses menghapus pekerjaan"})
 	}
 
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+		return versionedWriteFailed(c, err)
 	}
 
 	return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan dihapus (soft delete)"})
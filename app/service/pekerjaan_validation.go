@@ -0,0 +1,47 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/utils"
+)
+
+// normalizeStatusPekerjaan menyeragamkan status dari client ("Aktif " -> "aktif")
+func normalizeStatusPekerjaan(status string) string {
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+// validateStatusPekerjaan memeriksa status termasuk daftar yang diizinkan dan perpindahannya sah.
+// prev diisi status tersimpan saat update, kosong untuk data baru.
+func validateStatusPekerjaan(errs utils.FieldErrors, prev, status string) {
+	switch {
+	case status == "":
+		errs.Add("status_pekerjaan", "Status pekerjaan wajib diisi")
+	case !model.IsValidStatusPekerjaan(status):
+		errs.Add("status_pekerjaan", "Status pekerjaan harus salah satu dari: "+strings.Join(model.StatusPekerjaanList, ", "))
+	case !model.CanTransitionStatusPekerjaan(prev, status):
+		errs.Add("status_pekerjaan", "Status pekerjaan tidak bisa diubah dari "+prev+" ke "+status)
+	}
+}
+
+// validateTanggalPekerjaan memeriksa konsistensi tanggal: selesai tidak sebelum mulai, dan pekerjaan
+// aktif tidak punya tanggal selesai yang sudah lewat (tanggal selesai di masa depan = akhir kontrak).
+func validateTanggalPekerjaan(errs utils.FieldErrors, status string, mulai, selesai *time.Time, now time.Time) {
+	if selesai == nil {
+		return
+	}
+	if mulai != nil && selesai.Before(*mulai) {
+		errs.Add("tanggal_selesai_kerja", "Tanggal selesai tidak boleh sebelum tanggal mulai")
+	}
+	if status == model.StatusPekerjaanAktif && selesai.Before(startOfDay(now)) {
+		errs.Add("tanggal_selesai_kerja", "Pekerjaan aktif tidak boleh punya tanggal selesai yang sudah lewat")
+	}
+}
+
+// startOfDay awal hari (UTC), sama dengan tanggal YYYY-MM-DD hasil time.Parse
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
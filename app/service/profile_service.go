@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxAlamatLength = 255
+
+type ProfileService struct {
+	userRepo   repository.IUserRepository
+	alumniRepo repository.AlumniRepository
+	tokenRepo  repository.UserTokenRepository
+	password   utils.PasswordChecker
+	mailer     utils.EmailSender
+}
+
+func NewProfileService(
+	userRepo repository.IUserRepository,
+	alumniRepo repository.AlumniRepository,
+	tokenRepo repository.UserTokenRepository,
+	password utils.PasswordChecker,
+	mailer utils.EmailSender,
+) *ProfileService {
+	return &ProfileService{userRepo: userRepo, alumniRepo: alumniRepo, tokenRepo: tokenRepo, password: password, mailer: mailer}
+}
+
+// GetMe godoc
+// @Summary Profil saya
+// @Description Mengambil akun user yang sedang login
+// @Tags Profile
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401,500 {object} map[string]interface{}
+// @Router /me [get]
+func (s *ProfileService) GetMe(c *fiber.Ctx) error {
+	user, err := s.loadUser(c)
+	if user == nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "data": user})
+}
+
+// UpdateMe godoc
+// @Summary Ubah profil saya
+// @Description Mengubah akun sendiri. Hanya email yang bisa diubah: wajib menyertakan current_password, dan email lama tetap dipakai sampai link konfirmasi yang dikirim ke email baru dibuka. Username, role, dan field lain ditolak.
+// @Tags Profile
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.UpdateMeRequest true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{} "Link konfirmasi dikirim ke email baru"
+// @Failure 400,401,403,409,500 {object} map[string]interface{}
+// @Router /me [put]
+func (s *ProfileService) UpdateMe(c *fiber.Ctx) error {
+	user, err := s.loadUser(c)
+	if user == nil {
+		return err
+	}
+
+	// current_password bukan field akun, hanya konfirmasi pemilik
+	allowed := append([]string{"current_password"}, model.SelfEditableUserFields...)
+	if status, msg := checkSelfEditable(c.Body(), user, allowed); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+	var req model.UpdateMeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+
+	if req.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		if !utils.IsValidEmail(email) {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+		}
+		if email != user.Email {
+			return s.requestEmailChange(c, user, email, req.CurrentPassword)
+		}
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": "Profil berhasil diperbarui", "data": user})
+}
+
+// requestEmailChange mengirim link konfirmasi ke email baru. Email login baru diganti saat
+// link dibuka (POST /verify-email), jadi token akses curian saja tidak cukup untuk
+// memindahkan akun ke alamat lain lalu reset password.
+func (s *ProfileService) requestEmailChange(c *fiber.Ctx, user *model.User, email, currentPassword string) error {
+	if currentPassword == "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "current_password wajib diisi untuk mengganti email"})
+	}
+	if !s.password.Check(user.PasswordHash, currentPassword) {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Password saat ini salah"})
+	}
+
+	ctx := context.Background()
+	other, err := s.userRepo.FindByUsernameOrEmail(ctx, email)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if other != nil && other.ID != user.ID {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Email sudah digunakan akun lain"})
+	}
+
+	token, err := issueUserToken(ctx, s.tokenRepo, &model.UserToken{
+		UserID:  user.ID,
+		Purpose: model.TokenPurposeEmailChange,
+		Email:   email,
+	}, verificationTokenTTL)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	link := appBaseURL() + "/verify-email?token=" + token
+	body := "Halo " + user.Username + ",\n\n" +
+		"Klik link berikut untuk memakai alamat ini sebagai email akun kamu (berlaku 24 jam):\n" + link + "\n\n" +
+		"Abaikan email ini jika kamu tidak meminta penggantian email."
+	if err := s.mailer.Send(email, "Konfirmasi email baru akun Alumni", body); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mengirim email konfirmasi: " + err.Error()})
+	}
+
+	return c.Status(202).JSON(fiber.Map{
+		"success": true,
+		"message": "Link konfirmasi dikirim ke " + email + ". Email lama tetap dipakai sampai link tersebut dibuka",
+		"data":    user,
+	})
+}
+
+// GetMyAlumni godoc
+// @Summary Data alumni saya
+// @Description Mengambil data alumni yang terhubung ke akun yang sedang login
+// @Tags Profile
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401,404,500 {object} map[string]interface{}
+// @Router /me/alumni [get]
+func (s *ProfileService) GetMyAlumni(c *fiber.Ctx) error {
+	alumni, err := s.loadAlumni(c)
+	if alumni == nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "data": alumni})
+}
+
+// UpdateMyAlumni godoc
+// @Summary Ubah data alumni saya
+// @Description Alumni mengubah data kontaknya sendiri (email, no_telepon, alamat). Perubahan NIM, angkatan, dan field akademik lain ditolak karena hanya bisa diubah admin.
+// @Tags Profile
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.UpdateMyAlumniRequest true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401,403,404,500 {object} map[string]interface{}
+// @Router /me/alumni [put]
+func (s *ProfileService) UpdateMyAlumni(c *fiber.Ctx) error {
+	alumni, err := s.loadAlumni(c)
+	if alumni == nil {
+		return err
+	}
+
+	if status, msg := checkSelfEditable(c.Body(), alumni, model.SelfEditableAlumniFields); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+	var req model.UpdateMyAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+
+	if req.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		if !utils.IsValidEmail(email) {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+		}
+		req.Email = &email
+	}
+	if req.NoTelepon != nil {
+		phone := strings.TrimSpace(*req.NoTelepon)
+		if phone != "" && !utils.IsValidPhone(phone) {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format nomor telepon tidak valid"})
+		}
+		req.NoTelepon = &phone
+	}
+	if req.Alamat != nil {
+		alamat := strings.TrimSpace(*req.Alamat)
+		if len(alamat) > maxAlamatLength {
+			return c.Status(400).JSON(fiber.Map{"success": false, "message": "Alamat maksimal 255 karakter"})
+		}
+		req.Alamat = &alamat
+	}
+
+	if err := s.alumniRepo.UpdateContact(alumni.ID, req); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	alumni, err = s.loadAlumni(c)
+	if alumni == nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Data alumni berhasil diperbarui", "data": alumni})
+}
+
+// loadUser memuat akun user login. nil berarti response error sudah dikirim.
+func (s *ProfileService) loadUser(c *fiber.Ctx) (*model.User, error) {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return nil, c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	user, err := s.userRepo.FindByID(context.Background(), userID)
+	if err != nil {
+		return nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if user == nil {
+		return nil, c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+	return user, nil
+}
+
+// loadAlumni memuat data alumni milik user login. nil berarti response error sudah dikirim.
+func (s *ProfileService) loadAlumni(c *fiber.Ctx) (*model.Alumni, error) {
+	user, err := s.loadUser(c)
+	if user == nil {
+		return nil, err
+	}
+	// alumni_id dibaca dari database, bukan token, agar klaim yang baru disetujui langsung berlaku
+	if user.AlumniID == nil {
+		return nil, c.Status(404).JSON(fiber.Map{"success": false, "message": "Akun belum terhubung dengan data alumni"})
+	}
+
+	alumni, err := s.alumniRepo.GetByID(*user.AlumniID)
+	if err != nil {
+		return nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if alumni == nil {
+		return nil, c.Status(404).JSON(fiber.Map{"success": false, "message": "Data alumni tidak ditemukan"})
+	}
+	return alumni, nil
+}
+
+// checkSelfEditable memeriksa body JSON terhadap daftar field yang boleh diubah sendiri.
+// Field lain boleh ikut dikirim (misal hasil GET dikirim ulang) selama nilainya tidak berubah.
+func checkSelfEditable(body []byte, current interface{}, allowed []string) (int, string) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return 400, "Body request tidak valid"
+	}
+
+	raw, _ := json.Marshal(current)
+	var existing map[string]interface{}
+	_ = json.Unmarshal(raw, &existing)
+
+	var locked []string
+	for key, value := range fields {
+		if contains(allowed, key) {
+			continue
+		}
+		old, known := existing[key]
+		if !known {
+			return 400, "Field tidak dikenal: " + key
+		}
+		if !reflect.DeepEqual(old, value) {
+			locked = append(locked, key)
+		}
+	}
+	if len(locked) > 0 {
+		sort.Strings(locked)
+		return 403, "Field berikut hanya bisa diubah admin: " + strings.Join(locked, ", ")
+	}
+	return 0, ""
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// nama role: huruf kecil, angka, '_' atau '-'
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+type RoleService struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.IUserRepository
+}
+
+func NewRoleService(roleRepo repository.RoleRepository, userRepo repository.IUserRepository) *RoleService {
+	return &RoleService{roleRepo: roleRepo, userRepo: userRepo}
+}
+
+// GetPermissions godoc
+// @Summary Daftar permission
+// @Description Mengambil seluruh permission yang dikenal sistem
+// @Tags Role
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /permissions [get]
+func (s *RoleService) GetPermissions(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"success": true, "data": model.Permissions})
+}
+
+// GetAll godoc
+// @Summary Get semua role
+// @Description Mengambil semua role beserta permission-nya
+// @Tags Role
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /roles/ [get]
+func (s *RoleService) GetAll(c *fiber.Ctx) error {
+	data, err := s.roleRepo.FindAll(context.Background())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// Create godoc
+// @Summary Tambah role
+// @Description Membuat role baru dengan kumpulan permission
+// @Tags Role
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.RoleRequest true "Data role"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,409,500 {object} map[string]interface{}
+// @Router /roles/ [post]
+func (s *RoleService) Create(c *fiber.Ctx) error {
+	var req model.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(req.Name) {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Nama role harus 2-32 karakter huruf kecil, angka, '_' atau '-'"})
+	}
+	perms, msg := normalizePermissions(req.Permissions)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": msg})
+	}
+
+	role := &model.Role{
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: perms,
+	}
+	if err := s.roleRepo.Create(context.Background(), role); err != nil {
+		if errors.Is(err, repository.ErrRoleExists) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": "Role berhasil ditambahkan", "data": role})
+}
+
+// Update godoc
+// @Summary Update role
+// @Description Mengganti deskripsi & permission role. Role admin tidak bisa diubah.
+// @Tags Role
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param name path string true "Nama role"
+// @Param body body model.RoleRequest true "Data role"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,403,404,500 {object} map[string]interface{}
+// @Router /roles/{name} [put]
+func (s *RoleService) Update(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if name == model.RoleAdmin {
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Role admin tidak bisa diubah"})
+	}
+
+	var req model.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+	perms, msg := normalizePermissions(req.Permissions)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": msg})
+	}
+
+	if err := s.roleRepo.Update(context.Background(), name, strings.TrimSpace(req.Description), perms); err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return c.Status(404).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Role berhasil diperbarui"})
+}
+
+// Delete godoc
+// @Summary Hapus role
+// @Description Menghapus role buatan admin. Role bawaan dan role yang masih dipakai user tidak bisa dihapus.
+// @Tags Role
+// @Security BearerAuth
+// @Produce json
+// @Param name path string true "Nama role"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403,404,409,500 {object} map[string]interface{}
+// @Router /roles/{name} [delete]
+func (s *RoleService) Delete(c *fiber.Ctx) error {
+	name := c.Params("name")
+	ctx := context.Background()
+
+	role, err := s.roleRepo.FindByName(ctx, name)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if role == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": repository.ErrRoleNotFound.Error()})
+	}
+	if role.BuiltIn {
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Role bawaan tidak bisa dihapus"})
+	}
+
+	inUse, err := s.userRepo.CountByRole(ctx, name)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if inUse > 0 {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Role masih dipakai user"})
+	}
+
+	if err := s.roleRepo.Delete(ctx, name); err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return c.Status(404).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Role berhasil dihapus"})
+}
+
+// normalizePermissions memvalidasi & menghapus duplikat permission
+func normalizePermissions(perms []string) ([]string, string) {
+	out := make([]string, 0, len(perms))
+	seen := map[string]bool{}
+	for _, p := range perms {
+		p = strings.TrimSpace(p)
+		if !model.IsValidPermission(p) {
+			return nil, "Permission tidak dikenal: " + p
+		}
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out, ""
+}
+
+// hasPermission memeriksa permission user login (diisi AuthRequired).
+// Tanpa Locals "permissions" (misal di test) dipakai permission bawaan role.
+func hasPermission(c *fiber.Ctx, perm string) bool {
+	if perms, ok := c.Locals("permissions").([]string); ok {
+		return model.HasPermission(perms, perm)
+	}
+
+	role, _ := c.Locals("role").(string)
+	if role == "" {
+		switch u := c.Locals("user").(type) {
+		case *model.User:
+			role = u.Role
+		case map[string]interface{}:
+			role, _ = u["role"].(string)
+		}
+	}
+	return model.HasPermission(model.DefaultPermissions(role), perm)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionService struct {
+	sessions    repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+	revocations repository.TokenRevocationRepository
+	userRepo    repository.IUserRepository
+}
+
+func NewSessionService(
+	sessions repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+	userRepo repository.IUserRepository,
+) *SessionService {
+	return &SessionService{sessions: sessions, refreshRepo: refreshRepo, revocations: revocations, userRepo: userRepo}
+}
+
+// GetMine godoc
+// @Summary Daftar sesi login saya
+// @Description Menampilkan semua sesi (perangkat / browser) yang masih aktif beserta user agent, IP, dan waktu terakhir dipakai. Sesi yang sedang dipakai ditandai current=true.
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401,500 {object} map[string]interface{}
+// @Router /me/sessions [get]
+func (s *SessionService) GetMine(c *fiber.Ctx) error {
+	claims, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	sessions, err := s.sessions.FindActiveByUser(context.Background(), userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID.Hex() == claims.SessionID
+	}
+	return c.JSON(fiber.Map{"success": true, "data": sessions})
+}
+
+// Revoke godoc
+// @Summary Akhiri sesi login
+// @Description Sign out dari satu perangkat: access token dan refresh token sesi tersebut langsung tidak berlaku
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID sesi"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401,404,500 {object} map[string]interface{}
+// @Router /me/sessions/{id} [delete]
+func (s *SessionService) Revoke(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+	sessionID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID sesi tidak valid"})
+	}
+
+	ended, err := endSession(context.Background(), s.sessions, s.refreshRepo, s.revocations, sessionID, userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if !ended {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Sesi tidak ditemukan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Sesi berhasil diakhiri"})
+}
+
+// RevokeAllForUser godoc
+// @Summary Sign out user dari semua perangkat
+// @Description Mengakhiri semua sesi user: semua access token dan refresh token yang sudah terbit langsung ditolak (permission users:manage)
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /users/{id}/sessions [delete]
+func (s *SessionService) RevokeAllForUser(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	ctx := context.Background()
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if user == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+
+	// access token lama ditolak lewat pencabutan massal (iat), refresh token dicabut semua
+	if err := s.revocations.RevokeAllForUser(ctx, userID, time.Now()); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if err := s.refreshRepo.RevokeByUser(ctx, userID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if err := s.sessions.RevokeAllForUser(ctx, userID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "User berhasil di-sign out dari semua perangkat"})
+}
+
+// endSession mengakhiri satu sesi milik user: dokumen sesi, refresh token family-nya,
+// dan semua access token yang membawa sid tersebut. false jika sesi tidak ditemukan.
+func endSession(
+	ctx context.Context,
+	sessions repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+	sessionID, userID primitive.ObjectID,
+) (bool, error) {
+	ended, err := sessions.Revoke(ctx, sessionID, userID)
+	if err != nil || !ended {
+		return false, err
+	}
+	if err := refreshRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return false, err
+	}
+	// access token sesi ini paling lama berlaku AccessTokenTTL sejak rotasi terakhir
+	err = revocations.Revoke(ctx, repository.SessionRevocationKey(sessionID.Hex()), userID, time.Now().Add(utils.AccessTokenTTL))
+	return err == nil, err
+}
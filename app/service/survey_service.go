@@ -0,0 +1,521 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SurveyService mengelola kuesioner tracer study: admin menyusun & menerbitkan versi kuesioner,
+// alumni mengisi versi yang sedang terbit, admin mengekspor jawaban dan melihat ringkasannya.
+type SurveyService struct {
+	surveyRepo repository.SurveyRepository
+	userRepo   repository.IUserRepository
+}
+
+func NewSurveyService(surveyRepo repository.SurveyRepository, userRepo repository.IUserRepository) *SurveyService {
+	return &SurveyService{surveyRepo: surveyRepo, userRepo: userRepo}
+}
+
+// loadSurvey mengambil kuesioner dari parameter :id; nil berarti respon error sudah dikirim
+func (s *SurveyService) loadSurvey(c *fiber.Ctx) (*model.Survey, error) {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(400).JSON(fiber.Map{"success": false, "message": "ID kuesioner tidak valid"})
+	}
+	survey, err := s.surveyRepo.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if survey == nil {
+		return nil, c.Status(404).JSON(fiber.Map{"success": false, "message": "Kuesioner tidak ditemukan"})
+	}
+	return survey, nil
+}
+
+// loadPublished seperti loadSurvey, tapi kuesioner yang belum terbit disembunyikan dari non-admin
+func (s *SurveyService) loadPublished(c *fiber.Ctx) (*model.Survey, error) {
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return nil, err
+	}
+	if survey.Status == model.SurveyStatusDraft && !hasPermission(c, model.PermSurveysManage) {
+		return nil, c.Status(404).JSON(fiber.Map{"success": false, "message": "Kuesioner tidak ditemukan"})
+	}
+	return survey, nil
+}
+
+// loadAlumniID mengambil alumni_id user login dari database (bukan dari token, agar klaim
+// yang baru disetujui langsung berlaku); nil berarti respon error sudah dikirim
+func (s *SurveyService) loadAlumniID(c *fiber.Ctx, userID primitive.ObjectID) (*primitive.ObjectID, error) {
+	user, err := s.userRepo.FindByID(context.Background(), userID)
+	if err != nil {
+		return nil, c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if user == nil {
+		return nil, c.Status(401).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+	if user.AlumniID == nil {
+		return nil, c.Status(403).JSON(fiber.Map{"success": false, "message": "Akun belum terhubung dengan data alumni, ajukan klaim alumni terlebih dulu"})
+	}
+	return user.AlumniID, nil
+}
+
+// ================== ADMIN ==================
+
+// Create godoc
+// @Summary Buat kuesioner
+// @Description Membuat draft kuesioner versi 1 untuk kode baru. Untuk kode yang sudah ada gunakan POST /surveys/{id}/versions.
+// @Tags Survey
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.SurveyRequest true "Definisi kuesioner"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,401,409,422,500 {object} map[string]interface{}
+// @Router /surveys/ [post]
+func (s *SurveyService) Create(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+
+	var req model.SurveyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+	normalizeSurvey(&req)
+	if errs := validateSurvey(req, true); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	survey := &model.Survey{
+		Code:        req.Code,
+		Version:     1,
+		Title:       req.Title,
+		Description: req.Description,
+		Questions:   req.Questions,
+		CreatedBy:   userID,
+	}
+	if err := s.surveyRepo.Create(context.Background(), survey); err != nil {
+		if errors.Is(err, repository.ErrSurveyExists) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": "Kode kuesioner sudah dipakai, buat versi baru dari kuesioner tersebut"})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": "Draft kuesioner berhasil dibuat", "data": survey})
+}
+
+// GetAll godoc
+// @Summary Daftar kuesioner
+// @Description Mengambil semua versi kuesioner, bisa difilter kode dan status
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param code query string false "Kode kuesioner"
+// @Param status query string false "draft / published / closed"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,500 {object} map[string]interface{}
+// @Router /surveys/ [get]
+func (s *SurveyService) GetAll(c *fiber.Ctx) error {
+	status := c.Query("status", "")
+	switch status {
+	case "", model.SurveyStatusDraft, model.SurveyStatusPublished, model.SurveyStatusClosed:
+	default:
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "status tidak valid"})
+	}
+
+	data, err := s.surveyRepo.FindAll(context.Background(), strings.ToLower(strings.TrimSpace(c.Query("code", ""))), status)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// Update godoc
+// @Summary Ubah draft kuesioner
+// @Description Mengubah judul, deskripsi, dan pertanyaan. Hanya draft yang bisa diubah; kode tidak bisa diganti.
+// @Tags Survey
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Param body body model.SurveyRequest true "Definisi kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,409,422,500 {object} map[string]interface{}
+// @Router /surveys/{id} [put]
+func (s *SurveyService) Update(c *fiber.Ctx) error {
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return err
+	}
+
+	var req model.SurveyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+	normalizeSurvey(&req)
+	if errs := validateSurvey(req, false); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	if err := s.surveyRepo.UpdateDraft(context.Background(), survey.ID, req); err != nil {
+		if errors.Is(err, repository.ErrSurveyNotDraft) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": "Kuesioner yang sudah terbit tidak bisa diubah, buat versi baru"})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	survey.Title, survey.Description, survey.Questions = req.Title, req.Description, req.Questions
+	return c.JSON(fiber.Map{"success": true, "message": "Draft kuesioner berhasil diubah", "data": survey})
+}
+
+// NewVersion godoc
+// @Summary Buat versi baru kuesioner
+// @Description Menyalin pertanyaan kuesioner menjadi draft dengan nomor versi berikutnya untuk kode yang sama
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner sumber"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,401,404,409,500 {object} map[string]interface{}
+// @Router /surveys/{id}/versions [post]
+func (s *SurveyService) NewVersion(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+	source, err := s.loadSurvey(c)
+	if source == nil {
+		return err
+	}
+
+	ctx := context.Background()
+	latest, err := s.surveyRepo.LatestVersion(ctx, source.Code)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	survey := &model.Survey{
+		Code:        source.Code,
+		Version:     latest + 1,
+		Title:       source.Title,
+		Description: source.Description,
+		Questions:   source.Questions,
+		CreatedBy:   userID,
+	}
+	if err := s.surveyRepo.Create(ctx, survey); err != nil {
+		if errors.Is(err, repository.ErrSurveyExists) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": "Versi baru sedang dibuat bersamaan, coba lagi"})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": fmt.Sprintf("Draft versi %d berhasil dibuat", survey.Version), "data": survey})
+}
+
+// Publish godoc
+// @Summary Terbitkan kuesioner
+// @Description Menerbitkan draft agar bisa diisi alumni. Versi lain dengan kode yang sama yang sedang terbit otomatis ditutup.
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,409,500 {object} map[string]interface{}
+// @Router /surveys/{id}/publish [post]
+func (s *SurveyService) Publish(c *fiber.Ctx) error {
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return err
+	}
+
+	if err := s.surveyRepo.Publish(context.Background(), survey.ID, survey.Code); err != nil {
+		if errors.Is(err, repository.ErrSurveyNotDraft) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": "Hanya draft yang bisa diterbitkan"})
+		}
+		if errors.Is(err, repository.ErrSurveyPublishConflict) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Kuesioner berhasil diterbitkan"})
+}
+
+// Close godoc
+// @Summary Tutup kuesioner
+// @Description Menutup kuesioner yang sedang terbit sehingga tidak bisa diisi lagi
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,409,500 {object} map[string]interface{}
+// @Router /surveys/{id}/close [post]
+func (s *SurveyService) Close(c *fiber.Ctx) error {
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return err
+	}
+
+	closed, err := s.surveyRepo.Close(context.Background(), survey.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if !closed {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Hanya kuesioner yang sedang terbit yang bisa ditutup"})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Kuesioner berhasil ditutup"})
+}
+
+// ExportResponses godoc
+// @Summary Ekspor jawaban kuesioner
+// @Description Mengambil semua jawaban satu versi kuesioner dalam format JSON atau CSV (satu kolom per pertanyaan, pilihan ganda dipisah "; ")
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json,text/csv
+// @Param id path string true "ID kuesioner"
+// @Param format query string false "Format ekspor" Enums(json, csv) default(json)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /surveys/{id}/responses [get]
+func (s *SurveyService) ExportResponses(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "format harus json atau csv"})
+	}
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return err
+	}
+
+	responses, err := s.surveyRepo.FindResponses(context.Background(), survey.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	if format == "json" {
+		return c.JSON(fiber.Map{"success": true, "survey": survey, "data": responses})
+	}
+
+	body, err := responsesCSV(survey, responses)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="survey-%s-v%d.csv"`, survey.Code, survey.Version))
+	return c.Send(body)
+}
+
+// responsesCSV: satu baris per responden, kolom pertanyaan mengikuti urutan kuesioner
+func responsesCSV(survey *model.Survey, responses []model.SurveyResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"response_id", "alumni_id", "user_id", "submitted_at"}
+	for _, q := range survey.Questions {
+		header = append(header, q.ID)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, r := range responses {
+		answers := map[string]model.SurveyAnswer{}
+		for _, a := range r.Answers {
+			answers[a.QuestionID] = a
+		}
+
+		row := []string{r.ID.Hex(), r.AlumniID.Hex(), r.UserID.Hex(), r.SubmittedAt.Format(time.RFC3339)}
+		for _, q := range survey.Questions {
+			a := answers[q.ID]
+			switch {
+			case len(a.Choices) > 0:
+				row = append(row, strings.Join(a.Choices, "; "))
+			case a.Scale != nil:
+				row = append(row, strconv.Itoa(*a.Scale))
+			default:
+				row = append(row, a.Text)
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// Aggregates godoc
+// @Summary Ringkasan jawaban kuesioner
+// @Description Jumlah responden, jumlah jawaban per pertanyaan, sebaran opsi / nilai skala, dan rata-rata skala
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /surveys/{id}/aggregates [get]
+func (s *SurveyService) Aggregates(c *fiber.Ctx) error {
+	survey, err := s.loadSurvey(c)
+	if survey == nil {
+		return err
+	}
+
+	stats, err := s.surveyRepo.Stats(context.Background(), survey.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"survey_id":       survey.ID,
+			"code":            survey.Code,
+			"version":         survey.Version,
+			"total_responses": stats.TotalResponses,
+			"questions":       buildAggregates(survey, stats),
+		},
+	})
+}
+
+// ================== ALUMNI ==================
+
+// GetActive godoc
+// @Summary Kuesioner yang sedang dibuka
+// @Description Mengambil semua kuesioner berstatus published yang bisa diisi alumni
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /surveys/active [get]
+func (s *SurveyService) GetActive(c *fiber.Ctx) error {
+	data, err := s.surveyRepo.FindAll(context.Background(), "", model.SurveyStatusPublished)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// GetByID godoc
+// @Summary Detail kuesioner
+// @Description Mengambil kuesioner beserta pertanyaannya. Draft hanya terlihat oleh pengelola kuesioner.
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /surveys/{id} [get]
+func (s *SurveyService) GetByID(c *fiber.Ctx) error {
+	survey, err := s.loadPublished(c)
+	if survey == nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "data": survey})
+}
+
+// Submit godoc
+// @Summary Isi kuesioner
+// @Description Alumni mengirim jawaban untuk kuesioner yang sedang terbit. Akun harus sudah terhubung dengan data alumni; tiap alumni hanya sekali per versi.
+// @Tags Survey
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Param body body model.SubmitSurveyRequest true "Jawaban"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,401,403,404,409,422,500 {object} map[string]interface{}
+// @Router /surveys/{id}/responses [post]
+func (s *SurveyService) Submit(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+	survey, err := s.loadPublished(c)
+	if survey == nil {
+		return err
+	}
+	if survey.Status != model.SurveyStatusPublished {
+		return c.Status(409).JSON(fiber.Map{"success": false, "message": "Kuesioner tidak sedang dibuka"})
+	}
+
+	var req model.SubmitSurveyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Body request tidak valid"})
+	}
+
+	alumniID, err := s.loadAlumniID(c, userID)
+	if alumniID == nil {
+		return err
+	}
+
+	answers, errs := validateAnswers(survey, req.Answers)
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	resp := &model.SurveyResponse{
+		SurveyID:      survey.ID,
+		SurveyVersion: survey.Version,
+		AlumniID:      *alumniID,
+		UserID:        userID,
+		Answers:       answers,
+	}
+	if err := s.surveyRepo.CreateResponse(context.Background(), resp); err != nil {
+		if errors.Is(err, repository.ErrAlreadyResponded) {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": "Terima kasih, jawaban berhasil disimpan", "data": resp})
+}
+
+// GetMyResponse godoc
+// @Summary Jawaban saya
+// @Description Mengambil jawaban alumni login untuk kuesioner tertentu
+// @Tags Survey
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID kuesioner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401,403,404,500 {object} map[string]interface{}
+// @Router /surveys/{id}/responses/me [get]
+func (s *SurveyService) GetMyResponse(c *fiber.Ctx) error {
+	_, userID, ok := currentClaims(c)
+	if !ok {
+		return c.Status(401).JSON(fiber.Map{"success": false, "message": "Token tidak valid"})
+	}
+	survey, err := s.loadPublished(c)
+	if survey == nil {
+		return err
+	}
+	alumniID, err := s.loadAlumniID(c, userID)
+	if alumniID == nil {
+		return err
+	}
+
+	resp, err := s.surveyRepo.FindResponseByAlumni(context.Background(), survey.ID, *alumniID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if resp == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Belum mengisi kuesioner ini"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": resp})
+}
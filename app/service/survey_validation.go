@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"praktikum3/app/model"
+	"praktikum3/app/utils"
+)
+
+// Batas isi kuesioner
+const (
+	maxSurveyTitle     = 200
+	maxSurveyQuestions = 100
+	maxScaleSteps      = 10
+	maxTextAnswer      = 2000
+)
+
+var (
+	surveyCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,49}$`)
+	questionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,30}$`)
+)
+
+// normalizeSurvey merapikan spasi pada isi request sebelum divalidasi
+func normalizeSurvey(req *model.SurveyRequest) {
+	req.Code = strings.ToLower(strings.TrimSpace(req.Code))
+	req.Title = strings.TrimSpace(req.Title)
+	req.Description = strings.TrimSpace(req.Description)
+	for i := range req.Questions {
+		q := &req.Questions[i]
+		q.ID = strings.TrimSpace(q.ID)
+		q.Text = strings.TrimSpace(q.Text)
+		for j := range q.Options {
+			q.Options[j] = strings.TrimSpace(q.Options[j])
+		}
+	}
+}
+
+// validateSurvey memeriksa definisi kuesioner. Code hanya dicek saat membuat kuesioner baru.
+func validateSurvey(req model.SurveyRequest, withCode bool) utils.FieldErrors {
+	errs := utils.FieldErrors{}
+
+	if withCode && !surveyCodePattern.MatchString(req.Code) {
+		errs.Add("code", "Kode wajib diisi: 3-50 karakter huruf kecil, angka, atau tanda hubung")
+	}
+	if req.Title == "" {
+		errs.Add("title", "Judul wajib diisi")
+	} else if utf8.RuneCountInString(req.Title) > maxSurveyTitle {
+		errs.Add("title", fmt.Sprintf("Judul maksimal %d karakter", maxSurveyTitle))
+	}
+	if len(req.Questions) == 0 {
+		errs.Add("questions", "Minimal satu pertanyaan")
+	} else if len(req.Questions) > maxSurveyQuestions {
+		errs.Add("questions", fmt.Sprintf("Maksimal %d pertanyaan", maxSurveyQuestions))
+	}
+
+	seen := map[string]bool{}
+	for i, q := range req.Questions {
+		key := fmt.Sprintf("questions[%d]", i)
+		switch {
+		case !questionIDPattern.MatchString(q.ID):
+			errs.Add(key+".id", "ID pertanyaan wajib diisi: huruf, angka, _ atau -, maksimal 30 karakter")
+		case seen[q.ID]:
+			errs.Add(key+".id", "ID pertanyaan duplikat")
+		}
+		seen[q.ID] = true
+
+		if q.Text == "" {
+			errs.Add(key+".text", "Teks pertanyaan wajib diisi")
+		}
+
+		switch q.Type {
+		case model.QuestionSingleChoice, model.QuestionMultiChoice:
+			if len(q.Options) < 2 {
+				errs.Add(key+".options", "Pertanyaan pilihan minimal punya 2 opsi")
+			}
+			opts := map[string]bool{}
+			for _, o := range q.Options {
+				if o == "" || opts[o] {
+					errs.Add(key+".options", "Opsi tidak boleh kosong atau duplikat")
+				}
+				opts[o] = true
+			}
+		case model.QuestionScale:
+			if q.ScaleMin >= q.ScaleMax || q.ScaleMax-q.ScaleMin > maxScaleSteps {
+				errs.Add(key+".scale_max", fmt.Sprintf("scale_max harus lebih besar dari scale_min, maksimal %d langkah", maxScaleSteps))
+			}
+		case model.QuestionText:
+		default:
+			errs.Add(key+".type", "Tipe harus salah satu dari: "+strings.Join(model.QuestionTypes, ", "))
+		}
+		if q.Type != model.QuestionSingleChoice && q.Type != model.QuestionMultiChoice && len(q.Options) > 0 {
+			errs.Add(key+".options", "Opsi hanya untuk pertanyaan pilihan")
+		}
+	}
+	return errs
+}
+
+// validateAnswers mencocokkan jawaban dengan definisi kuesioner dan mengembalikan jawaban yang sudah dirapikan
+// (urut sesuai pertanyaan, jawaban kosong untuk pertanyaan opsional dibuang). Error dikunci "answers.<question_id>".
+func validateAnswers(s *model.Survey, answers []model.SurveyAnswer) ([]model.SurveyAnswer, utils.FieldErrors) {
+	errs := utils.FieldErrors{}
+	given := map[string]model.SurveyAnswer{}
+
+	for _, a := range answers {
+		id := strings.TrimSpace(a.QuestionID)
+		if s.Question(id) == nil {
+			errs.Add("answers."+id, "Pertanyaan tidak dikenal")
+			continue
+		}
+		if _, dup := given[id]; dup {
+			errs.Add("answers."+id, "Pertanyaan dijawab lebih dari sekali")
+			continue
+		}
+		a.QuestionID = id
+		given[id] = a
+	}
+
+	cleaned := []model.SurveyAnswer{}
+	for _, q := range s.Questions {
+		key := "answers." + q.ID
+		a, ok := given[q.ID]
+		if ok {
+			a.Text = strings.TrimSpace(a.Text)
+		}
+		if !ok || answerEmpty(a) {
+			if q.Required {
+				errs.Add(key, "Pertanyaan wajib dijawab")
+			}
+			continue
+		}
+
+		if msg := checkAnswer(q, &a); msg != "" {
+			errs.Add(key, msg)
+			continue
+		}
+		cleaned = append(cleaned, a)
+	}
+	return cleaned, errs
+}
+
+func answerEmpty(a model.SurveyAnswer) bool {
+	return len(a.Choices) == 0 && a.Scale == nil && a.Text == ""
+}
+
+// checkAnswer memvalidasi satu jawaban sesuai tipe pertanyaan dan membuang field yang tidak relevan
+func checkAnswer(q model.SurveyQuestion, a *model.SurveyAnswer) string {
+	switch q.Type {
+	case model.QuestionSingleChoice, model.QuestionMultiChoice:
+		if q.Type == model.QuestionSingleChoice && len(a.Choices) != 1 {
+			return "Pilih tepat satu opsi"
+		}
+		picked := map[string]bool{}
+		for _, ch := range a.Choices {
+			if !contains(q.Options, ch) {
+				return "Opsi tidak dikenal: " + ch
+			}
+			if picked[ch] {
+				return "Opsi dipilih lebih dari sekali"
+			}
+			picked[ch] = true
+		}
+		a.Scale, a.Text = nil, ""
+	case model.QuestionScale:
+		if a.Scale == nil || *a.Scale < q.ScaleMin || *a.Scale > q.ScaleMax {
+			return fmt.Sprintf("Nilai skala harus antara %d dan %d", q.ScaleMin, q.ScaleMax)
+		}
+		a.Choices, a.Text = nil, ""
+	case model.QuestionText:
+		if a.Text == "" {
+			return "Jawaban teks wajib diisi"
+		}
+		if utf8.RuneCountInString(a.Text) > maxTextAnswer {
+			return fmt.Sprintf("Jawaban maksimal %d karakter", maxTextAnswer)
+		}
+		a.Choices, a.Scale = nil, nil
+	}
+	return ""
+}
+
+// buildAggregates menyusun ringkasan per pertanyaan dari hitungan database, urut sesuai kuesioner.
+// Opsi yang belum pernah dipilih tetap ditampilkan dengan jumlah 0.
+func buildAggregates(s *model.Survey, stats model.SurveyStats) []model.QuestionAggregate {
+	list := make([]model.QuestionAggregate, 0, len(s.Questions))
+	for _, q := range s.Questions {
+		agg := model.QuestionAggregate{QuestionID: q.ID, Text: q.Text, Type: q.Type, Answered: stats.Answered[q.ID]}
+
+		switch q.Type {
+		case model.QuestionSingleChoice, model.QuestionMultiChoice:
+			for _, o := range q.Options {
+				n := stats.Choices[q.ID][o]
+				agg.Options = append(agg.Options, model.OptionCount{Value: o, Count: n, Persentase: ratio(n, agg.Answered)})
+			}
+		case model.QuestionScale:
+			sum, count := 0, 0
+			for v := q.ScaleMin; v <= q.ScaleMax; v++ {
+				n := stats.Scales[q.ID][v]
+				sum += v * n
+				count += n
+				agg.Options = append(agg.Options, model.OptionCount{Value: strconv.Itoa(v), Count: n, Persentase: ratio(n, agg.Answered)})
+			}
+			if count > 0 {
+				avg := math.Round(float64(sum)/float64(count)*100) / 100
+				agg.Average = &avg
+			}
+		}
+		list = append(list, agg)
+	}
+	return list
+}
+
+// ratio persentase n dari total, dua angka di belakang koma
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*10000) / 100
+}
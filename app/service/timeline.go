@@ -0,0 +1,176 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// jobPeriod menurunkan rentang kerja sebuah pekerjaan.
+// Tanpa tanggal selesai, pekerjaan berstatus aktif dianggap berjalan sampai sekarang,
+// sedangkan status lain dianggap selesai di tanggal mulai (durasi tidak diketahui).
+type jobPeriod struct {
+	start, end   time.Time
+	berjalan     bool
+	unknownEnd   bool
+	hasStartDate bool
+}
+
+func periodOf(p model.Pekerjaan, now time.Time) jobPeriod {
+	if p.TanggalMulaiKerja == nil {
+		return jobPeriod{}
+	}
+	jp := jobPeriod{start: *p.TanggalMulaiKerja, hasStartDate: true}
+	started := !jp.start.After(now)
+
+	switch {
+	case p.TanggalSelesaiKerja != nil:
+		jp.end = *p.TanggalSelesaiKerja
+		jp.berjalan = started && jp.end.After(now)
+	case p.StatusPekerjaan == model.StatusPekerjaanAktif:
+		jp.end = now
+		jp.berjalan = started
+	default:
+		jp.end = jp.start
+		jp.unknownEnd = true
+	}
+	return jp
+}
+
+// clamped rentang yang sudah terjadi (dipotong di waktu sekarang)
+func (jp jobPeriod) clamped(now time.Time) (time.Time, time.Time) {
+	return minTime(jp.start, now), minTime(jp.end, now)
+}
+
+// currentJob pekerjaan yang sedang berjalan; kalau lebih dari satu, yang paling baru dimulai
+func currentJob(jobs []model.Pekerjaan, now time.Time) *model.Pekerjaan {
+	var current *model.Pekerjaan
+	var currentStart time.Time
+	for i := range jobs {
+		jp := periodOf(jobs[i], now)
+		if !jp.berjalan {
+			continue
+		}
+		if current == nil || jp.start.After(currentStart) {
+			current, currentStart = &jobs[i], jp.start
+		}
+	}
+	return current
+}
+
+// buildTimeline menyusun riwayat karier kronologis beserta jeda, tumpang tindih, dan total bulan bekerja.
+// Total bulan dihitung dari gabungan rentang, jadi pekerjaan yang tumpang tindih tidak dihitung dua kali.
+func buildTimeline(alumniID primitive.ObjectID, jobs []model.Pekerjaan, now time.Time) model.AlumniTimeline {
+	t := model.AlumniTimeline{
+		AlumniID:      alumniID,
+		Riwayat:       []model.TimelineEntry{},
+		TanpaTanggal:  []model.Pekerjaan{},
+		Jeda:          []model.TimelinePeriod{},
+		TumpangTindih: []model.TimelineOverlap{},
+		CurrentJob:    currentJob(jobs, now),
+	}
+
+	var periods []jobPeriod
+	for _, p := range jobs {
+		jp := periodOf(p, now)
+		if !jp.hasStartDate {
+			t.TanpaTanggal = append(t.TanpaTanggal, p)
+			continue
+		}
+		start, end := jp.clamped(now)
+		t.Riwayat = append(t.Riwayat, model.TimelineEntry{
+			Pekerjaan:             p,
+			Berjalan:              jp.berjalan,
+			SelesaiTidakDiketahui: jp.unknownEnd,
+			DurasiBulan:           monthsBetween(start, end),
+		})
+		periods = append(periods, jp)
+	}
+
+	// urut kronologis: tanggal mulai, lalu yang lebih dulu selesai
+	idx := make([]int, len(periods))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		pa, pb := periods[idx[a]], periods[idx[b]]
+		if !pa.start.Equal(pb.start) {
+			return pa.start.Before(pb.start)
+		}
+		return pa.end.Before(pb.end)
+	})
+	riwayat := make([]model.TimelineEntry, len(idx))
+	sorted := make([]jobPeriod, len(idx))
+	for i, j := range idx {
+		riwayat[i], sorted[i] = t.Riwayat[j], periods[j]
+	}
+	t.Riwayat = riwayat
+
+	// tumpang tindih antar pasangan pekerjaan
+	for i := range sorted {
+		si, ei := sorted[i].clamped(now)
+		for j := i + 1; j < len(sorted); j++ {
+			sj, ej := sorted[j].clamped(now)
+			from, to := maxTime(si, sj), minTime(ei, ej)
+			if to.After(from) {
+				t.TumpangTindih = append(t.TumpangTindih, model.TimelineOverlap{
+					TimelinePeriod: model.TimelinePeriod{Dari: from, Sampai: to, Bulan: monthsBetween(from, to)},
+					PekerjaanIDs:   [2]primitive.ObjectID{riwayat[i].ID, riwayat[j].ID},
+				})
+			}
+		}
+	}
+
+	// gabungkan rentang untuk total bulan bekerja dan jeda di antaranya
+	var mergedStart, mergedEnd time.Time
+	merging := false
+	for _, jp := range sorted {
+		start, end := jp.clamped(now)
+		if !end.After(start) {
+			continue
+		}
+		switch {
+		case !merging:
+			mergedStart, mergedEnd, merging = start, end, true
+		case !start.After(mergedEnd):
+			mergedEnd = maxTime(mergedEnd, end)
+		default:
+			t.TotalBulanBekerja += monthsBetween(mergedStart, mergedEnd)
+			t.Jeda = append(t.Jeda, model.TimelinePeriod{Dari: mergedEnd, Sampai: start, Bulan: monthsBetween(mergedEnd, start)})
+			mergedStart, mergedEnd = start, end
+		}
+	}
+	if merging {
+		t.TotalBulanBekerja += monthsBetween(mergedStart, mergedEnd)
+	}
+	return t
+}
+
+// monthsBetween jumlah bulan penuh dari a sampai b
+func monthsBetween(a, b time.Time) int {
+	months := (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+	if b.Day() < a.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -3,26 +3,53 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
 	"praktikum3/app/repository"
+	"praktikum3/app/utils"
 
+	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrUserDeleteForbidden dikembalikan SoftDeleteUser saat pemanggil tidak punya permission users:manage
+var ErrUserDeleteForbidden = errors.New("forbidden: tidak punya akses menghapus user")
+
 // ✅ Struct service untuk mengelola operasi user
 type UserService struct {
-	userRepo repository.IUserRepository
+	userRepo    repository.IUserRepository
+	roleRepo    repository.RoleRepository
+	refreshRepo repository.RefreshTokenRepository
+	revocations repository.TokenRevocationRepository
+	sessions    repository.SessionRepository
 }
 
 // ✅ Constructor: menerima dependency dari repository layer
-func NewUserService(repo repository.IUserRepository) *UserService {
-	return &UserService{userRepo: repo}
+func NewUserService(
+	repo repository.IUserRepository,
+	roleRepo repository.RoleRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+	sessions repository.SessionRepository,
+) *UserService {
+	return &UserService{
+		userRepo:    repo,
+		roleRepo:    roleRepo,
+		refreshRepo: refreshRepo,
+		revocations: revocations,
+		sessions:    sessions,
+	}
 }
 
-// ✅ SoftDeleteUser — hanya boleh dijalankan oleh admin
-func (s *UserService) SoftDeleteUser(ctx context.Context, id string, role string) error {
-	// Cek role user (hanya admin)
-	if role != "admin" {
-		return errors.New("forbidden: hanya admin yang dapat menghapus user")
+// ✅ SoftDeleteUser — hanya boleh dijalankan pemilik permission users:manage
+func (s *UserService) SoftDeleteUser(ctx context.Context, id string, perms []string) error {
+	// Cek permission
+	if !model.HasPermission(perms, model.PermUsersManage) {
+		return ErrUserDeleteForbidden
 	}
 
 	// Konversi string ke ObjectID MongoDB
@@ -34,3 +61,348 @@ func (s *UserService) SoftDeleteUser(ctx context.Context, id string, role string
 	// Jalankan operasi soft delete di repository
 	return s.userRepo.SoftDeleteUser(ctx, objectID)
 }
+
+// GetAll godoc
+// @Summary Get semua user
+// @Description Mengambil daftar user aktif dengan paginasi dan pencarian username/email (khusus admin)
+// @Tags User
+// @Security BearerAuth
+// @Produce json
+// @Param search query string false "Cari username / email"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman" default(10)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/ [get]
+func (s *UserService) GetAll(c *fiber.Ctx) error {
+	search := strings.TrimSpace(c.Query("search", ""))
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	ctx := context.Background()
+	data, err := s.userRepo.FindAll(ctx, search, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	total, err := s.userRepo.Count(ctx, search)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"meta": model.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: "created_at",
+			Order:  "DESC",
+			Search: search,
+		},
+	})
+}
+
+// GetByID godoc
+// @Summary Get user by ID
+// @Description Mendapatkan detail user aktif berdasarkan ID (khusus admin)
+// @Tags User
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID User"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /users/{id} [get]
+func (s *UserService) GetByID(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	user, err := s.userRepo.FindByID(context.Background(), id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if user == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": user})
+}
+
+// Create godoc
+// @Summary Tambah user
+// @Description Admin membuat user baru (langsung aktif, tanpa verifikasi email)
+// @Tags User
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user body model.CreateUserRequest true "Data user"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400,409,500 {object} map[string]interface{}
+// @Router /users/ [post]
+func (s *UserService) Create(c *fiber.Ctx) error {
+	var req model.CreateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if req.Role == "" {
+		req.Role = model.RoleUser
+	}
+
+	if len(req.Username) < 3 {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Username minimal 3 karakter"})
+	}
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Format email tidak valid"})
+	}
+	if len(req.Password) < 8 {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "Password minimal 8 karakter"})
+	}
+	if status, msg := s.checkRole(req.Role); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal memproses password"})
+	}
+
+	user := &model.User{
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: hash,
+		Role:         req.Role,
+		Status:       model.UserStatusActive,
+	}
+	if err := s.userRepo.Create(context.Background(), user); err != nil {
+		if err == repository.ErrUserExists {
+			return c.Status(409).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	return c.Status(201).JSON(fiber.Map{"success": true, "message": "User berhasil ditambahkan", "data": user})
+}
+
+// UpdateRole godoc
+// @Summary Ganti role user
+// @Description Admin mengganti role user. Admin tidak bisa mengganti role dirinya sendiri. Token & sesi user dicabut agar role baru langsung berlaku (user login ulang).
+// @Tags User
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID User"
+// @Param body body model.UpdateRoleRequest true "Role baru"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,403,404,500 {object} map[string]interface{}
+// @Router /users/{id}/role [put]
+func (s *UserService) UpdateRole(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	var req model.UpdateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if status, msg := s.checkRole(req.Role); status != 0 {
+		return c.Status(status).JSON(fiber.Map{"success": false, "message": msg})
+	}
+	if isSelf(c, id) {
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Tidak bisa mengganti role akun sendiri"})
+	}
+
+	ctx := context.Background()
+	existing, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, id, req.Role); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	// permission dibaca dari role di JWT, jadi token lama harus dicabut agar role baru langsung berlaku
+	if existing.Role != req.Role {
+		if err := s.revokeAccess(ctx, id, "ganti role"); err != nil {
+			return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mencabut sesi: " + err.Error()})
+		}
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "Role user berhasil diperbarui"})
+}
+
+// SoftDelete godoc
+// @Summary Soft delete user
+// @Description Memindahkan user ke trash. User yang dihapus tidak bisa login; token yang sudah terbit dicabut dan semua sesinya diakhiri.
+// @Tags User
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID User"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,403,404,500 {object} map[string]interface{}
+// @Router /users/{id} [delete]
+func (s *UserService) SoftDelete(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+	if isSelf(c, id) {
+		return c.Status(403).JSON(fiber.Map{"success": false, "message": "Tidak bisa menghapus akun sendiri"})
+	}
+
+	ctx := context.Background()
+	existing, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "User tidak ditemukan"})
+	}
+
+	perms, ok := c.Locals("permissions").([]string)
+	if !ok {
+		role, _ := c.Locals("role").(string)
+		perms = model.DefaultPermissions(role)
+	}
+	if err := s.SoftDeleteUser(ctx, id.Hex(), perms); err != nil {
+		if errors.Is(err, ErrUserDeleteForbidden) {
+			return c.Status(403).JSON(fiber.Map{"success": false, "message": err.Error()})
+		}
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	if err := s.revokeAccess(ctx, id, "hapus user"); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": "Gagal mencabut sesi: " + err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "User berhasil dihapus (soft delete)"})
+}
+
+// revokeAccess membuat token yang sudah terbit tidak berlaku: refresh token, access token, dan sesi aktif.
+// Gagal mengakhiri sesi hanya dicatat karena kedua token sudah dicabut.
+func (s *UserService) revokeAccess(ctx context.Context, id primitive.ObjectID, action string) error {
+	if err := s.refreshRepo.RevokeByUser(ctx, id); err != nil {
+		return err
+	}
+	if err := s.revocations.RevokeAllForUser(ctx, id, time.Now()); err != nil {
+		return err
+	}
+	if err := s.sessions.RevokeAllForUser(ctx, id); err != nil {
+		log.Printf("%s: gagal mengakhiri sesi user %s: %v", action, id.Hex(), err)
+	}
+	return nil
+}
+
+// GetTrashed godoc
+// @Summary Get user yang dihapus (trash)
+// @Description Mengambil user yang dalam status soft delete
+// @Tags User
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /users/trash [get]
+func (s *UserService) GetTrashed(c *fiber.Ctx) error {
+	data, err := s.userRepo.GetTrashed(context.Background())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// Restore godoc
+// @Summary Restore user dari trash
+// @Description Mengembalikan user yang soft delete
+// @Tags User
+// @Security BearerAuth
+// @Param id path string true "ID User"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /users/restore/{id} [put]
+func (s *UserService) Restore(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	ctx := context.Background()
+	existing, err := s.userRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan di trash"})
+	}
+
+	if err := s.userRepo.Restore(ctx, id); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "User berhasil direstore"})
+}
+
+// HardDelete godoc
+// @Summary Hard delete user
+// @Description Menghapus user secara permanen (harus sudah di trash)
+// @Tags User
+// @Security BearerAuth
+// @Param id path string true "ID User"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,404,500 {object} map[string]interface{}
+// @Router /users/hard/{id} [delete]
+func (s *UserService) HardDelete(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"success": false, "message": "ID tidak valid"})
+	}
+
+	ctx := context.Background()
+	existing, err := s.userRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Data tidak ditemukan di trash"})
+	}
+
+	if err := s.userRepo.HardDelete(ctx, id); err != nil {
+		return c.Status(500).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": "User dihapus permanen"})
+}
+
+// checkRole memastikan role ada di koleksi roles.
+// Mengembalikan status HTTP != 0 beserta pesan jika tidak valid.
+func (s *UserService) checkRole(role string) (int, string) {
+	r, err := s.roleRepo.FindByName(context.Background(), role)
+	if err != nil {
+		return 500, err.Error()
+	}
+	if r == nil {
+		return 400, "Role tidak dikenal"
+	}
+	return 0, ""
+}
+
+// isSelf true jika id sama dengan user yang sedang login
+func isSelf(c *fiber.Ctx, id primitive.ObjectID) bool {
+	claimsMap, ok := c.Locals("user").(map[string]interface{})
+	if !ok {
+		return false
+	}
+	userID, _ := claimsMap["id"].(string)
+	return userID == id.Hex()
+}
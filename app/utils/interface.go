@@ -7,7 +7,7 @@ type PasswordChecker interface {
 }
 
 type TokenGenerator interface {
-    Generate(user model.User) (string, error)
+    Generate(user model.User, sessionID string) (string, error)
 }
 
 // implementasi asli
@@ -19,6 +19,6 @@ func (RealPasswordChecker) Check(hash, password string) bool {
 
 type RealTokenGenerator struct{}
 
-func (RealTokenGenerator) Generate(user model.User) (string, error) {
-    return GenerateToken(user)
+func (RealTokenGenerator) Generate(user model.User, sessionID string) (string, error) {
+    return GenerateSessionToken(user, sessionID)
 }
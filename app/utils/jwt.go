@@ -1,50 +1,138 @@
 package utils
 
 import (
-	"os"
 	"praktikum3/app/model"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Ambil secret key dari environment (.env)
-var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+// AccessTokenTTL adalah masa berlaku access token
+const AccessTokenTTL = 24 * time.Hour
+
+// ChallengeTokenTTL adalah masa berlaku token challenge 2FA setelah password benar
+const ChallengeTokenTTL = 5 * time.Minute
 
 // GenerateToken membuat JWT token untuk user MongoDB
 func GenerateToken(user model.User) (string, error) {
+	return GenerateSessionToken(user, "")
+}
+
+// GenerateSessionToken membuat JWT token yang terikat ke satu sesi login (klaim "sid")
+func GenerateSessionToken(user model.User, sessionID string) (string, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return "", err
+	}
+
 	userID := ""
 	if !user.ID.IsZero() {
 		userID = user.ID.Hex()
 	}
 
+	alumniID := ""
+	if user.AlumniID != nil {
+		alumniID = user.AlumniID.Hex()
+	}
+
+	claims := &model.JWTClaims{
+		UserID:    userID,
+		Username:  user.Username,
+		Role:      user.Role,
+		AlumniID:  alumniID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)), // Expired dalam 1 hari
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.New().String(), // jti, dipakai untuk logout / revocation
+		},
+	}
+
+	token := jwt.NewWithClaims(ks.signer.method, claims)
+	token.Header["kid"] = ks.signer.kid
+	return token.SignedString(ks.signer.signKey)
+}
+
+// GenerateChallengeToken membuat token berumur pendek untuk langkah kedua login (2FA)
+func GenerateChallengeToken(user model.User, purpose string) (string, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return "", err
+	}
+
 	claims := &model.JWTClaims{
-		UserID:   userID,
+		UserID:   user.ID.Hex(),
 		Username: user.Username,
-		Role:     user.Role,
+		Purpose:  purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // Expired dalam 1 hari
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ChallengeTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.New().String(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	token := jwt.NewWithClaims(ks.signer.method, claims)
+	token.Header["kid"] = ks.signer.kid
+	return token.SignedString(ks.signer.signKey)
 }
 
-// ValidateToken memverifikasi JWT dan mengembalikan klaim
+// ValidateToken memverifikasi JWT access token dan mengembalikan klaim.
+// Token challenge 2FA ditolak di sini.
 func ValidateToken(tokenString string) (*model.JWTClaims, error) {
+	claims, err := parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// ValidateChallengeToken memverifikasi token challenge 2FA dengan purpose tertentu
+func ValidateChallengeToken(tokenString string, purposes ...string) (*model.JWTClaims, error) {
+	claims, err := parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range purposes {
+		if claims.Purpose == p {
+			return claims, nil
+		}
+	}
+	return nil, jwt.ErrTokenInvalidClaims
+}
+
+func parseToken(tokenString string) (*model.JWTClaims, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return nil, err
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
+		// token lama (sebelum ada kid) diverifikasi dengan key aktif
+		k := ks.signer
+		if kid, ok := token.Header["kid"].(string); ok {
+			if k, ok = ks.verifiers[kid]; !ok {
+				return nil, jwt.ErrTokenUnverifiable
+			}
+		}
+		// algoritma harus sesuai key, bukan sekadar percaya header "alg"
+		if token.Method.Alg() != k.method.Alg() {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return k.pubKey, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	if claims, ok := token.Claims.(*model.JWTClaims); ok && token.Valid {
-		// Validasi tambahan: pastikan UserID valid
-		if claims.UserID == "" || primitive.IsValidObjectID(claims.UserID) {
+		// Validasi tambahan: pastikan UserID & AlumniID valid
+		if (claims.UserID == "" || primitive.IsValidObjectID(claims.UserID)) &&
+			(claims.AlumniID == "" || primitive.IsValidObjectID(claims.AlumniID)) {
 			return claims, nil
 		}
 	}
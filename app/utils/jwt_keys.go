@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Konfigurasi key JWT (dibaca dari environment saat LoadKeys / pemakaian pertama):
+//
+//	JWT_ALG               HS256 (default) | RS256 | EdDSA
+//	JWT_SECRET            secret HS256
+//	JWT_KID               kid untuk key HS256 (default "default")
+//	JWT_PRIVATE_KEY_FILE  PEM private key untuk RS256 / EdDSA (PKCS#1 / PKCS#8)
+//	JWT_VERIFY_KEY_FILES  daftar PEM (dipisah koma) key lama yang masih diterima saat rotasi
+//
+// kid key asimetris = JWK thumbprint (RFC 7638), sehingga sama di semua instance.
+
+// JWK public key dalam format JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// jwtKey satu key beserta algoritmanya
+type jwtKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	signKey interface{} // nil untuk key yang hanya dipakai verifikasi
+	pubKey  interface{} // []byte untuk HS256
+}
+
+// keySet key aktif untuk sign + semua key yang diterima untuk verifikasi
+type keySet struct {
+	signer    *jwtKey
+	verifiers map[string]*jwtKey
+}
+
+var (
+	keysMu sync.RWMutex
+	keys   *keySet
+)
+
+// LoadKeys (re)load key JWT dari environment. Dipanggil saat server start agar
+// konfigurasi yang salah langsung ketahuan; tanpa itu key di-load saat pertama dipakai.
+func LoadKeys() error {
+	ks, err := loadKeySet()
+	if err != nil {
+		return err
+	}
+	keysMu.Lock()
+	keys = ks
+	keysMu.Unlock()
+	return nil
+}
+
+func currentKeys() (*keySet, error) {
+	keysMu.RLock()
+	ks := keys
+	keysMu.RUnlock()
+	if ks != nil {
+		return ks, nil
+	}
+
+	if err := LoadKeys(); err != nil {
+		return nil, err
+	}
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return keys, nil
+}
+
+// PublicJWKS public key yang boleh dipublikasikan (key HS256 tidak pernah ikut)
+func PublicJWKS() ([]JWK, error) {
+	ks, err := currentKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []JWK{}
+	// key aktif dulu, lalu key lama
+	if jwk, ok := toJWK(ks.signer); ok {
+		list = append(list, jwk)
+	}
+	for kid, k := range ks.verifiers {
+		if kid == ks.signer.kid {
+			continue
+		}
+		if jwk, ok := toJWK(k); ok {
+			list = append(list, jwk)
+		}
+	}
+	return list, nil
+}
+
+func loadKeySet() (*keySet, error) {
+	alg := strings.ToUpper(strings.TrimSpace(os.Getenv("JWT_ALG")))
+	if alg == "" {
+		alg = "HS256"
+	}
+
+	ks := &keySet{verifiers: map[string]*jwtKey{}}
+	switch alg {
+	case "HS256":
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET wajib diisi untuk JWT_ALG=HS256")
+		}
+		kid := os.Getenv("JWT_KID")
+		if kid == "" {
+			kid = "default"
+		}
+		ks.signer = &jwtKey{kid: kid, method: jwt.SigningMethodHS256, signKey: []byte(secret), pubKey: []byte(secret)}
+
+	case "RS256", "EDDSA":
+		path := os.Getenv("JWT_PRIVATE_KEY_FILE")
+		if path == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE wajib diisi untuk JWT_ALG=%s", alg)
+		}
+		k, err := loadPEMKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if k.signKey == nil {
+			return nil, fmt.Errorf("%s bukan private key", path)
+		}
+		if (alg == "RS256") != (k.method == jwt.SigningMethodRS256) {
+			return nil, fmt.Errorf("%s tidak cocok dengan JWT_ALG=%s", path, alg)
+		}
+		ks.signer = k
+
+	default:
+		return nil, fmt.Errorf("JWT_ALG tidak didukung: %s", alg)
+	}
+	ks.verifiers[ks.signer.kid] = ks.signer
+
+	// key lama yang masih diterima (rotasi)
+	for _, path := range strings.Split(os.Getenv("JWT_VERIFY_KEY_FILES"), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		k, err := loadPEMKey(path)
+		if err != nil {
+			return nil, err
+		}
+		k.signKey = nil // hanya untuk verifikasi
+		if _, exists := ks.verifiers[k.kid]; !exists {
+			ks.verifiers[k.kid] = k
+		}
+	}
+	return ks, nil
+}
+
+// loadPEMKey membaca private key (PKCS#1/PKCS#8) atau public key (PKIX) RSA / Ed25519
+func loadPEMKey(path string) (*jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca key %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s bukan file PEM", path)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: tipe PEM tidak didukung (%s)", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal parsing key %s: %w", path, err)
+	}
+
+	k := &jwtKey{}
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		k.method, k.signKey, k.pubKey = jwt.SigningMethodRS256, key, &key.PublicKey
+	case *rsa.PublicKey:
+		k.method, k.pubKey = jwt.SigningMethodRS256, key
+	case ed25519.PrivateKey:
+		k.method, k.signKey, k.pubKey = jwt.SigningMethodEdDSA, key, key.Public()
+	case ed25519.PublicKey:
+		k.method, k.pubKey = jwt.SigningMethodEdDSA, key
+	default:
+		return nil, fmt.Errorf("%s: hanya key RSA dan Ed25519 yang didukung", path)
+	}
+
+	if k.method == jwt.SigningMethodRS256 && k.pubKey.(*rsa.PublicKey).N.BitLen() < 2048 {
+		return nil, fmt.Errorf("%s: key RSA minimal 2048 bit", path)
+	}
+
+	jwk, _ := toJWK(k)
+	k.kid = thumbprint(jwk)
+	return k, nil
+}
+
+// toJWK mengubah public key ke JWK (false untuk key HS256)
+func toJWK(k *jwtKey) (JWK, bool) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := k.pubKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA", Kid: k.kid, Use: "sig", Alg: k.method.Alg(),
+			N: b64(pub.N.Bytes()),
+			E: b64(big.NewInt(int64(pub.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Kid: k.kid, Use: "sig", Alg: k.method.Alg(), Crv: "Ed25519", X: b64(pub)}, true
+	}
+	return JWK{}, false
+}
+
+// thumbprint JWK thumbprint SHA-256 (RFC 7638): hanya member wajib, urut alfabet
+func thumbprint(jwk JWK) string {
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	raw, _ := json.Marshal(members)
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+)
+
+// EmailSender adalah kontrak pengiriman email, bisa diganti (SMTP, log, outbox untuk test)
+type EmailSender interface {
+	Send(to, subject, body string) error
+}
+
+// SMTPEmailSender mengirim email lewat server SMTP
+type SMTPEmailSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (s SMTPEmailSender) Send(to, subject, body string) error {
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		s.From, to, subject, body)
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	return smtp.SendMail(s.Host+":"+s.Port, auth, s.From, []string{to}, []byte(msg))
+}
+
+// LogEmailSender hanya mencetak email ke log (untuk development tanpa SMTP)
+type LogEmailSender struct{}
+
+func (LogEmailSender) Send(to, subject, body string) error {
+	log.Printf("📧 [EMAIL] to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
+
+// NewEmailSender memilih implementasi dari environment.
+// Jika SMTP_HOST kosong, email hanya ditulis ke log.
+func NewEmailSender() EmailSender {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return LogEmailSender{}
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USERNAME")
+	}
+
+	return SMTPEmailSender{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	}
+}
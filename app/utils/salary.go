@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	salaryNumber    = regexp.MustCompile(`[0-9][0-9.,]*`)
+	thousandsGroups = regexp.MustCompile(`^[0-9]{1,3}(\.[0-9]{3})+(,[0-9]+)?$`)
+)
+
+// SalaryLowerBound mengambil batas bawah dari teks gaji_range bebas, dalam rupiah bila satuannya dikenal.
+// Contoh: "Rp3.000.000 - 5.000.000" -> 3000000, "3-5 juta" -> 3000000, "< 3,5 jt" -> 3500000.
+// Titik di antara kelompok 3 digit dianggap pemisah ribuan, koma sebagai desimal.
+func SalaryLowerBound(gaji string) (float64, bool) {
+	token := salaryNumber.FindString(gaji)
+	if token == "" {
+		return 0, false
+	}
+	token = strings.TrimRight(token, ".,")
+	if thousandsGroups.MatchString(token) {
+		token = strings.ReplaceAll(token, ".", "")
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(token, ",", "."), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	lower := strings.ToLower(gaji)
+	switch {
+	case strings.Contains(lower, "juta") || strings.Contains(lower, "jt"):
+		n *= 1_000_000
+	case strings.Contains(lower, "ribu") || strings.Contains(lower, "rb"):
+		n *= 1_000
+	}
+	return n, true
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/mail"
+	"regexp"
+)
+
+var (
+	nimPattern   = regexp.MustCompile(`^[0-9]{8,15}$`)
+	phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 \-]{6,18}[0-9]$`)
+)
+
+// FieldErrors berisi pesan error validasi per field (key = nama field JSON)
+type FieldErrors map[string]string
+
+// Add mencatat error untuk field, error pertama yang dicatat yang dipertahankan
+func (e FieldErrors) Add(field, message string) {
+	if _, exists := e[field]; !exists {
+		e[field] = message
+	}
+}
+
+// Untuk cek format email (hanya alamat polos, tanpa nama tampilan)
+func IsValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// Untuk cek format NIM: 8-15 digit angka
+func IsValidNIM(nim string) bool {
+	return nimPattern.MatchString(nim)
+}
+
+// Untuk cek format nomor telepon: angka, boleh diawali +, dipisah spasi atau -
+func IsValidPhone(phone string) bool {
+	return phonePattern.MatchString(phone)
+}
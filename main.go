@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
 	"praktikum3/config"
 	"praktikum3/database"
+	"praktikum3/middleware"
 	"praktikum3/route"
 
 	"github.com/joho/godotenv"
@@ -29,18 +34,31 @@ import (
 // @in header
 // @name Authorization
 
+// ✅ API key untuk integrasi antar sistem
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
 func main() {
 	// === 1️⃣ Load environment variables ===
 	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️  .env file not found, using system environment variables")
 	}
 
+	// ✅ Key JWT (HS256 / RS256 / EdDSA) dari environment
+	if err := utils.LoadKeys(); err != nil {
+		log.Fatalf("❌ Konfigurasi JWT tidak valid: %v", err)
+	}
+
 	// === 2️⃣ Connect to MongoDB ===
 	mongoDB := database.ConnectMongo()
 	if mongoDB == nil {
 		log.Fatal("❌ Failed to connect to MongoDB")
 	}
 
+	// ✅ Revocation list untuk middleware auth
+	middleware.InitAuth(mongoDB)
+
 	// === 3️⃣ Initialize Fiber App ===
 	app := config.NewApp(mongoDB)
 
@@ -54,11 +72,24 @@ func main() {
 	api := app.Group("/api/v1")
 
 	route.AuthRoute(api, mongoDB)
+	route.UserRoute(api, mongoDB)
+	route.SessionRoute(api, mongoDB)
+	route.ProfileRoute(api, mongoDB)
+	route.RoleRoute(api, mongoDB)
+	route.LockoutRoute(api, mongoDB)
+	route.APIKeyRoute(api, mongoDB)
 	route.AlumniRoute(api, mongoDB)
+	route.AlumniClaimRoute(api, mongoDB)
 	route.PekerjaanRoute(api, mongoDB)
+	route.AnalyticsRoute(api, mongoDB)
+	route.SurveyRoute(api, mongoDB)
 	route.AlumniStatusRoute(app, mongoDB) // ini tidak di bawah /api/v1
+	route.WellKnownRoute(app)             // JWKS, juga di luar /api/v1
 	route.FileRoute(api, mongoDB, "./uploads")
 
+	// ✅ Job latar: pekerjaan aktif yang kontraknya sudah habis otomatis jadi selesai
+	service.StartContractExpiry(context.Background(), repository.NewPekerjaanRepository(mongoDB), service.ContractExpiryInterval())
+
 	// === 6️⃣ PORT ===
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetAPIKeyRepository mengganti penyimpanan API key yang dipakai AuthRequired
+// (dipakai test; di server diisi InitAuth)
+func SetAPIKeyRepository(repo repository.APIKeyRepository) {
+	apiKeys = repo
+}
+
+// authenticateAPIKey memvalidasi header X-API-Key lalu mengisi context dengan
+// service principal yang permission-nya persis permission key tersebut
+func authenticateAPIKey(c *fiber.Ctx, raw string) error {
+	if apiKeys == nil || !strings.HasPrefix(raw, "ak_") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "API key tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	key, err := apiKeys.FindByHash(ctx, utils.HashToken(raw))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal memeriksa API key",
+		})
+	}
+	if key == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "API key tidak valid",
+		})
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "API key sudah dicabut atau expired",
+		})
+	}
+
+	if err := apiKeys.TouchLastUsed(ctx, key.ID, now); err != nil {
+		log.Printf("api key: gagal mencatat last_used_at %s: %v", key.ID.Hex(), err)
+	}
+
+	// permission yang sudah dihapus dari registry tidak ikut diberikan
+	perms := make([]string, 0, len(key.Permissions))
+	for _, p := range key.Permissions {
+		if model.IsValidPermission(p) {
+			perms = append(perms, p)
+		}
+	}
+
+	c.Locals("user", map[string]interface{}{
+		"id":         key.ID.Hex(),
+		"username":   key.Name,
+		"role":       model.PrincipalService,
+		"alumni_id":  "",
+		"api_key_id": key.ID.Hex(),
+	})
+	c.Locals("role", model.PrincipalService)
+	c.Locals("api_key", key)
+	c.Locals("permissions", perms)
+
+	return c.Next()
+}
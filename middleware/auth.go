@@ -1,15 +1,50 @@
 package middleware
 
 import (
+	"context"
+	"log"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
 	"praktikum3/app/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// revocation list JWT, role -> permission, dan API key, diisi lewat InitAuth saat server start
+var (
+	revocations repository.TokenRevocationRepository
+	roles       repository.RoleRepository
+	apiKeys     repository.APIKeyRepository
+	sessions    repository.SessionRepository
 )
 
-// AuthRequired middleware untuk endpoint yang wajib login
+// InitAuth menyiapkan penyimpanan yang dibutuhkan AuthRequired (revocation list, role, API key, sesi)
+// dan memastikan role bawaan sudah ada di database
+func InitAuth(db *mongo.Database) {
+	revocations = repository.NewTokenRevocationRepository(db)
+	roles = repository.NewRoleRepository(db)
+	apiKeys = repository.NewAPIKeyRepository(db)
+	sessions = repository.NewSessionRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := roles.EnsureDefaults(ctx); err != nil {
+		log.Println("⚠️  gagal seed role bawaan:", err)
+	}
+}
+
+// AuthRequired middleware untuk endpoint yang wajib login.
+// Selain JWT user (Authorization: Bearer), integrasi sistem bisa memakai header X-API-Key.
 func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if apiKey := c.Get("X-API-Key"); apiKey != "" {
+			return authenticateAPIKey(c, apiKey)
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -46,42 +81,143 @@ func AuthRequired() fiber.Handler {
 			})
 		}
 
+		// Cek revocation list (token yang sudah logout)
+		if revocations != nil {
+			if claims.ID == "" {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"message": "Token tidak valid, silakan login ulang",
+				})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			revoked, err := revocations.IsRevoked(ctx, claims.ID)
+			cancel()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"message": "Gagal memeriksa status token",
+				})
+			}
+			if revoked {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"message": "Token sudah tidak berlaku (logout)",
+				})
+			}
+
+			// sesi yang diakhiri dari perangkat lain
+			if claims.SessionID != "" {
+				ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
+				revoked, err = revocations.IsRevoked(ctx, repository.SessionRevocationKey(claims.SessionID))
+				cancel()
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"success": false,
+						"message": "Gagal memeriksa status token",
+					})
+				}
+				if revoked {
+					return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+						"success": false,
+						"message": "Sesi sudah diakhiri, silakan login ulang",
+					})
+				}
+			}
+
+			// pencabutan massal (misal setelah reset password)
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			userID, _ := primitive.ObjectIDFromHex(claims.UserID)
+			ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
+			revoked, err = revocations.IsUserRevoked(ctx, userID, issuedAt)
+			cancel()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"message": "Gagal memeriksa status token",
+				})
+			}
+			if revoked {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"message": "Sesi sudah berakhir, silakan login ulang",
+				})
+			}
+		}
+
+		// Permission dari role (tanpa store: pakai permission bawaan)
+		perms := model.DefaultPermissions(claims.Role)
+		if roles != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			perms, err = roles.Permissions(ctx, claims.Role)
+			cancel()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"message": "Gagal memeriksa hak akses",
+				})
+			}
+		}
+
+		// catat aktivitas terakhir sesi (di-throttle di repository)
+		if sessions != nil {
+			if sid, err := primitive.ObjectIDFromHex(claims.SessionID); err == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				if err := sessions.Touch(ctx, sid); err != nil {
+					log.Printf("session: gagal memperbarui last_seen_at %s: %v", claims.SessionID, err)
+				}
+				cancel()
+			}
+		}
+
 		// Simpan data user ke context
 		c.Locals("user", map[string]interface{}{
-			"id":       claims.UserID,
-			"username": claims.Username,
-			"role":     claims.Role,
+			"id":        claims.UserID,
+			"username":  claims.Username,
+			"role":      claims.Role,
+			"alumni_id": claims.AlumniID,
 		})
 		c.Locals("role", claims.Role)
+		c.Locals("claims", claims)
+		c.Locals("permissions", perms)
 
 		return c.Next()
 	}
 }
 
-// AdminOnly middleware untuk admin saja
-func AdminOnly() fiber.Handler {
+// Require middleware: user wajib punya SEMUA permission yang disebut
+func Require(perms ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		role, _ := c.Locals("role").(string)
-		if role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Hanya admin yang boleh mengakses endpoint ini",
-			})
+		granted, _ := c.Locals("permissions").([]string)
+		for _, p := range perms {
+			if !model.HasPermission(granted, p) {
+				return forbidden(c)
+			}
 		}
 		return c.Next()
 	}
 }
 
-// UserOnly middleware untuk user biasa saja
-func UserOnly() fiber.Handler {
+// RequireAny middleware: user cukup punya SALAH SATU permission yang disebut
+// (misal hapus milik sendiri ATAU hapus semua; pembedaan detail ada di service)
+func RequireAny(perms ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		role, _ := c.Locals("role").(string)
-		if role != "user" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Hanya user yang boleh mengakses endpoint ini",
-			})
+		granted, _ := c.Locals("permissions").([]string)
+		for _, p := range perms {
+			if model.HasPermission(granted, p) {
+				return c.Next()
+			}
 		}
-		return c.Next()
+		return forbidden(c)
 	}
 }
+
+func forbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"success": false,
+		"message": "Tidak punya akses ke endpoint ini",
+	})
+}
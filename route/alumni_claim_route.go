@@ -0,0 +1,31 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AlumniClaimRoute mendaftarkan alur klaim data alumni (user mengajukan, admin mereview)
+func AlumniClaimRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewAlumniClaimService(
+		repository.NewAlumniClaimRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewAlumniRepository(db),
+	)
+
+	g := r.Group("/alumni-claims", middleware.AuthRequired())
+
+	// Pengajuan
+	g.Post("/", middleware.Require(model.PermAlumniClaimsCreate), s.Create)
+	g.Get("/me", middleware.Require(model.PermAlumniClaimsCreate), s.GetMine)
+
+	// Review
+	g.Get("/", middleware.Require(model.PermAlumniClaimsReview), s.GetAll)
+	g.Put("/:id/approve", middleware.Require(model.PermAlumniClaimsReview), s.Approve)
+	g.Put("/:id/reject", middleware.Require(model.PermAlumniClaimsReview), s.Reject)
+}
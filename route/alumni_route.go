@@ -2,6 +2,7 @@ package route
 
 import (
     "os"
+    "praktikum3/app/model"
     "praktikum3/app/repository"
     "praktikum3/app/service"
     "praktikum3/middleware"
@@ -16,7 +17,7 @@ func isRunningTest() bool {
 
 func AlumniRoute(r fiber.Router, db *mongo.Database) {
     repo := repository.NewAlumniRepository(db)
-    al := service.NewAlumniService(repo)
+    al := service.NewAlumniService(repo, repository.NewPekerjaanRepository(db))
 
     testMode := isRunningTest()
 
@@ -31,7 +32,7 @@ func AlumniRoute(r fiber.Router, db *mongo.Database) {
 
     //
     // ================================
-    // ADMIN ROUTES
+    // TRASH ROUTES
     // ================================
     //
     if testMode {
@@ -40,31 +41,40 @@ func AlumniRoute(r fiber.Router, db *mongo.Database) {
         g.Put("/restore/:id", al.Restore)
         g.Delete("/hard/:id", al.HardDelete)
     } else {
-        g.Get("/trash", middleware.AdminOnly(), al.GetTrashed)
-        g.Put("/restore/:id", middleware.AdminOnly(), al.Restore)
-        g.Delete("/hard/:id", middleware.AdminOnly(), al.HardDelete)
+        g.Get("/trash", middleware.Require(model.PermAlumniRestore), al.GetTrashed)
+        g.Put("/restore/:id", middleware.Require(model.PermAlumniRestore), al.Restore)
+        g.Delete("/hard/:id", middleware.Require(model.PermAlumniHardDelete), al.HardDelete)
     }
 
     //
     // ================================
-    // CRUD ROUTES (admin only)
+    // CRUD ROUTES
     // ================================
     //
     if testMode {
         g.Post("/", al.Create)
         g.Put("/:id", al.Update)
+        g.Patch("/:id", al.Patch)
         g.Delete("/:id", al.SoftDelete)
     } else {
-        g.Post("/", middleware.AdminOnly(), al.Create)
-        g.Put("/:id", middleware.AdminOnly(), al.Update)
-        g.Delete("/:id", middleware.AdminOnly(), al.SoftDelete)
+        g.Post("/", middleware.Require(model.PermAlumniWrite), al.Create)
+        g.Put("/:id", middleware.Require(model.PermAlumniWrite), al.Update)
+        g.Patch("/:id", middleware.Require(model.PermAlumniWrite), al.Patch)
+        g.Delete("/:id", middleware.Require(model.PermAlumniDelete), al.SoftDelete)
     }
 
     //
     // ================================
-    // PUBLIC ROUTES
+    // READ ROUTES
     // ================================
     //
-    g.Get("/", al.GetAll)
-    g.Get("/:id", al.GetByID)
+    if testMode {
+        g.Get("/", al.GetAll)
+        g.Get("/:id", al.GetByID)
+        g.Get("/:id/timeline", al.Timeline)
+    } else {
+        g.Get("/", middleware.Require(model.PermAlumniRead), al.GetAll)
+        g.Get("/:id", middleware.Require(model.PermAlumniRead), al.GetByID)
+        g.Get("/:id/timeline", middleware.Require(model.PermAlumniRead), al.Timeline)
+    }
 }
@@ -1,8 +1,10 @@
 package route
 
 import (
+	"praktikum3/app/model"
 	"praktikum3/app/repository"
 	"praktikum3/app/service"
+	"praktikum3/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,8 +16,8 @@ func AlumniStatusRoute(app *fiber.App, db *mongo.Database) {
 	statusRepo := repository.NewAlumniStatusRepository(db)
 	statusService := service.NewAlumniStatusService(statusRepo)
 
-	// Buat group route untuk alumni status
-	r := app.Group("/alumni-status")
+	// Buat group route untuk alumni status; laporan berisi data alumni + pekerjaan
+	r := app.Group("/alumni-status", middleware.AuthRequired(), middleware.Require(model.PermAlumniRead, model.PermPekerjaanRead))
 
 	// GET /alumni-status?status=aktif
 	r.Get("/", statusService.GetAlumniByStatus)
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsRoute mendaftarkan endpoint dashboard analitik karier alumni (tracer study)
+func AnalyticsRoute(r fiber.Router, db *mongo.Database) {
+	repo := repository.NewAnalyticsRepository(db)
+	a := service.NewAnalyticsService(repo)
+
+	g := r.Group("/analytics", middleware.AuthRequired(), middleware.Require(model.PermAnalyticsRead))
+
+	g.Get("/employment-rate", a.EmploymentRate)
+	g.Get("/waiting-time", a.WaitingTime)
+	g.Get("/distribution/:field", a.Distribution)
+	g.Get("/salary", a.SalaryHistogram)
+}
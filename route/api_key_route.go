@@ -0,0 +1,21 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// APIKeyRoute mendaftarkan endpoint pengelolaan API key integrasi
+func APIKeyRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewAPIKeyService(repository.NewAPIKeyRepository(db))
+
+	g := r.Group("/api-keys", middleware.AuthRequired(), middleware.Require(model.PermAPIKeysManage))
+	g.Get("/", s.GetAll)
+	g.Post("/", s.Create)
+	g.Delete("/:id", s.Revoke)
+}
@@ -2,6 +2,7 @@ package route
 
 import (
 	"praktikum3/app/service"
+	"praktikum3/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,4 +14,27 @@ func AuthRoute(app fiber.Router, db *mongo.Database) {
 
 	// 🟢 Endpoint login (tanpa middleware)
 	app.Post("/login", authService.Login)
+	app.Post("/refresh", authService.Refresh)
+
+	// 🟢 Login langkah kedua (2FA), memakai challenge_token dari /login
+	app.Post("/login/2fa", authService.LoginTwoFactor)
+	app.Post("/login/2fa/setup", authService.LoginTwoFactorSetup)
+
+	// 🟢 Lupa / reset password (tanpa middleware)
+	app.Post("/forgot-password", authService.ForgotPassword)
+	app.Post("/reset-password", authService.ResetPassword)
+
+	// 🔒 Logout & ganti password (wajib login)
+	app.Post("/logout", middleware.AuthRequired(), authService.Logout)
+	app.Put("/me/password", middleware.AuthRequired(), authService.ChangePassword)
+
+	// 🔒 Kelola 2FA (TOTP) akun sendiri
+	app.Post("/me/2fa/setup", middleware.AuthRequired(), authService.SetupTwoFactor)
+	app.Post("/me/2fa/enable", middleware.AuthRequired(), authService.EnableTwoFactor)
+	app.Post("/me/2fa/disable", middleware.AuthRequired(), authService.DisableTwoFactor)
+
+	// 🟢 Register & verifikasi email (tanpa middleware)
+	app.Post("/register", authService.Register)
+	app.Get("/verify-email", authService.VerifyEmail) // link dari email
+	app.Post("/verify-email", authService.VerifyEmail)
 }
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"praktikum3/app/model"
 	"praktikum3/app/repository"
 	"praktikum3/app/service"
 	"praktikum3/middleware"
@@ -17,14 +18,15 @@ func FileRoute(app fiber.Router, db *mongo.Database, uploadBase string) {
 	api := app.Group("/api/files", middleware.AuthRequired())
 
 	// === Upload ===
-	// Admin dan user bisa upload, tapi validasi siapa boleh upload untuk siapa ada di service
-	api.Post("/photo", svc.UploadPhoto)           // ✅ upload foto (jpg/png ≤1MB)
-	api.Post("/certificate", svc.UploadCertificate) // ✅ upload sertifikat (pdf ≤2MB)
+	// Validasi siapa boleh upload untuk alumni mana ada di service
+	upload := middleware.RequireAny(model.PermFilesUploadOwn, model.PermFilesUploadAny)
+	api.Post("/photo", upload, svc.UploadPhoto)             // ✅ upload foto (jpg/png ≤1MB)
+	api.Post("/certificate", upload, svc.UploadCertificate) // ✅ upload sertifikat (pdf ≤2MB)
 
 	// === Read ===
-	api.Get("/", middleware.AdminOnly(), svc.GetAll) // ✅ hanya admin bisa lihat semua file
-	api.Get("/:id", svc.GetByID)                     // ✅ semua user bisa lihat file by id
+	api.Get("/", middleware.Require(model.PermFilesReadAll), svc.GetAll) // ✅ daftar semua file
+	api.Get("/:id", middleware.Require(model.PermFilesRead), svc.GetByID)  // ✅ detail file by id
 
 	// === Delete ===
-	api.Delete("/:id", svc.DeleteByID) // ✅ admin bisa hapus semua, user hanya miliknya sendiri
+	api.Delete("/:id", middleware.RequireAny(model.PermFilesDeleteOwn, model.PermFilesDeleteAny), svc.DeleteByID) // ✅ hapus semua / hanya milik sendiri
 }
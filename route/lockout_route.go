@@ -0,0 +1,22 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LockoutRoute mendaftarkan endpoint untuk melihat & membuka lockout login
+func LockoutRoute(r fiber.Router, db *mongo.Database) {
+	repo := repository.NewLoginAttemptRepository(db)
+	l := service.NewLockoutService(repo)
+
+	g := r.Group("/lockouts", middleware.AuthRequired(), middleware.Require(model.PermLockoutsManage))
+
+	g.Get("/", l.GetLocked)
+	g.Delete("/:kind/:subject", l.Clear)
+}
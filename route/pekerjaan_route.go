@@ -1,6 +1,7 @@
 package route
 
 import (
+	"praktikum3/app/model"
 	"praktikum3/app/repository"
 	"praktikum3/app/service"
 	"praktikum3/middleware"
@@ -15,16 +16,19 @@ func PekerjaanRoute(r fiber.Router, db *mongo.Database) {
 
 	g := r.Group("/pekerjaan", middleware.AuthRequired())
 
-	// Admin only
-	g.Post("/", middleware.AdminOnly(), p.Create)
-	g.Put("/:id", middleware.AdminOnly(), p.Update)
-	g.Delete("/hard/:id", middleware.AdminOnly(), p.HardDelete)
-	g.Get("/trash", middleware.AdminOnly(), p.GetTrashed)
+	// Tulis & trash
+	g.Post("/", middleware.Require(model.PermPekerjaanWrite), p.Create)
+	g.Put("/:id", middleware.Require(model.PermPekerjaanWrite), p.Update)
+	g.Patch("/:id", middleware.Require(model.PermPekerjaanWrite), p.Patch)
+	g.Delete("/hard/:id", middleware.Require(model.PermPekerjaanHardDelete), p.HardDelete)
+	g.Get("/trash", middleware.Require(model.PermPekerjaanRestore), p.GetTrashed)
+	g.Put("/restore/:id", middleware.Require(model.PermPekerjaanRestore), p.Restore)
 
-	// Semua user
-	g.Get("/", p.GetAll)
-	g.Get("/:id", p.GetByID)
-	g.Get("/alumni/:alumni_id", p.GetByAlumniID)
-	g.Delete("/:id", p.SoftDelete)
-	g.Put("/restore/:id", middleware.AdminOnly(), p.Restore)
+	// Baca
+	g.Get("/", middleware.Require(model.PermPekerjaanRead), p.GetAll)
+	g.Get("/:id", middleware.Require(model.PermPekerjaanRead), p.GetByID)
+	g.Get("/alumni/:alumni_id", middleware.Require(model.PermPekerjaanRead), p.GetByAlumniID)
+
+	// Hapus: semua pekerjaan atau hanya milik sendiri (dibedakan di service)
+	g.Delete("/:id", middleware.RequireAny(model.PermPekerjaanDelete, model.PermPekerjaanDeleteOwn), p.SoftDelete)
 }
@@ -0,0 +1,27 @@
+package route
+
+import (
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProfileRoute mendaftarkan endpoint profil milik user yang sedang login
+func ProfileRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewProfileService(
+		repository.NewUserRepository(db),
+		repository.NewAlumniRepository(db),
+		repository.NewUserTokenRepository(db),
+		utils.RealPasswordChecker{},
+		utils.NewEmailSender(),
+	)
+
+	r.Get("/me", middleware.AuthRequired(), s.GetMe)
+	r.Put("/me", middleware.AuthRequired(), s.UpdateMe)
+	r.Get("/me/alumni", middleware.AuthRequired(), s.GetMyAlumni)
+	r.Put("/me/alumni", middleware.AuthRequired(), s.UpdateMyAlumni)
+}
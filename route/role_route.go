@@ -0,0 +1,25 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoleRoute mendaftarkan endpoint pengelolaan role & permission
+func RoleRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewRoleService(repository.NewRoleRepository(db), repository.NewUserRepository(db))
+
+	manage := middleware.Require(model.PermRolesManage)
+	r.Get("/permissions", middleware.AuthRequired(), manage, s.GetPermissions)
+
+	g := r.Group("/roles", middleware.AuthRequired(), manage)
+	g.Get("/", s.GetAll)
+	g.Post("/", s.Create)
+	g.Put("/:name", s.Update)
+	g.Delete("/:name", s.Delete)
+}
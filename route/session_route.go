@@ -0,0 +1,28 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SessionRoute mendaftarkan endpoint daftar sesi login & sign out jarak jauh
+func SessionRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewSessionService(
+		repository.NewSessionRepository(db),
+		repository.NewRefreshTokenRepository(db),
+		repository.NewTokenRevocationRepository(db),
+		repository.NewUserRepository(db),
+	)
+
+	// 🔒 Sesi milik sendiri
+	r.Get("/me/sessions", middleware.AuthRequired(), s.GetMine)
+	r.Delete("/me/sessions/:id", middleware.AuthRequired(), s.Revoke)
+
+	// 🔒 Admin: sign out user dari semua perangkat
+	r.Delete("/users/:id/sessions", middleware.AuthRequired(), middleware.Require(model.PermUsersManage), s.RevokeAllForUser)
+}
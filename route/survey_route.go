@@ -0,0 +1,37 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SurveyRoute mendaftarkan kuesioner tracer study (admin menyusun, alumni mengisi)
+func SurveyRoute(r fiber.Router, db *mongo.Database) {
+	s := service.NewSurveyService(
+		repository.NewSurveyRepository(db),
+		repository.NewUserRepository(db),
+	)
+
+	g := r.Group("/surveys", middleware.AuthRequired())
+
+	// Alumni
+	g.Get("/active", middleware.RequireAny(model.PermSurveysRespond, model.PermSurveysManage), s.GetActive)
+	g.Get("/:id", middleware.RequireAny(model.PermSurveysRespond, model.PermSurveysManage), s.GetByID)
+	g.Post("/:id/responses", middleware.Require(model.PermSurveysRespond), s.Submit)
+	g.Get("/:id/responses/me", middleware.Require(model.PermSurveysRespond), s.GetMyResponse)
+
+	// Pengelola
+	g.Post("/", middleware.Require(model.PermSurveysManage), s.Create)
+	g.Get("/", middleware.Require(model.PermSurveysManage), s.GetAll)
+	g.Put("/:id", middleware.Require(model.PermSurveysManage), s.Update)
+	g.Post("/:id/versions", middleware.Require(model.PermSurveysManage), s.NewVersion)
+	g.Post("/:id/publish", middleware.Require(model.PermSurveysManage), s.Publish)
+	g.Post("/:id/close", middleware.Require(model.PermSurveysManage), s.Close)
+	g.Get("/:id/responses", middleware.Require(model.PermSurveysManage), s.ExportResponses)
+	g.Get("/:id/aggregates", middleware.Require(model.PermSurveysManage), s.Aggregates)
+}
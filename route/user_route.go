@@ -0,0 +1,37 @@
+package route
+
+import (
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UserRoute mendaftarkan endpoint manajemen user
+func UserRoute(r fiber.Router, db *mongo.Database) {
+	repo := repository.NewUserRepository(db)
+	u := service.NewUserService(
+		repo,
+		repository.NewRoleRepository(db),
+		repository.NewRefreshTokenRepository(db),
+		repository.NewTokenRevocationRepository(db),
+		repository.NewSessionRepository(db),
+	)
+
+	g := r.Group("/users", middleware.AuthRequired(), middleware.Require(model.PermUsersManage))
+
+	// Trash / restore / hard delete
+	g.Get("/trash", u.GetTrashed)
+	g.Put("/restore/:id", u.Restore)
+	g.Delete("/hard/:id", u.HardDelete)
+
+	// CRUD
+	g.Get("/", u.GetAll)
+	g.Post("/", u.Create)
+	g.Get("/:id", u.GetByID)
+	g.Put("/:id/role", u.UpdateRole)
+	g.Delete("/:id", u.SoftDelete)
+}
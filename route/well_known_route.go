@@ -0,0 +1,12 @@
+package route
+
+import (
+	"praktikum3/app/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WellKnownRoute mendaftarkan endpoint /.well-known (di luar /api/v1)
+func WellKnownRoute(app *fiber.App) {
+	app.Get("/.well-known/jwks.json", service.JWKS)
+}
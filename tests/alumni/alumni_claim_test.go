@@ -0,0 +1,200 @@
+package alumni_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupClaimApp(svc *service.AlumniClaimService, userID primitive.ObjectID, role string) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: userID.Hex(), Role: role})
+		return c.Next()
+	})
+	app.Post("/alumni-claims", svc.Create)
+	app.Get("/alumni-claims/me", svc.GetMine)
+	app.Get("/alumni-claims", svc.GetAll)
+	app.Put("/alumni-claims/:id/approve", svc.Approve)
+	app.Put("/alumni-claims/:id/reject", svc.Reject)
+	return app
+}
+
+func sendClaim(app *fiber.App, method, url string, v interface{}) int {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ===========================
+// CLAIM: USER MENGAJUKAN KLAIM
+// ===========================
+func TestClaimCreate(t *testing.T) {
+	userID := primitive.NewObjectID()
+	alumniID := primitive.NewObjectID()
+	linked := primitive.NewObjectID()
+
+	var created *model.AlumniClaim
+	users := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: id}, nil
+		},
+	}
+	alumni := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) {
+			if id == alumniID {
+				return &model.Alumni{ID: id}, nil
+			}
+			return nil, nil
+		},
+	}
+	claims := &mocks.AlumniClaimRepositoryMock{
+		CreateFunc: func(ctx context.Context, c *model.AlumniClaim) error {
+			created = c
+			return nil
+		},
+	}
+	app := setupClaimApp(service.NewAlumniClaimService(claims, users, alumni), userID, model.RoleUser)
+
+	assert.Equal(t, 400, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: "xxx"}))
+	assert.Equal(t, 404, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: primitive.NewObjectID().Hex()}))
+
+	assert.Equal(t, 201, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: alumniID.Hex(), Message: "NIM saya"}))
+	if assert.NotNil(t, created) {
+		assert.Equal(t, userID, created.UserID)
+		assert.Equal(t, alumniID, created.AlumniID)
+	}
+
+	// klaim pending kedua ditolak
+	claims.CreateFunc = func(ctx context.Context, c *model.AlumniClaim) error { return repository.ErrClaimPending }
+	assert.Equal(t, 409, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: alumniID.Hex()}))
+
+	// alumni sudah dimiliki akun lain
+	users.FindByAlumniIDFunc = func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+		return &model.User{ID: primitive.NewObjectID()}, nil
+	}
+	assert.Equal(t, 409, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: alumniID.Hex()}))
+
+	// akun sudah terhubung
+	users.FindByIDFunc = func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+		return &model.User{ID: id, AlumniID: &linked}, nil
+	}
+	assert.Equal(t, 409, sendClaim(app, "POST", "/alumni-claims", model.CreateAlumniClaimRequest{AlumniID: alumniID.Hex()}))
+}
+
+// ===========================
+// CLAIM: ADMIN MENYETUJUI KLAIM
+// ===========================
+func TestClaimApprove(t *testing.T) {
+	adminID := primitive.NewObjectID()
+	claimID := primitive.NewObjectID()
+	claim := &model.AlumniClaim{ID: claimID, UserID: primitive.NewObjectID(), AlumniID: primitive.NewObjectID(), Status: model.ClaimStatusPending}
+
+	var linkedUser, linkedAlumni primitive.ObjectID
+	var resolvedStatus string
+	var reviewer primitive.ObjectID
+	users := &mocks.UserRepositoryMock{
+		LinkAlumniFunc: func(ctx context.Context, id, alumniID primitive.ObjectID) error {
+			linkedUser, linkedAlumni = id, alumniID
+			return nil
+		},
+	}
+	alumni := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) { return &model.Alumni{ID: id}, nil },
+	}
+	claims := &mocks.AlumniClaimRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error) {
+			if id == claimID {
+				return claim, nil
+			}
+			return nil, nil
+		},
+		ResolveFunc: func(ctx context.Context, id primitive.ObjectID, status string, by primitive.ObjectID, note string) (bool, error) {
+			resolvedStatus, reviewer = status, by
+			return true, nil
+		},
+	}
+	app := setupClaimApp(service.NewAlumniClaimService(claims, users, alumni), adminID, model.RoleAdmin)
+
+	assert.Equal(t, 400, sendClaim(app, "PUT", "/alumni-claims/xxx/approve", nil))
+	assert.Equal(t, 404, sendClaim(app, "PUT", "/alumni-claims/"+primitive.NewObjectID().Hex()+"/approve", nil))
+
+	assert.Equal(t, 200, sendClaim(app, "PUT", "/alumni-claims/"+claimID.Hex()+"/approve", model.ReviewAlumniClaimRequest{Note: "cocok"}))
+	assert.Equal(t, claim.UserID, linkedUser)
+	assert.Equal(t, claim.AlumniID, linkedAlumni)
+	assert.Equal(t, model.ClaimStatusApproved, resolvedStatus)
+	assert.Equal(t, adminID, reviewer)
+
+	// alumni sudah dimiliki akun lain (race dengan index unik)
+	users.LinkAlumniFunc = func(ctx context.Context, id, alumniID primitive.ObjectID) error { return repository.ErrAlumniLinked }
+	assert.Equal(t, 409, sendClaim(app, "PUT", "/alumni-claims/"+claimID.Hex()+"/approve", nil))
+
+	// klaim lebih dulu ditolak admin lain setelah dibaca: link dibatalkan, 409
+	users.LinkAlumniFunc = func(ctx context.Context, id, alumniID primitive.ObjectID) error { return nil }
+	var unlinkedUser, unlinkedAlumni primitive.ObjectID
+	users.UnlinkAlumniFunc = func(ctx context.Context, id, alumniID primitive.ObjectID) error {
+		unlinkedUser, unlinkedAlumni = id, alumniID
+		return nil
+	}
+	claims.ResolveFunc = func(ctx context.Context, id primitive.ObjectID, status string, by primitive.ObjectID, note string) (bool, error) {
+		return false, nil
+	}
+	assert.Equal(t, 409, sendClaim(app, "PUT", "/alumni-claims/"+claimID.Hex()+"/approve", nil))
+	assert.Equal(t, claim.UserID, unlinkedUser)
+	assert.Equal(t, claim.AlumniID, unlinkedAlumni)
+
+	// klaim yang sudah diproses tidak bisa disetujui lagi
+	claim.Status = model.ClaimStatusRejected
+	assert.Equal(t, 409, sendClaim(app, "PUT", "/alumni-claims/"+claimID.Hex()+"/approve", nil))
+}
+
+// ===========================
+// CLAIM: ADMIN MENOLAK & MELIHAT KLAIM
+// ===========================
+func TestClaimRejectAndList(t *testing.T) {
+	claimID := primitive.NewObjectID()
+	var resolvedStatus, filter string
+	claims := &mocks.AlumniClaimRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error) {
+			return &model.AlumniClaim{ID: id, Status: model.ClaimStatusPending}, nil
+		},
+		ResolveFunc: func(ctx context.Context, id primitive.ObjectID, status string, by primitive.ObjectID, note string) (bool, error) {
+			resolvedStatus = status
+			return true, nil
+		},
+		FindAllFunc: func(ctx context.Context, status string) ([]model.AlumniClaim, error) {
+			filter = status
+			return []model.AlumniClaim{}, nil
+		},
+	}
+	users := &mocks.UserRepositoryMock{
+		LinkAlumniFunc: func(ctx context.Context, id, alumniID primitive.ObjectID) error {
+			t.Fatal("klaim yang ditolak tidak boleh menghubungkan akun")
+			return nil
+		},
+	}
+	app := setupClaimApp(service.NewAlumniClaimService(claims, users, &mocks.AlumniRepositoryMock{}), primitive.NewObjectID(), model.RoleAdmin)
+
+	assert.Equal(t, 200, sendClaim(app, "PUT", "/alumni-claims/"+claimID.Hex()+"/reject", model.ReviewAlumniClaimRequest{Note: "NIM tidak cocok"}))
+	assert.Equal(t, model.ClaimStatusRejected, resolvedStatus)
+
+	assert.Equal(t, 200, sendClaim(app, "GET", "/alumni-claims?status=pending", nil))
+	assert.Equal(t, model.ClaimStatusPending, filter)
+	assert.Equal(t, 400, sendClaim(app, "GET", "/alumni-claims?status=bogus", nil))
+}
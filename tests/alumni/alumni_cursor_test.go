@@ -0,0 +1,71 @@
+package alumni_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================== CURSOR ==========================
+func TestGetAll_CursorMode(t *testing.T) {
+	var gotQ model.AlumniQuery
+	var gotCursor string
+	repo := &mocks.AlumniRepositoryMock{
+		GetAllByCursorFunc: func(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error) {
+			gotQ, gotCursor = q, cursor
+			return []model.Alumni{{Nama: "Budi"}}, "abc", nil
+		},
+		GetAllFunc: func(q model.AlumniQuery) ([]model.Alumni, error) {
+			t.Fatal("GetAll tidak boleh dipanggil pada mode cursor")
+			return nil, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/alumni?cursor=xyz&sortBy=nama&limit=5", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "xyz", gotCursor)
+	assert.Equal(t, "nama", gotQ.SortBy)
+	assert.Equal(t, 5, gotQ.Limit)
+
+	var body struct {
+		Meta model.CursorMeta `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, model.CursorMeta{Limit: 5, SortBy: "nama", Order: "DESC", NextCursor: "abc", HasMore: true}, body.Meta)
+}
+
+func TestGetAll_CursorFirstAndLastPage(t *testing.T) {
+	var gotCursor = "-"
+	repo := &mocks.AlumniRepositoryMock{
+		GetAllByCursorFunc: func(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error) {
+			gotCursor = cursor
+			return []model.Alumni{}, "", nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/alumni?cursor=", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", gotCursor)
+
+	var body map[string]map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, false, body["meta"]["has_more"])
+	assert.NotContains(t, body["meta"], "next_cursor")
+}
+
+func TestGetAll_InvalidCursor(t *testing.T) {
+	repo := &mocks.AlumniRepositoryMock{
+		GetAllByCursorFunc: func(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error) {
+			return nil, "", repository.ErrInvalidCursor
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/alumni?cursor=rusak", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
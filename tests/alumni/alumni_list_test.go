@@ -0,0 +1,81 @@
+package alumni_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================
+// TEST LIST: PAGINASI, FILTER, URUTAN
+// ================================
+func TestGetAll_QueryAndMeta(t *testing.T) {
+	var got model.AlumniQuery
+	repo := &mocks.AlumniRepositoryMock{
+		GetAllFunc: func(q model.AlumniQuery) ([]model.Alumni, error) {
+			got = q
+			return []model.Alumni{{Nama: "Budi"}}, nil
+		},
+		CountFunc: func(q model.AlumniQuery) (int, error) {
+			return 45, nil
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/alumni?search=%20budi%20&jurusan=Teknik%20Informatika&angkatan=2020&sortBy=nama&order=asc&page=3&limit=20", nil)
+	resp, _ := setupTestApp(repo).Test(req)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	assert.Equal(t, model.AlumniQuery{
+		Search:   "budi",
+		Jurusan:  "Teknik Informatika",
+		Angkatan: 2020,
+		SortBy:   "nama",
+		Order:    "ASC",
+		Limit:    20,
+		Offset:   40,
+	}, got)
+
+	var body struct {
+		Meta model.MetaInfo `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, model.MetaInfo{Page: 3, Limit: 20, Total: 45, Pages: 3, SortBy: "nama", Order: "ASC", Search: "budi"}, body.Meta)
+}
+
+func TestGetAll_InvalidQuery(t *testing.T) {
+	app := setupTestApp(&mocks.AlumniRepositoryMock{})
+
+	for _, url := range []string{
+		"/alumni?sortBy=password",
+		"/alumni?order=sideways",
+		"/alumni?angkatan=dua-ribu",
+		"/alumni?tahun_lulus=-1",
+		"/alumni?page=0",
+		"/alumni?page=dua",
+		"/alumni?limit=-5",
+		"/alumni?limit=sepuluh",
+	} {
+		resp, _ := app.Test(httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, 400, resp.StatusCode, url)
+	}
+}
+
+func TestGetAll_LimitCapped(t *testing.T) {
+	var got model.AlumniQuery
+	repo := &mocks.AlumniRepositoryMock{
+		GetAllFunc: func(q model.AlumniQuery) ([]model.Alumni, error) {
+			got = q
+			return []model.Alumni{}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/alumni?limit=1000&page=2", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, model.MaxAlumniLimit, got.Limit)
+	assert.Equal(t, model.MaxAlumniLimit, got.Offset)
+}
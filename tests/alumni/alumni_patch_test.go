@@ -0,0 +1,75 @@
+package alumni_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func patchAlumni(repo *mocks.AlumniRepositoryMock, id, body string) int {
+	req := httptest.NewRequest("PATCH", "/alumni/"+id, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"0"`)
+	resp, _ := setupTestApp(repo).Test(req)
+	return resp.StatusCode
+}
+
+// ================================
+// TEST PATCH (JSON MERGE PATCH)
+// ================================
+func TestPatch_OnlyProvidedFields(t *testing.T) {
+	id := primitive.NewObjectID()
+	var gotSet, gotUnset []string
+	var got *model.Alumni
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Alumni, error) {
+			// data lama tanpa NIM tetap bisa di-patch
+			return &model.Alumni{ID: oid, Nama: "Budi", Email: "budi@example.com", Alamat: "Jl. Lama", NoTelepon: "081234567890"}, nil
+		},
+		PatchFunc: func(oid primitive.ObjectID, a *model.Alumni, set, unset []string, version int64) error {
+			got, gotSet, gotUnset = a, set, unset
+			return nil
+		},
+	}
+
+	status := patchAlumni(repo, id.Hex(), `{"nama":" Budi Santoso ","alamat":null}`)
+	assert.Equal(t, 200, status)
+	assert.ElementsMatch(t, []string{"nama"}, gotSet)
+	assert.ElementsMatch(t, []string{"alamat"}, gotUnset)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "Budi Santoso", got.Nama)
+		assert.Equal(t, "081234567890", got.NoTelepon)
+	}
+}
+
+func TestPatch_Rejected(t *testing.T) {
+	id := primitive.NewObjectID()
+	patched := false
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Alumni, error) {
+			if oid != id {
+				return nil, nil
+			}
+			return &model.Alumni{ID: oid, NIM: "2020101234", Nama: "Budi", Email: "budi@example.com", Angkatan: 2020, TahunLulus: 2024}, nil
+		},
+		PatchFunc: func(oid primitive.ObjectID, a *model.Alumni, set, unset []string, version int64) error {
+			patched = true
+			return nil
+		},
+	}
+
+	assert.Equal(t, 400, patchAlumni(repo, "INVALID", `{}`))
+	assert.Equal(t, 400, patchAlumni(repo, id.Hex(), `[1,2]`))
+	assert.Equal(t, 400, patchAlumni(repo, id.Hex(), `{"angkatan":"dua ribu"}`))
+	assert.Equal(t, 422, patchAlumni(repo, id.Hex(), `{"user_id":"abc"}`))
+	assert.Equal(t, 422, patchAlumni(repo, id.Hex(), `{"email":null}`))
+	assert.Equal(t, 422, patchAlumni(repo, id.Hex(), `{"angkatan":2025}`))
+	assert.Equal(t, 404, patchAlumni(repo, primitive.NewObjectID().Hex(), `{"nama":"X"}`))
+	assert.False(t, patched)
+}
@@ -19,7 +19,7 @@ import (
 func setupTestApp(repo *mocks.AlumniRepositoryMock) *fiber.App {
     app := fiber.New()
 
-    alumniService := service.NewAlumniService(repo)
+    alumniService := service.NewAlumniService(repo, &mocks.PekerjaanRepositoryMock{})
 
     app.Use(func(c *fiber.Ctx) error {
         c.Locals("user", map[string]interface{}{
@@ -41,6 +41,7 @@ func setupTestApp(repo *mocks.AlumniRepositoryMock) *fiber.App {
     app.Get("/alumni/:id", alumniService.GetByID)
     app.Post("/alumni", alumniService.Create)
     app.Put("/alumni/:id", alumniService.Update)
+    app.Patch("/alumni/:id", alumniService.Patch)
     app.Delete("/alumni/:id", alumniService.SoftDelete)
 
     return app
@@ -53,7 +54,7 @@ func setupTestApp(repo *mocks.AlumniRepositoryMock) *fiber.App {
 // ================================
 func TestGetAll_Error(t *testing.T) {
 	repo := &mocks.AlumniRepositoryMock{
-		GetAllFunc: func() ([]model.Alumni, error) {
+		GetAllFunc: func(q model.AlumniQuery) ([]model.Alumni, error) {
 			return nil, errors.New("db error")
 		},
 	}
@@ -67,7 +68,7 @@ func TestGetAll_Error(t *testing.T) {
 
 func TestGetAll_Success(t *testing.T) {
 	repo := &mocks.AlumniRepositoryMock{
-		GetAllFunc: func() ([]model.Alumni, error) {
+		GetAllFunc: func(q model.AlumniQuery) ([]model.Alumni, error) {
 			return []model.Alumni{}, nil
 		},
 	}
@@ -140,14 +141,14 @@ func TestCreate_MissingFields(t *testing.T) {
 	repo := &mocks.AlumniRepositoryMock{}
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{
+	body, _ := json.Marshal(model.CreateAlumniReq{
 		Email: "mail@mail.com",
 	})
 	req := httptest.NewRequest("POST", "/alumni", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, _ := app.Test(req)
-	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, 422, resp.StatusCode)
 }
 
 func TestCreate_RepoError(t *testing.T) {
@@ -159,7 +160,8 @@ func TestCreate_RepoError(t *testing.T) {
 
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{
+	body, _ := json.Marshal(model.CreateAlumniReq{
+		NIM:   "2020101234",
 		Nama:  "Test",
 		Email: "mail@mail.com",
 	})
@@ -180,7 +182,8 @@ func TestCreate_Success(t *testing.T) {
 
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{
+	body, _ := json.Marshal(model.CreateAlumniReq{
+		NIM:   "2020101234",
 		Nama:  "Test",
 		Email: "mail@mail.com",
 	})
@@ -226,7 +229,7 @@ func TestUpdate_NotFound(t *testing.T) {
 
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{Nama: "New"})
+	body, _ := json.Marshal(model.UpdateAlumniReq{NIM: "2020101234", Nama: "New", Email: "mail@mail.com"})
 	req := httptest.NewRequest("PUT", "/alumni/"+primitive.NewObjectID().Hex(), bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -240,16 +243,17 @@ func TestUpdate_RepoError(t *testing.T) {
 		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) {
 			return &model.Alumni{}, nil
 		},
-		UpdateFunc: func(id primitive.ObjectID, a *model.Alumni) error {
+		UpdateFunc: func(id primitive.ObjectID, a *model.Alumni, version int64) error {
 			return errors.New("update error")
 		},
 	}
 
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{Nama: "New"})
+	body, _ := json.Marshal(model.UpdateAlumniReq{NIM: "2020101234", Nama: "New", Email: "mail@mail.com"})
 	req := httptest.NewRequest("PUT", "/alumni/"+primitive.NewObjectID().Hex(), bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"0"`)
 
 	resp, _ := app.Test(req)
 
@@ -261,16 +265,17 @@ func TestUpdate_Success(t *testing.T) {
 		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) {
 			return &model.Alumni{}, nil
 		},
-		UpdateFunc: func(id primitive.ObjectID, a *model.Alumni) error {
+		UpdateFunc: func(id primitive.ObjectID, a *model.Alumni, version int64) error {
 			return nil
 		},
 	}
 
 	app := setupTestApp(repo)
 
-	body, _ := json.Marshal(model.Alumni{Nama: "New"})
+	body, _ := json.Marshal(model.UpdateAlumniReq{NIM: "2020101234", Nama: "New", Email: "mail@mail.com"})
 	req := httptest.NewRequest("PUT", "/alumni/"+primitive.NewObjectID().Hex(), bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"0"`)
 
 	resp, _ := app.Test(req)
 
@@ -293,13 +298,14 @@ func TestSoftDelete_InvalidID(t *testing.T) {
 
 func TestSoftDelete_RepoError(t *testing.T) {
 	repo := &mocks.AlumniRepositoryMock{
-		SoftDeleteFunc: func(id primitive.ObjectID) error {
+		SoftDeleteFunc: func(id primitive.ObjectID, version int64) error {
 			return errors.New("delete error")
 		},
 	}
 
 	app := setupTestApp(repo)
 	req := httptest.NewRequest("DELETE", "/alumni/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"0"`)
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, 500, resp.StatusCode)
@@ -307,13 +313,14 @@ func TestSoftDelete_RepoError(t *testing.T) {
 
 func TestSoftDelete_Success(t *testing.T) {
 	repo := &mocks.AlumniRepositoryMock{
-		SoftDeleteFunc: func(id primitive.ObjectID) error {
+		SoftDeleteFunc: func(id primitive.ObjectID, version int64) error {
 			return nil
 		},
 	}
 
 	app := setupTestApp(repo)
 	req := httptest.NewRequest("DELETE", "/alumni/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"0"`)
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, 200, resp.StatusCode)
@@ -0,0 +1,42 @@
+package alumni_test
+
+import (
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// ========================== STATUS REPORT LOOKUP ==========================
+func TestAlumniStatusRepository_LookupAcceptsLegacyStringID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("join alumni", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch))
+
+		_, _, err := repository.NewAlumniStatusRepository(mt.DB).GetAlumniByStatus(model.AlumniStatusQuery{Status: "aktif", Limit: 10})
+		assert.NoError(mt, err)
+
+		var cmd struct {
+			Pipeline []bson.M `bson:"pipeline"`
+		}
+		assert.NoError(mt, bson.Unmarshal(mt.GetStartedEvent().Command, &cmd))
+
+		var lookup bson.M
+		for _, stage := range cmd.Pipeline {
+			if l, ok := stage["$lookup"].(bson.M); ok {
+				lookup = l
+			}
+		}
+		if assert.NotNil(mt, lookup) {
+			// pekerjaan dengan alumni_id string tidak hilang dari data maupun counts
+			conv := lookup["let"].(bson.M)["alumni_id"].(bson.M)["$convert"].(bson.M)
+			assert.Equal(mt, "$alumni_id", conv["input"])
+			assert.Equal(mt, "objectId", conv["to"])
+		}
+	})
+}
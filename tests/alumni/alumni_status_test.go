@@ -0,0 +1,86 @@
+package alumni_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStatusApp(repo *mocks.AlumniStatusRepositoryMock) *fiber.App {
+	app := fiber.New()
+	app.Get("/alumni-status", service.NewAlumniStatusService(repo).GetAlumniByStatus)
+	return app
+}
+
+// ========================== STATUS REPORT ==========================
+func TestAlumniStatus_PaginationAndCounts(t *testing.T) {
+	var got model.AlumniStatusQuery
+	repo := &mocks.AlumniStatusRepositoryMock{
+		GetAlumniByStatusFunc: func(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error) {
+			got = q
+			return []model.AlumniPekerjaanReport{{Nama: "Budi", LebihDariSatuTahun: true}}, model.AlumniStatusCounts{Total: 21, LebihDariSatuTahun: 7}, nil
+		},
+	}
+
+	resp, _ := setupStatusApp(repo).Test(httptest.NewRequest("GET", "/alumni-status?status=tidak-aktif&page=3&limit=5", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, model.AlumniStatusQuery{Status: "tidak-aktif", Limit: 5, Offset: 10}, got)
+
+	var body struct {
+		Status string                        `json:"status"`
+		Lebih  int                           `json:"jumlah_bekerja_lebih_dari_satu_tahun"`
+		Data   []model.AlumniPekerjaanReport `json:"data"`
+		Meta   model.MetaInfo                `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "tidak-aktif", body.Status)
+	assert.Equal(t, 7, body.Lebih)
+	assert.Len(t, body.Data, 1)
+	assert.Equal(t, model.MetaInfo{Page: 3, Limit: 5, Total: 21, Pages: 5}, body.Meta)
+}
+
+func TestAlumniStatus_Defaults(t *testing.T) {
+	var got model.AlumniStatusQuery
+	repo := &mocks.AlumniStatusRepositoryMock{
+		GetAlumniByStatusFunc: func(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error) {
+			got = q
+			return []model.AlumniPekerjaanReport{}, model.AlumniStatusCounts{}, nil
+		},
+	}
+
+	resp, _ := setupStatusApp(repo).Test(httptest.NewRequest("GET", "/alumni-status", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, model.AlumniStatusQuery{Status: "aktif", Limit: 10, Offset: 0}, got)
+
+	// limit di atas batas dipotong, bukan dikembalikan ke default
+	resp, _ = setupStatusApp(repo).Test(httptest.NewRequest("GET", "/alumni-status?limit=1000", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, model.AlumniStatusQuery{Status: "aktif", Limit: model.MaxAlumniLimit, Offset: 0}, got)
+}
+
+func TestAlumniStatus_InvalidPagination(t *testing.T) {
+	app := setupStatusApp(&mocks.AlumniStatusRepositoryMock{})
+	for _, url := range []string{"/alumni-status?page=0", "/alumni-status?limit=-1", "/alumni-status?page=satu"} {
+		resp, _ := app.Test(httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, 400, resp.StatusCode, url)
+	}
+}
+
+func TestAlumniStatus_RepoError(t *testing.T) {
+	repo := &mocks.AlumniStatusRepositoryMock{
+		GetAlumniByStatusFunc: func(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error) {
+			return nil, model.AlumniStatusCounts{}, errors.New("db error")
+		},
+	}
+
+	resp, _ := setupStatusApp(repo).Test(httptest.NewRequest("GET", "/alumni-status", nil))
+	assert.Equal(t, 500, resp.StatusCode)
+}
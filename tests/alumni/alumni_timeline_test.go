@@ -0,0 +1,153 @@
+package alumni_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupTimelineApp(alumni *model.Alumni, jobs []model.Pekerjaan) *fiber.App {
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) {
+			if alumni == nil || alumni.ID != id {
+				return nil, nil
+			}
+			return alumni, nil
+		},
+	}
+	pekerjaanRepo := &mocks.PekerjaanRepositoryMock{
+		GetByAlumniIDFunc: func(alumniID primitive.ObjectID, includeDeleted bool) ([]model.Pekerjaan, error) {
+			return jobs, nil
+		},
+	}
+	s := service.NewAlumniService(repo, pekerjaanRepo)
+
+	app := fiber.New()
+	app.Get("/alumni/:id", s.GetByID)
+	app.Get("/alumni/:id/timeline", s.Timeline)
+	return app
+}
+
+func job(mulai, selesai string, status string) model.Pekerjaan {
+	p := model.Pekerjaan{ID: primitive.NewObjectID(), StatusPekerjaan: status}
+	if mulai != "" {
+		t, _ := time.Parse("2006-01-02", mulai)
+		p.TanggalMulaiKerja = &t
+	}
+	if selesai != "" {
+		t, _ := time.Parse("2006-01-02", selesai)
+		p.TanggalSelesaiKerja = &t
+	}
+	return p
+}
+
+// ========================== TIMELINE ==========================
+func TestTimeline_GapsOverlapsAndTotal(t *testing.T) {
+	alumni := &model.Alumni{ID: primitive.NewObjectID(), Nama: "Budi"}
+	a := job("2015-01-01", "2016-01-01", "selesai")
+	b := job("2016-07-01", "2018-01-01", "selesai")
+	c := job("2017-07-01", "2018-07-01", "selesai")
+	undated := job("", "", "aktif")
+
+	app := setupTimelineApp(alumni, []model.Pekerjaan{c, undated, a, b})
+	resp, _ := app.Test(httptest.NewRequest("GET", "/alumni/"+alumni.ID.Hex()+"/timeline", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data model.AlumniTimeline `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	tl := body.Data
+
+	assert.Equal(t, alumni.ID, tl.AlumniID)
+	if assert.Len(t, tl.Riwayat, 3) {
+		assert.Equal(t, []primitive.ObjectID{a.ID, b.ID, c.ID}, []primitive.ObjectID{tl.Riwayat[0].ID, tl.Riwayat[1].ID, tl.Riwayat[2].ID})
+		assert.Equal(t, 12, tl.Riwayat[0].DurasiBulan)
+		assert.Equal(t, 18, tl.Riwayat[1].DurasiBulan)
+	}
+	if assert.Len(t, tl.TanpaTanggal, 1) {
+		assert.Equal(t, undated.ID, tl.TanpaTanggal[0].ID)
+	}
+	if assert.Len(t, tl.Jeda, 1) {
+		assert.Equal(t, 6, tl.Jeda[0].Bulan)
+		assert.Equal(t, *a.TanggalSelesaiKerja, tl.Jeda[0].Dari)
+		assert.Equal(t, *b.TanggalMulaiKerja, tl.Jeda[0].Sampai)
+	}
+	if assert.Len(t, tl.TumpangTindih, 1) {
+		assert.Equal(t, [2]primitive.ObjectID{b.ID, c.ID}, tl.TumpangTindih[0].PekerjaanIDs)
+		assert.Equal(t, 6, tl.TumpangTindih[0].Bulan)
+	}
+	// 12 + (2016-07 s/d 2018-07) = 36, bulan tumpang tindih tidak dihitung dua kali
+	assert.Equal(t, 36, tl.TotalBulanBekerja)
+	assert.Nil(t, tl.CurrentJob)
+}
+
+func TestTimeline_EmptyHistory(t *testing.T) {
+	alumni := &model.Alumni{ID: primitive.NewObjectID()}
+	resp, _ := setupTimelineApp(alumni, nil).Test(httptest.NewRequest("GET", "/alumni/"+alumni.ID.Hex()+"/timeline", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, []interface{}{}, body.Data["riwayat"])
+	assert.Equal(t, []interface{}{}, body.Data["jeda"])
+	assert.Equal(t, float64(0), body.Data["total_bulan_bekerja"])
+	assert.Nil(t, body.Data["current_job"])
+}
+
+func TestTimeline_InvalidIDAndNotFound(t *testing.T) {
+	app := setupTimelineApp(nil, nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/alumni/bukan-id/timeline", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/alumni/"+primitive.NewObjectID().Hex()+"/timeline", nil))
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+// ========================== CURRENT JOB ==========================
+func TestGetByID_CurrentJob(t *testing.T) {
+	alumni := &model.Alumni{ID: primitive.NewObjectID(), Nama: "Budi"}
+	lama := job("2020-01-01", "", "aktif")
+	baru := job("2022-01-01", "", "aktif")
+	kontrakHabis := job("2023-01-01", "2023-06-01", "aktif")
+	selesai := job("2024-01-01", "", "selesai")
+
+	app := setupTimelineApp(alumni, []model.Pekerjaan{lama, baru, kontrakHabis, selesai})
+	resp, _ := app.Test(httptest.NewRequest("GET", "/alumni/"+alumni.ID.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data model.AlumniDetail `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "Budi", body.Data.Nama)
+	if assert.NotNil(t, body.Data.CurrentJob) {
+		assert.Equal(t, baru.ID, body.Data.CurrentJob.ID)
+	}
+}
+
+func TestGetByID_NoCurrentJob(t *testing.T) {
+	alumni := &model.Alumni{ID: primitive.NewObjectID()}
+	app := setupTimelineApp(alumni, []model.Pekerjaan{job("2019-01-01", "2020-01-01", "selesai")})
+	resp, _ := app.Test(httptest.NewRequest("GET", "/alumni/"+alumni.ID.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Contains(t, body.Data, "current_job")
+	assert.Nil(t, body.Data["current_job"])
+}
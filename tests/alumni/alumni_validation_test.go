@@ -0,0 +1,125 @@
+package alumni_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func sendAlumni(t *testing.T, repo *mocks.AlumniRepositoryMock, method, url string, v interface{}) (int, map[string]string) {
+	body, _ := json.Marshal(v)
+	req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "*")
+
+	resp, err := setupTestApp(repo).Test(req)
+	if !assert.NoError(t, err) {
+		return 0, nil
+	}
+	var out struct {
+		Errors map[string]string `json:"errors"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out.Errors
+}
+
+// ================================
+// TEST VALIDASI CREATE
+// ================================
+func TestCreate_ValidationErrors(t *testing.T) {
+	var created *model.Alumni
+	repo := &mocks.AlumniRepositoryMock{
+		CreateFunc: func(a *model.Alumni) error {
+			created = a
+			return nil
+		},
+	}
+
+	status, errs := sendAlumni(t, repo, "POST", "/alumni", model.CreateAlumniReq{
+		NIM:        "20A0",
+		Email:      "bukan email",
+		Angkatan:   2022,
+		TahunLulus: 2020,
+		NoTelepon:  "telp",
+	})
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "nim")
+	assert.Contains(t, errs, "nama")
+	assert.Contains(t, errs, "email")
+	assert.Contains(t, errs, "tahun_lulus")
+	assert.Contains(t, errs, "no_telepon")
+	assert.Nil(t, created)
+
+	// field sistem dari client diabaikan
+	status, _ = sendAlumni(t, repo, "POST", "/alumni", map[string]interface{}{
+		"nim":        "2020101234",
+		"nama":       " Budi ",
+		"email":      "Budi@Example.com",
+		"angkatan":   2020,
+		"user_id":    primitive.NewObjectID().Hex(),
+		"deleted_at": "2024-01-01T00:00:00Z",
+	})
+	assert.Equal(t, 200, status)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, "Budi", created.Nama)
+		assert.Equal(t, "budi@example.com", created.Email)
+		assert.True(t, created.UserID.IsZero())
+		assert.Nil(t, created.DeletedAt)
+	}
+}
+
+func TestCreate_DuplicateNIM(t *testing.T) {
+	repo := &mocks.AlumniRepositoryMock{
+		FindByNIMFunc: func(nim string) (*model.Alumni, error) {
+			return &model.Alumni{ID: primitive.NewObjectID(), NIM: nim}, nil
+		},
+	}
+
+	status, errs := sendAlumni(t, repo, "POST", "/alumni", model.CreateAlumniReq{NIM: "2020101234", Nama: "Budi", Email: "budi@example.com"})
+	assert.Equal(t, 422, status)
+	assert.Equal(t, "NIM sudah terdaftar", errs["nim"])
+
+	// race: lolos pengecekan tapi tertahan unique index
+	repo = &mocks.AlumniRepositoryMock{
+		CreateFunc: func(a *model.Alumni) error { return repository.ErrNIMExists },
+	}
+	status, errs = sendAlumni(t, repo, "POST", "/alumni", model.CreateAlumniReq{NIM: "2020101234", Nama: "Budi", Email: "budi@example.com"})
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "nim")
+}
+
+// ================================
+// TEST VALIDASI UPDATE
+// ================================
+func TestUpdate_KeepOwnNIM(t *testing.T) {
+	id := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Alumni, error) {
+			return &model.Alumni{ID: oid}, nil
+		},
+		FindByNIMFunc: func(nim string) (*model.Alumni, error) {
+			if nim == "2020101234" {
+				return &model.Alumni{ID: id, NIM: nim}, nil
+			}
+			return &model.Alumni{ID: other, NIM: nim}, nil
+		},
+	}
+
+	req := model.UpdateAlumniReq{NIM: "2020101234", Nama: "Budi", Email: "budi@example.com"}
+	status, _ := sendAlumni(t, repo, "PUT", "/alumni/"+id.Hex(), req)
+	assert.Equal(t, 200, status)
+
+	req.NIM = "2020109999"
+	status, errs := sendAlumni(t, repo, "PUT", "/alumni/"+id.Hex(), req)
+	assert.Equal(t, 422, status)
+	assert.Equal(t, "NIM sudah terdaftar", errs["nim"])
+}
@@ -0,0 +1,103 @@
+package alumni_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func sendIfMatch(repo *mocks.AlumniRepositoryMock, method, url, ifMatch string, v interface{}) (int, string) {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	resp, _ := setupTestApp(repo).Test(req)
+	return resp.StatusCode, resp.Header.Get("ETag")
+}
+
+// ================================
+// TEST OPTIMISTIC CONCURRENCY (ETag / If-Match)
+// ================================
+func TestVersion_ETagAndIfMatch(t *testing.T) {
+	id := primitive.NewObjectID()
+	current := &model.Alumni{ID: id, NIM: "2020101234", Nama: "Budi", Email: "budi@example.com", Version: 3}
+	var gotVersion int64 = -100
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Alumni, error) {
+			return current, nil
+		},
+		UpdateFunc: func(oid primitive.ObjectID, a *model.Alumni, version int64) error {
+			gotVersion = version
+			current = &model.Alumni{ID: id, NIM: a.NIM, Nama: a.Nama, Email: a.Email, Version: version + 1}
+			return nil
+		},
+	}
+	url := "/alumni/" + id.Hex()
+	req := model.UpdateAlumniReq{NIM: "2020101234", Nama: "Budi S", Email: "budi@example.com"}
+
+	status, etag := sendIfMatch(repo, "GET", url, "", nil)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `"3"`, etag)
+
+	status, _ = sendIfMatch(repo, "PUT", url, "", req)
+	assert.Equal(t, 428, status)
+	status, _ = sendIfMatch(repo, "PUT", url, `"2"`, req)
+	assert.Equal(t, 412, status)
+	status, _ = sendIfMatch(repo, "PUT", url, `W/"3"`, req)
+	assert.Equal(t, 412, status)
+	assert.Equal(t, int64(-100), gotVersion)
+
+	status, etag = sendIfMatch(repo, "PUT", url, `"3"`, req)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, int64(3), gotVersion)
+	assert.Equal(t, `"4"`, etag)
+
+	// ETag lama ditolak setelah data berubah
+	status, _ = sendIfMatch(repo, "PUT", url, `"3"`, req)
+	assert.Equal(t, 412, status)
+}
+
+func TestVersion_ConflictFromRepository(t *testing.T) {
+	id := primitive.NewObjectID()
+	repo := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Alumni, error) {
+			return &model.Alumni{ID: oid, NIM: "2020101234", Nama: "Budi", Email: "budi@example.com", Version: 1}, nil
+		},
+		// ada penulis lain di antara baca & tulis
+		PatchFunc: func(oid primitive.ObjectID, a *model.Alumni, set, unset []string, version int64) error {
+			return repository.ErrVersionConflict
+		},
+		SoftDeleteFunc: func(oid primitive.ObjectID, version int64) error {
+			if version != 1 {
+				return repository.ErrVersionConflict
+			}
+			return repository.ErrNotFound
+		},
+	}
+	url := "/alumni/" + id.Hex()
+
+	status, _ := sendIfMatch(repo, "PATCH", url, `"1"`, map[string]interface{}{"nama": "X"})
+	assert.Equal(t, 412, status)
+	status, _ = sendIfMatch(repo, "PATCH", url, "", map[string]interface{}{"nama": "X"})
+	assert.Equal(t, 428, status)
+
+	status, _ = sendIfMatch(repo, "DELETE", url, "", nil)
+	assert.Equal(t, 428, status)
+	status, _ = sendIfMatch(repo, "DELETE", url, `"7"`, nil)
+	assert.Equal(t, 412, status)
+	status, _ = sendIfMatch(repo, "DELETE", url, `"1"`, nil)
+	assert.Equal(t, 404, status)
+}
@@ -0,0 +1,138 @@
+package analytics_test
+
+import (
+	"testing"
+
+	"praktikum3/app/repository"
+	"praktikum3/app/utils"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// ========================== SALARY PARSING ==========================
+func TestSalaryLowerBound(t *testing.T) {
+	cases := []struct {
+		gaji string
+		want float64
+		ok   bool
+	}{
+		{"Rp3.000.000 - 5.000.000", 3_000_000, true},
+		{"Rp 10.000.000+", 10_000_000, true},
+		{"> Rp15.000.000", 15_000_000, true},
+		{"3.000.000-5.000.000", 3_000_000, true},
+		{"Rp4.500.000,00", 4_500_000, true},
+		{"3-5 juta", 3_000_000, true},
+		{"3,5 - 5 juta", 3_500_000, true},
+		{"< 3 jt", 3_000_000, true},
+		{"5.5 juta", 5_500_000, true},
+		{"500 ribu", 500_000, true},
+		{"", 0, false},
+		{"Dirahasiakan", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := utils.SalaryLowerBound(tc.gaji)
+		assert.Equal(t, tc.ok, ok, tc.gaji)
+		assert.Equal(t, tc.want, got, tc.gaji)
+	}
+}
+
+// ========================== REPOSITORY ==========================
+func lookupStage(mt *mtest.T) bson.M {
+	var cmd struct {
+		Pipeline []bson.M `bson:"pipeline"`
+	}
+	assert.NoError(mt, bson.Unmarshal(mt.GetStartedEvent().Command, &cmd))
+	for _, stage := range cmd.Pipeline {
+		if l, ok := stage["$lookup"].(bson.M); ok {
+			return l
+		}
+	}
+	return nil
+}
+
+func TestAnalyticsRepository_SkipsDeletedAlumni(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	assertAlumniLookup := func(mt *mtest.T) {
+		lookup := lookupStage(mt)
+		if assert.NotNil(mt, lookup) {
+			assert.Equal(mt, "alumni", lookup["from"])
+			match := lookup["pipeline"].(bson.A)[0].(bson.M)["$match"].(bson.M)
+			assert.Contains(mt, match, "deleted_at")
+			assert.Nil(mt, match["deleted_at"])
+		}
+	}
+
+	mt.Run("distribution", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch))
+		_, err := repository.NewAnalyticsRepository(mt.DB).Distribution("bidang_industri")
+		assert.NoError(mt, err)
+		assertAlumniLookup(mt)
+	})
+
+	mt.Run("salary", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch))
+		_, err := repository.NewAnalyticsRepository(mt.DB).SalaryHistogram()
+		assert.NoError(mt, err)
+		assertAlumniLookup(mt)
+	})
+}
+
+func TestSalaryHistogram_SortsByLowerBound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("urut", func(mt *mtest.T) {
+		bucket := func(gaji string, n int) bson.D {
+			return bson.D{{Key: "_id", Value: gaji}, {Key: "jumlah", Value: n}}
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch,
+			bucket("", 1),
+			bucket("Rp10.000.000+", 1),
+			bucket("Rp3.000.000 - 5.000.000", 1),
+			bucket("5-10 juta", 1),
+			bucket("< 3 juta", 1),
+		))
+
+		list, err := repository.NewAnalyticsRepository(mt.DB).SalaryHistogram()
+		assert.NoError(mt, err)
+		var got []string
+		for _, b := range list {
+			got = append(got, b.GajiRange)
+		}
+		assert.Equal(mt, []string{"< 3 juta", "Rp3.000.000 - 5.000.000", "5-10 juta", "Rp10.000.000+", ""}, got)
+	})
+}
+
+func TestAnalyticsRepository_JobsLookupAcceptsLegacyStringID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	// pekerjaan dengan alumni_id string (data lama) tetap terhitung: sisi pekerjaan dikonversi ke ObjectID
+	assertJobsLookup := func(mt *mtest.T) {
+		lookup := lookupStage(mt)
+		if assert.NotNil(mt, lookup) {
+			assert.Equal(mt, "pekerjaan_alumni", lookup["from"])
+			match := lookup["pipeline"].(bson.A)[0].(bson.M)["$match"].(bson.M)
+			eq := match["$expr"].(bson.M)["$eq"].(bson.A)
+			conv := eq[0].(bson.M)["$convert"].(bson.M)
+			assert.Equal(mt, "$alumni_id", conv["input"])
+			assert.Equal(mt, "objectId", conv["to"])
+			assert.Equal(mt, "$$alumni_id", eq[1])
+		}
+	}
+
+	mt.Run("employment rate", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.alumni", mtest.FirstBatch))
+		_, err := repository.NewAnalyticsRepository(mt.DB).EmploymentRate("angkatan")
+		assert.NoError(mt, err)
+		assertJobsLookup(mt)
+	})
+
+	mt.Run("waiting time", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.alumni", mtest.FirstBatch))
+		_, err := repository.NewAnalyticsRepository(mt.DB).WaitingTime("")
+		assert.NoError(mt, err)
+		assertJobsLookup(mt)
+	})
+}
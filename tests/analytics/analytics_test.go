@@ -0,0 +1,141 @@
+package analytics_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestApp(repo *mocks.AnalyticsRepositoryMock) *fiber.App {
+	app := fiber.New()
+	s := service.NewAnalyticsService(repo)
+
+	app.Get("/analytics/employment-rate", s.EmploymentRate)
+	app.Get("/analytics/waiting-time", s.WaitingTime)
+	app.Get("/analytics/distribution/:field", s.Distribution)
+	app.Get("/analytics/salary", s.SalaryHistogram)
+	return app
+}
+
+// ========================== EMPLOYMENT RATE ==========================
+func TestEmploymentRate_GroupBy(t *testing.T) {
+	var got string
+	repo := &mocks.AnalyticsRepositoryMock{
+		EmploymentRateFunc: func(groupBy string) ([]model.EmploymentRate, error) {
+			got = groupBy
+			return []model.EmploymentRate{{Kelompok: 2020, JumlahAlumni: 4, Bekerja: 3, PernahBekerja: 4, Persentase: 75}}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/analytics/employment-rate?group_by=angkatan", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "angkatan", got)
+
+	var body struct {
+		GroupBy string                 `json:"group_by"`
+		Data    []model.EmploymentRate `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "angkatan", body.GroupBy)
+	assert.Equal(t, 75.0, body.Data[0].Persentase)
+}
+
+func TestEmploymentRate_Overall(t *testing.T) {
+	got := "-"
+	repo := &mocks.AnalyticsRepositoryMock{
+		EmploymentRateFunc: func(groupBy string) ([]model.EmploymentRate, error) {
+			got = groupBy
+			return []model.EmploymentRate{}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/analytics/employment-rate", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", got)
+}
+
+func TestAnalytics_InvalidGroupBy(t *testing.T) {
+	app := setupTestApp(&mocks.AnalyticsRepositoryMock{})
+
+	for _, url := range []string{
+		"/analytics/employment-rate?group_by=password",
+		"/analytics/waiting-time?group_by=$nama",
+	} {
+		resp, _ := app.Test(httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, 400, resp.StatusCode, url)
+	}
+}
+
+// ========================== WAITING TIME ==========================
+func TestWaitingTime_Success(t *testing.T) {
+	repo := &mocks.AnalyticsRepositoryMock{
+		WaitingTimeFunc: func(groupBy string) ([]model.WaitingTime, error) {
+			assert.Equal(t, "jurusan", groupBy)
+			return []model.WaitingTime{{Kelompok: "Teknik Informatika", JumlahAlumni: 10, RataRataBulan: 4.5, MinBulan: 0, MaxBulan: 12}}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/analytics/waiting-time?group_by=jurusan", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestWaitingTime_RepoError(t *testing.T) {
+	repo := &mocks.AnalyticsRepositoryMock{
+		WaitingTimeFunc: func(groupBy string) ([]model.WaitingTime, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/analytics/waiting-time", nil))
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+// ========================== DISTRIBUTION ==========================
+func TestDistribution_Fields(t *testing.T) {
+	var got []string
+	repo := &mocks.AnalyticsRepositoryMock{
+		DistributionFunc: func(field string) ([]model.DistributionItem, error) {
+			got = append(got, strings.Clone(field)) // params fiber hanya valid selama request
+
+			return []model.DistributionItem{}, nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	for _, field := range model.AnalyticsDistributionFields {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/analytics/distribution/"+field, nil))
+		assert.Equal(t, 200, resp.StatusCode, field)
+	}
+	assert.Equal(t, model.AnalyticsDistributionFields, got)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/analytics/distribution/gaji_range", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+// ========================== SALARY ==========================
+func TestSalaryHistogram_Success(t *testing.T) {
+	repo := &mocks.AnalyticsRepositoryMock{
+		SalaryHistogramFunc: func() ([]model.SalaryBucket, error) {
+			return []model.SalaryBucket{{GajiRange: "3-5 juta", Jumlah: 2, Persentase: 40}, {GajiRange: "5-10 juta", Jumlah: 3, Persentase: 60}}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/analytics/salary", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data []model.SalaryBucket `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, "3-5 juta", body.Data[0].GajiRange)
+}
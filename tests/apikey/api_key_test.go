@@ -0,0 +1,205 @@
+package apikey_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/middleware"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const rawKey = "ak_0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// keyStore menyimpan satu API key di memori, dicari berdasarkan hash
+func keyStore(key *model.APIKey, touched *int) *mocks.APIKeyRepositoryMock {
+	return &mocks.APIKeyRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, keyHash string) (*model.APIKey, error) {
+			if keyHash == key.KeyHash {
+				return key, nil
+			}
+			return nil, nil
+		},
+		TouchLastUsedFunc: func(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+			*touched++
+			return nil
+		},
+	}
+}
+
+func setupMiddlewareApp(t *testing.T, repo *mocks.APIKeyRepositoryMock) *fiber.App {
+	middleware.SetAPIKeyRepository(repo)
+	t.Cleanup(func() { middleware.SetAPIKeyRepository(nil) })
+
+	app := fiber.New()
+	app.Get("/alumni", middleware.AuthRequired(), middleware.Require(model.PermAlumniRead), func(c *fiber.Ctx) error {
+		return c.JSON(c.Locals("user"))
+	})
+	app.Delete("/alumni", middleware.AuthRequired(), middleware.Require(model.PermAlumniDelete), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	return app
+}
+
+// ===========================
+// API KEY: AUTENTIKASI DI MIDDLEWARE
+// ===========================
+func TestAPIKey_Middleware(t *testing.T) {
+	key := &model.APIKey{
+		ID:          primitive.NewObjectID(),
+		Name:        "data-warehouse",
+		KeyHash:     utils.HashToken(rawKey),
+		Permissions: []string{model.PermAlumniRead, model.PermPekerjaanRead},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	touched := 0
+	app := setupMiddlewareApp(t, keyStore(key, &touched))
+
+	call := func(method, apiKey string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, "/alumni", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		resp, _ := app.Test(req)
+		var body map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+
+	status, principal := call("GET", rawKey)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, model.PrincipalService, principal["role"])
+	assert.Equal(t, "data-warehouse", principal["username"])
+	assert.Equal(t, key.ID.Hex(), principal["api_key_id"])
+	assert.Equal(t, 1, touched)
+
+	// permission dibatasi sesuai scope key
+	status, _ = call("DELETE", rawKey)
+	assert.Equal(t, 403, status)
+
+	status, _ = call("GET", "ak_salah")
+	assert.Equal(t, 401, status)
+	status, _ = call("GET", "bukan-api-key")
+	assert.Equal(t, 401, status)
+
+	key.ExpiresAt = time.Now().Add(-time.Minute)
+	status, _ = call("GET", rawKey)
+	assert.Equal(t, 401, status, "key expired ditolak")
+
+	key.ExpiresAt = time.Now().Add(time.Hour)
+	now := time.Now()
+	key.RevokedAt = &now
+	status, _ = call("GET", rawKey)
+	assert.Equal(t, 401, status, "key dicabut ditolak")
+}
+
+func setupServiceApp(repo *mocks.APIKeyRepositoryMock, perms []string) *fiber.App {
+	app := fiber.New()
+	s := service.NewAPIKeyService(repo)
+
+	userID := primitive.NewObjectID()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: userID.Hex(), Role: model.RoleAdmin})
+		c.Locals("permissions", perms)
+		return c.Next()
+	})
+	app.Get("/api-keys", s.GetAll)
+	app.Post("/api-keys", s.Create)
+	app.Delete("/api-keys/:id", s.Revoke)
+	return app
+}
+
+func send(app *fiber.App, method, url string, v interface{}) (int, map[string]interface{}) {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	var out map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+// ===========================
+// API KEY: BUAT KEY
+// ===========================
+func TestAPIKey_Create(t *testing.T) {
+	var created *model.APIKey
+	repo := &mocks.APIKeyRepositoryMock{
+		CreateFunc: func(ctx context.Context, key *model.APIKey) error {
+			created = key
+			return nil
+		},
+	}
+	app := setupServiceApp(repo, model.AllPermissions())
+
+	status, body := send(app, "POST", "/api-keys", model.CreateAPIKeyRequest{
+		Name:        "data-warehouse",
+		Permissions: []string{model.PermAlumniRead, model.PermPekerjaanRead, model.PermAlumniRead},
+	})
+	assert.Equal(t, 201, status)
+	raw := body["data"].(map[string]interface{})["key"].(string)
+	assert.True(t, strings.HasPrefix(raw, "ak_"))
+
+	// hanya hash yang disimpan
+	assert.Equal(t, utils.HashToken(raw), created.KeyHash)
+	assert.Equal(t, raw[:11], created.Prefix)
+	assert.Equal(t, []string{model.PermAlumniRead, model.PermPekerjaanRead}, created.Permissions)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, model.DefaultAPIKeyDays), created.ExpiresAt, time.Minute)
+	assert.NotContains(t, body["data"].(map[string]interface{})["api_key"], "key_hash")
+}
+
+func TestAPIKey_CreateValidation(t *testing.T) {
+	app := setupServiceApp(&mocks.APIKeyRepositoryMock{}, model.AllPermissions())
+
+	cases := []model.CreateAPIKeyRequest{
+		{Permissions: []string{model.PermAlumniRead}},
+		{Name: "dw"},
+		{Name: "dw", Permissions: []string{"alumni:fly"}},
+		{Name: "dw", Permissions: []string{model.PermAPIKeysManage}},
+		{Name: "dw", Permissions: []string{model.PermAlumniRead}, ExpiresInDays: 366},
+		{Name: "dw", Permissions: []string{model.PermAlumniRead}, ExpiresInDays: -1},
+	}
+	for _, req := range cases {
+		status, _ := send(app, "POST", "/api-keys", req)
+		assert.Equal(t, 400, status, "%+v", req)
+	}
+
+	// tidak bisa memberi permission melebihi milik sendiri
+	limited := setupServiceApp(&mocks.APIKeyRepositoryMock{}, []string{model.PermAPIKeysManage, model.PermAlumniRead})
+	status, _ := send(limited, "POST", "/api-keys", model.CreateAPIKeyRequest{Name: "dw", Permissions: []string{model.PermAlumniRead}})
+	assert.Equal(t, 201, status)
+	status, _ = send(limited, "POST", "/api-keys", model.CreateAPIKeyRequest{Name: "dw", Permissions: []string{model.PermUsersManage}})
+	assert.Equal(t, 403, status)
+}
+
+// ===========================
+// API KEY: CABUT KEY
+// ===========================
+func TestAPIKey_Revoke(t *testing.T) {
+	id := primitive.NewObjectID()
+	repo := &mocks.APIKeyRepositoryMock{
+		RevokeFunc: func(ctx context.Context, got primitive.ObjectID) (bool, error) {
+			return got == id, nil
+		},
+	}
+	app := setupServiceApp(repo, model.AllPermissions())
+
+	status, _ := send(app, "DELETE", "/api-keys/"+id.Hex(), nil)
+	assert.Equal(t, 200, status)
+	status, _ = send(app, "DELETE", "/api-keys/"+primitive.NewObjectID().Hex(), nil)
+	assert.Equal(t, 404, status)
+	status, _ = send(app, "DELETE", "/api-keys/bukan-id", nil)
+	assert.Equal(t, 400, status)
+}
@@ -18,9 +18,16 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+func newAuthService(repo *mocks.UserRepositoryMock, pw mocks.PasswordCheckerMock, tg mocks.TokenGeneratorMock) *service.AuthService {
+	return service.NewAuthServiceMock(repo, &mocks.UserTokenRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), pw, tg, &mocks.EmailSenderMock{})
+}
+
 func setupTestApp(authSvc *service.AuthService) *fiber.App {
 	app := fiber.New()
 	app.Post("/login", authSvc.Login)
+	app.Post("/refresh", authSvc.Refresh)
+	app.Post("/register", authSvc.Register)
+	app.Get("/verify-email", authSvc.VerifyEmail)
 	return app
 }
 
@@ -32,7 +39,7 @@ func TestLogin_InvalidBody(t *testing.T) {
 	mockPw := mocks.PasswordCheckerMock{}
 	mockToken := mocks.TokenGeneratorMock{}
 
-	authSvc := service.NewAuthServiceMock(mockRepo, mockPw, mockToken)
+	authSvc := newAuthService(mockRepo, mockPw, mockToken)
 	app := setupTestApp(authSvc)
 
 	req := httptest.NewRequest("POST", "/login", bytes.NewBufferString("NOT_JSON"))
@@ -56,7 +63,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockPw := mocks.PasswordCheckerMock{}
 	mockToken := mocks.TokenGeneratorMock{}
 
-	authSvc := service.NewAuthServiceMock(mockRepo, mockPw, mockToken)
+	authSvc := newAuthService(mockRepo, mockPw, mockToken)
 	app := setupTestApp(authSvc)
 
 	body, _ := json.Marshal(model.LoginRequest{Username: "user", Password: "123"})
@@ -93,7 +100,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	mockToken := mocks.TokenGeneratorMock{}
 
-	authSvc := service.NewAuthServiceMock(mockRepo, mockPw, mockToken)
+	authSvc := newAuthService(mockRepo, mockPw, mockToken)
 	app := setupTestApp(authSvc)
 
 	body, _ := json.Marshal(model.LoginRequest{Username: "user", Password: "wrong"})
@@ -129,12 +136,12 @@ func TestLogin_TokenError(t *testing.T) {
 	}
 
 	mockToken := mocks.TokenGeneratorMock{
-		GenerateFunc: func(user model.User) (string, error) {
+		GenerateFunc: func(user model.User, sessionID string) (string, error) {
 			return "", errors.New("token error")
 		},
 	}
 
-	authSvc := service.NewAuthServiceMock(mockRepo, mockPw, mockToken)
+	authSvc := newAuthService(mockRepo, mockPw, mockToken)
 	app := setupTestApp(authSvc)
 
 	body, _ := json.Marshal(model.LoginRequest{Username: "user", Password: "123"})
@@ -170,12 +177,12 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	mockToken := mocks.TokenGeneratorMock{
-		GenerateFunc: func(user model.User) (string, error) {
+		GenerateFunc: func(user model.User, sessionID string) (string, error) {
 			return "TOKEN123", nil
 		},
 	}
 
-	authSvc := service.NewAuthServiceMock(mockRepo, mockPw, mockToken)
+	authSvc := newAuthService(mockRepo, mockPw, mockToken)
 	app := setupTestApp(authSvc)
 
 	body, _ := json.Marshal(model.LoginRequest{Username: "user", Password: "123"})
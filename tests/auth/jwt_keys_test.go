@@ -0,0 +1,168 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// useJWTEnv mengganti konfigurasi key JWT untuk satu test lalu me-load ulang
+func useJWTEnv(t *testing.T, env map[string]string) error {
+	for _, k := range []string{"JWT_ALG", "JWT_SECRET", "JWT_KID", "JWT_PRIVATE_KEY_FILE", "JWT_VERIFY_KEY_FILES"} {
+		t.Setenv(k, env[k])
+	}
+	t.Cleanup(func() {
+		os.Setenv("JWT_SECRET", "test-secret")
+		_ = utils.LoadKeys()
+	})
+	return utils.LoadKeys()
+}
+
+func writePEM(t *testing.T, typ string, der []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600))
+	return path
+}
+
+func newEd25519Key(t *testing.T) (privPath, pubPath string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privDER, _ := x509.MarshalPKCS8PrivateKey(priv)
+	pubDER, _ := x509.MarshalPKIXPublicKey(pub)
+	return writePEM(t, "PRIVATE KEY", privDER), writePEM(t, "PUBLIC KEY", pubDER)
+}
+
+func headerOf(t *testing.T, token string) map[string]interface{} {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &model.JWTClaims{})
+	require.NoError(t, err)
+	return parsed.Header
+}
+
+var jwtUser = model.User{ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser}
+
+// ===========================
+// JWT: HS256 TETAP JALAN LEWAT KONFIGURASI
+// ===========================
+func TestJWT_HS256(t *testing.T) {
+	assert.Error(t, useJWTEnv(t, map[string]string{"JWT_ALG": "HS256"}), "secret kosong harus ditolak")
+	require.NoError(t, useJWTEnv(t, map[string]string{"JWT_SECRET": "rahasia", "JWT_KID": "hs-1"}))
+
+	token, err := utils.GenerateToken(jwtUser)
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", headerOf(t, token)["alg"])
+	assert.Equal(t, "hs-1", headerOf(t, token)["kid"])
+
+	claims, err := utils.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, jwtUser.ID.Hex(), claims.UserID)
+
+	// token lama tanpa kid tetap diterima
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, &model.JWTClaims{
+		UserID:           jwtUser.ID.Hex(),
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	legacyStr, _ := legacy.SignedString([]byte("rahasia"))
+	_, err = utils.ValidateToken(legacyStr)
+	assert.NoError(t, err)
+
+	// secret HS256 tidak pernah dipublikasikan
+	keys, err := utils.PublicJWKS()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+// ===========================
+// JWT: RS256 + JWKS
+// ===========================
+func TestJWT_RS256AndJWKS(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(priv))
+	require.NoError(t, useJWTEnv(t, map[string]string{"JWT_ALG": "RS256", "JWT_PRIVATE_KEY_FILE": path}))
+
+	token, err := utils.GenerateToken(jwtUser)
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", headerOf(t, token)["alg"])
+
+	_, err = utils.ValidateToken(token)
+	assert.NoError(t, err)
+
+	keys, err := utils.PublicJWKS()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "RSA", keys[0].Kty)
+	assert.Equal(t, headerOf(t, token)["kid"], keys[0].Kid)
+
+	// token juga bisa diverifikasi pihak lain hanya dengan public key
+	_, err = jwt.ParseWithClaims(token, &model.JWTClaims{}, func(*jwt.Token) (interface{}, error) {
+		return &priv.PublicKey, nil
+	})
+	assert.NoError(t, err)
+
+	// alg confusion: HS256 yang "ditandatangani" dengan public key ditolak
+	pubDER := x509.MarshalPKCS1PublicKey(&priv.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &model.JWTClaims{UserID: jwtUser.ID.Hex()})
+	forged.Header["kid"] = keys[0].Kid
+	forgedStr, _ := forged.SignedString(pubDER)
+	_, err = utils.ValidateToken(forgedStr)
+	assert.Error(t, err)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", service.JWKS)
+	resp, _ := app.Test(httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+// ===========================
+// JWT: ROTASI KEY EdDSA
+// ===========================
+func TestJWT_EdDSAKeyRotation(t *testing.T) {
+	oldPriv, oldPub := newEd25519Key(t)
+	newPriv, _ := newEd25519Key(t)
+
+	require.NoError(t, useJWTEnv(t, map[string]string{"JWT_ALG": "EdDSA", "JWT_PRIVATE_KEY_FILE": oldPriv}))
+	oldToken, err := utils.GenerateToken(jwtUser)
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", headerOf(t, oldToken)["alg"])
+
+	// key baru aktif, key lama masih diterima untuk verifikasi
+	require.NoError(t, useJWTEnv(t, map[string]string{"JWT_ALG": "EdDSA", "JWT_PRIVATE_KEY_FILE": newPriv, "JWT_VERIFY_KEY_FILES": oldPub}))
+	newToken, err := utils.GenerateToken(jwtUser)
+	require.NoError(t, err)
+	assert.NotEqual(t, headerOf(t, oldToken)["kid"], headerOf(t, newToken)["kid"])
+
+	_, err = utils.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	_, err = utils.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	keys, err := utils.PublicJWKS()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, headerOf(t, newToken)["kid"], keys[0].Kid, "key aktif tampil pertama")
+
+	// key lama dipensiunkan -> token lama ditolak
+	require.NoError(t, useJWTEnv(t, map[string]string{"JWT_ALG": "EdDSA", "JWT_PRIVATE_KEY_FILE": newPriv}))
+	_, err = utils.ValidateToken(oldToken)
+	assert.Error(t, err)
+
+	// private key tidak cocok dengan JWT_ALG
+	assert.Error(t, useJWTEnv(t, map[string]string{"JWT_ALG": "RS256", "JWT_PRIVATE_KEY_FILE": newPriv}))
+}
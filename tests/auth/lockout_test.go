@@ -0,0 +1,260 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// attemptStore: penyimpanan counter login gagal in-memory untuk test
+type attemptStore struct {
+	mu   sync.Mutex
+	docs map[string]*model.LoginAttempt
+}
+
+func newAttemptStore() *attemptStore {
+	return &attemptStore{docs: map[string]*model.LoginAttempt{}}
+}
+
+func (s *attemptStore) mock() *mocks.LoginAttemptRepositoryMock {
+	return &mocks.LoginAttemptRepositoryMock{
+		GetFunc: func(ctx context.Context, key string) (*model.LoginAttempt, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if a, ok := s.docs[key]; ok {
+				cp := *a
+				return &cp, nil
+			}
+			return nil, nil
+		},
+		RegisterFailureFunc: func(ctx context.Context, kind, subject string) (*model.LoginAttempt, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			key := repository.AttemptKey(kind, subject)
+			a, ok := s.docs[key]
+			if !ok {
+				a = &model.LoginAttempt{Key: key, Kind: kind, Subject: subject}
+				s.docs[key] = a
+			}
+			a.Failures++
+			a.LastFailureAt = time.Now()
+			cp := *a
+			return &cp, nil
+		},
+		SetLockedUntilFunc: func(ctx context.Context, key string, until time.Time) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if a, ok := s.docs[key]; ok {
+				a.LockedUntil = &until
+			}
+			return nil
+		},
+		ResetFunc: func(ctx context.Context, key string) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.docs, key)
+			return nil
+		},
+	}
+}
+
+func (s *attemptStore) failures(kind, subject string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.docs[repository.AttemptKey(kind, subject)]; ok {
+		return a.Failures
+	}
+	return 0
+}
+
+var budiID = primitive.NewObjectID()
+
+func setupLockoutApp(store *attemptStore) *fiber.App {
+	repo := &mocks.UserRepositoryMock{
+		FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) {
+			if username != "budi" && username != "budi@example.com" {
+				return nil, nil
+			}
+			return &model.User{ID: budiID, Username: "budi", Email: "budi@example.com", PasswordHash: "benar", Role: model.RoleUser, Status: model.UserStatusActive}, nil
+		},
+	}
+	pw := mocks.PasswordCheckerMock{
+		CheckFunc: func(hash, password string) bool {
+			return hash == password
+		},
+	}
+	tg := mocks.TokenGeneratorMock{
+		GenerateFunc: func(user model.User, sessionID string) (string, error) { return "TOKEN", nil },
+	}
+
+	authSvc := service.NewAuthServiceMock(repo, &mocks.UserTokenRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{},
+		&mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{}, service.NewLockoutService(store.mock()), pw, tg, &mocks.EmailSenderMock{})
+	return setupTestApp(authSvc)
+}
+
+func login(app *fiber.App, username, password string) (int, string, map[string]interface{}) {
+	body, _ := json.Marshal(model.LoginRequest{Username: username, Password: password})
+	req := httptest.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	raw, _ := io.ReadAll(resp.Body)
+	var out map[string]interface{}
+	_ = json.Unmarshal(raw, &out)
+	return resp.StatusCode, resp.Header.Get("Retry-After"), out
+}
+
+// ===========================
+// LOCKOUT: SETELAH 5X GAGAL, PASSWORD BENAR PUN DITOLAK 423
+// ===========================
+func TestLogin_LocksAccountAfterRepeatedFailures(t *testing.T) {
+	store := newAttemptStore()
+	app := setupLockoutApp(store)
+
+	for i := 0; i < 5; i++ {
+		status, _, _ := login(app, "budi", "salah")
+		assert.Equal(t, 401, status)
+	}
+
+	status, retryAfter, body := login(app, "budi", "benar")
+	assert.Equal(t, 423, status)
+	assert.NotEmpty(t, retryAfter)
+	assert.Equal(t, false, body["success"])
+
+	// counter milik akun, bukan identifier: login lewat email ikut terkunci
+	status, _, _ = login(app, "budi@example.com", "benar")
+	assert.Equal(t, 423, status)
+}
+
+// ===========================
+// LOCKOUT: USERNAME & EMAIL BERBAGI SATU COUNTER PER AKUN
+// ===========================
+func TestLogin_CounterSharedAcrossIdentifiers(t *testing.T) {
+	store := newAttemptStore()
+	app := setupLockoutApp(store)
+
+	for i := 0; i < 3; i++ {
+		login(app, "budi", "salah")
+	}
+	for i := 0; i < 2; i++ {
+		login(app, "budi@example.com", "salah")
+	}
+	assert.Equal(t, 5, store.failures(model.AttemptKindAccount, budiID.Hex()))
+	assert.Equal(t, 0, store.failures(model.AttemptKindUser, "budi"))
+	assert.Equal(t, 0, store.failures(model.AttemptKindUser, "budi@example.com"))
+
+	status, _, _ := login(app, "budi", "benar")
+	assert.Equal(t, 423, status)
+}
+
+// ===========================
+// LOCKOUT: USERNAME TIDAK DIKENAL DIPERLAKUKAN SAMA (TIDAK BOCOR)
+// ===========================
+func TestLogin_UnknownUserIndistinguishable(t *testing.T) {
+	store := newAttemptStore()
+	app := setupLockoutApp(store)
+
+	statusKnown, _, bodyKnown := login(app, "budi", "salah")
+	statusUnknown, _, bodyUnknown := login(app, "hantu", "salah")
+	assert.Equal(t, statusKnown, statusUnknown)
+	assert.Equal(t, bodyKnown, bodyUnknown)
+
+	for i := 0; i < 4; i++ {
+		login(app, "hantu", "salah")
+	}
+	status, _, _ := login(app, "hantu", "apapun")
+	assert.Equal(t, 423, status)
+
+	// identifier dinormalisasi: variasi huruf besar / spasi tetap terkunci
+	status, _, _ = login(app, "  HANTU ", "apapun")
+	assert.Equal(t, 423, status)
+}
+
+// ===========================
+// LOCKOUT: LOGIN SUKSES MERESET COUNTER AKUN
+// ===========================
+func TestLogin_SuccessResetsUserCounter(t *testing.T) {
+	store := newAttemptStore()
+	app := setupLockoutApp(store)
+
+	for i := 0; i < 4; i++ {
+		login(app, "budi", "salah")
+	}
+	assert.Equal(t, 4, store.failures(model.AttemptKindAccount, budiID.Hex()))
+
+	status, _, _ := login(app, "budi", "benar")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, 0, store.failures(model.AttemptKindAccount, budiID.Hex()))
+
+	// counter IP tidak ikut direset
+	assert.Equal(t, 4, store.failures(model.AttemptKindIP, "0.0.0.0"))
+}
+
+// ===========================
+// LOCKOUT: IP DIKUNCI SETELAH 20X GAGAL LINTAS AKUN
+// ===========================
+func TestLogin_LocksIPAcrossAccounts(t *testing.T) {
+	store := newAttemptStore()
+	app := setupLockoutApp(store)
+
+	for i := 0; i < 20; i++ {
+		login(app, "user"+string(rune('a'+i)), "salah")
+	}
+
+	status, _, _ := login(app, "budi", "benar")
+	assert.Equal(t, 423, status)
+}
+
+// ===========================
+// ADMIN: LIHAT & BUKA LOCKOUT
+// ===========================
+func TestLockout_AdminEndpoints(t *testing.T) {
+	var resetKey string
+	until := time.Now().Add(time.Minute)
+	repo := &mocks.LoginAttemptRepositoryMock{
+		ListLockedFunc: func(ctx context.Context) ([]model.LoginAttempt, error) {
+			return []model.LoginAttempt{{Key: "user:budi", Kind: model.AttemptKindUser, Subject: "budi", Failures: 5, LockedUntil: &until}}, nil
+		},
+		ResetFunc: func(ctx context.Context, key string) error {
+			resetKey = key
+			return nil
+		},
+	}
+	l := service.NewLockoutService(repo)
+
+	app := fiber.New()
+	app.Get("/lockouts", l.GetLocked)
+	app.Delete("/lockouts/:kind/:subject", l.Clear)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/lockouts", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/lockouts/user/Budi", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, repository.AttemptKey(model.AttemptKindUser, "budi"), resetKey)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/lockouts/account/"+budiID.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, repository.AttemptKey(model.AttemptKindAccount, budiID.Hex()), resetKey)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/lockouts/ip/10.0.0.1", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, repository.AttemptKey(model.AttemptKindIP, "10.0.0.1"), resetKey)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/lockouts/unknown/x", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
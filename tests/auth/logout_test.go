@@ -0,0 +1,134 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupLogoutApp(authSvc *service.AuthService, claims *model.JWTClaims) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.Locals("claims", claims)
+		}
+		return c.Next()
+	})
+	app.Post("/logout", authSvc.Logout)
+	return app
+}
+
+func logoutRequest(app *fiber.App, body interface{}) int {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest("POST", "/logout", &buf)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ===========================
+// LOGOUT: JTI MASUK REVOCATION LIST
+// ===========================
+func TestLogout_RevokesTokenAndRefreshFamily(t *testing.T) {
+	userID := primitive.NewObjectID()
+	familyID := primitive.NewObjectID()
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	claims := &model.JWTClaims{
+		UserID: userID.Hex(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-123",
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+
+	var revokedJTI string
+	var revokedUntil time.Time
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeFunc: func(ctx context.Context, jti string, uid primitive.ObjectID, expiresAt time.Time) error {
+			assert.Equal(t, userID, uid)
+			revokedJTI, revokedUntil = jti, expiresAt
+			return nil
+		},
+	}
+
+	var revokedFamily primitive.ObjectID
+	refreshRepo := &mocks.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+			assert.Equal(t, utils.HashToken("REFRESH"), tokenHash)
+			return &model.RefreshToken{UserID: userID, FamilyID: familyID}, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, id primitive.ObjectID) error {
+			revokedFamily = id
+			return nil
+		},
+	}
+
+	authSvc := service.NewAuthServiceMock(&mocks.UserRepositoryMock{}, &mocks.UserTokenRepositoryMock{}, refreshRepo, revocations,
+		&mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}, &mocks.EmailSenderMock{})
+	app := setupLogoutApp(authSvc, claims)
+
+	assert.Equal(t, 200, logoutRequest(app, model.LogoutRequest{RefreshToken: "REFRESH"}))
+	assert.Equal(t, "jti-123", revokedJTI)
+	assert.True(t, exp.Equal(revokedUntil))
+	assert.Equal(t, familyID, revokedFamily)
+}
+
+// ===========================
+// LOGOUT: REFRESH TOKEN MILIK USER LAIN TIDAK DICABUT
+// ===========================
+func TestLogout_IgnoresForeignRefreshToken(t *testing.T) {
+	claims := &model.JWTClaims{
+		UserID:           primitive.NewObjectID().Hex(),
+		RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"},
+	}
+	refreshRepo := &mocks.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{UserID: primitive.NewObjectID(), FamilyID: primitive.NewObjectID()}, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, id primitive.ObjectID) error {
+			t.Fatal("family milik user lain tidak boleh dicabut")
+			return nil
+		},
+	}
+
+	authSvc := service.NewAuthServiceMock(&mocks.UserRepositoryMock{}, &mocks.UserTokenRepositoryMock{}, refreshRepo, &mocks.TokenRevocationRepositoryMock{},
+		&mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}, &mocks.EmailSenderMock{})
+
+	assert.Equal(t, 200, logoutRequest(setupLogoutApp(authSvc, claims), model.LogoutRequest{RefreshToken: "X"}))
+}
+
+// ===========================
+// LOGOUT: TANPA CLAIMS / ERROR DB
+// ===========================
+func TestLogout_Errors(t *testing.T) {
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeFunc: func(ctx context.Context, jti string, uid primitive.ObjectID, expiresAt time.Time) error {
+			return errors.New("db error")
+		},
+	}
+	authSvc := service.NewAuthServiceMock(&mocks.UserRepositoryMock{}, &mocks.UserTokenRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{}, revocations,
+		&mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}, &mocks.EmailSenderMock{})
+
+	assert.Equal(t, 401, logoutRequest(setupLogoutApp(authSvc, nil), nil))
+
+	claims := &model.JWTClaims{UserID: primitive.NewObjectID().Hex(), RegisteredClaims: jwt.RegisteredClaims{ID: "jti"}}
+	assert.Equal(t, 500, logoutRequest(setupLogoutApp(authSvc, claims), nil))
+}
@@ -0,0 +1,224 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type passwordDeps struct {
+	user        *model.User
+	newHash     string
+	tokens      map[string]*model.UserToken
+	outbox      *mocks.EmailSenderMock
+	refreshUser primitive.ObjectID
+	cutoffUser  primitive.ObjectID
+	updateErr   error // error dari UpdatePassword (simulasi database bermasalah)
+}
+
+func setupPasswordApp(d *passwordDeps) *fiber.App {
+	d.tokens = map[string]*model.UserToken{}
+	d.outbox = &mocks.EmailSenderMock{}
+
+	repo := &mocks.UserRepositoryMock{
+		FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) {
+			if username == d.user.Email || username == d.user.Username {
+				return d.user, nil
+			}
+			return nil, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			return d.user, nil
+		},
+		UpdatePasswordFunc: func(ctx context.Context, id primitive.ObjectID, hash string) error {
+			if d.updateErr != nil {
+				return d.updateErr
+			}
+			d.newHash = hash
+			return nil
+		},
+	}
+	tokenRepo := &mocks.UserTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, token *model.UserToken) error {
+			token.ID = primitive.NewObjectID()
+			d.tokens[token.TokenHash] = token
+			return nil
+		},
+		FindValidFunc: func(ctx context.Context, purpose, hash string) (*model.UserToken, error) {
+			t := d.tokens[hash]
+			if t == nil || t.Purpose != purpose || t.UsedAt != nil || time.Now().After(t.ExpiresAt) {
+				return nil, nil
+			}
+			return t, nil
+		},
+		MarkUsedFunc: func(ctx context.Context, id primitive.ObjectID) (bool, error) {
+			for _, t := range d.tokens {
+				if t.ID == id && t.UsedAt == nil {
+					now := time.Now()
+					t.UsedAt = &now
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		ReleaseFunc: func(ctx context.Context, id primitive.ObjectID) error {
+			for _, t := range d.tokens {
+				if t.ID == id {
+					t.UsedAt = nil
+				}
+			}
+			return nil
+		},
+	}
+	refreshRepo := &mocks.RefreshTokenRepositoryMock{
+		RevokeByUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			d.refreshUser = userID
+			return nil
+		},
+	}
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error {
+			d.cutoffUser = userID
+			return nil
+		},
+	}
+	pw := mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return utils.CheckPassword(hash, password) }}
+
+	authSvc := service.NewAuthServiceMock(repo, tokenRepo, refreshRepo, revocations, &mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), pw, mocks.TokenGeneratorMock{}, d.outbox)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: d.user.ID.Hex()})
+		return c.Next()
+	})
+	app.Put("/me/password", authSvc.ChangePassword)
+	app.Post("/forgot-password", authSvc.ForgotPassword)
+	app.Post("/reset-password", authSvc.ResetPassword)
+	return app
+}
+
+func sendJSON(app *fiber.App, method, url string, v interface{}) int {
+	body, _ := json.Marshal(v)
+	req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+func newPasswordUser(t *testing.T, password string) *model.User {
+	hash, err := utils.HashPassword(password)
+	assert.NoError(t, err)
+	return &model.User{ID: primitive.NewObjectID(), Username: "budi", Email: "budi@mail.com", PasswordHash: hash}
+}
+
+// ===========================
+// GANTI PASSWORD
+// ===========================
+func TestChangePassword(t *testing.T) {
+	d := &passwordDeps{user: newPasswordUser(t, "lama12345")}
+	app := setupPasswordApp(d)
+
+	assert.Equal(t, 401, sendJSON(app, "PUT", "/me/password", model.ChangePasswordRequest{OldPassword: "salah", NewPassword: "baru12345"}))
+	assert.Empty(t, d.newHash)
+
+	assert.Equal(t, 400, sendJSON(app, "PUT", "/me/password", model.ChangePasswordRequest{OldPassword: "lama12345", NewPassword: "pendek"}))
+	assert.Equal(t, 400, sendJSON(app, "PUT", "/me/password", model.ChangePasswordRequest{OldPassword: "lama12345", NewPassword: "lama12345"}))
+
+	assert.Equal(t, 200, sendJSON(app, "PUT", "/me/password", model.ChangePasswordRequest{OldPassword: "lama12345", NewPassword: "baru12345"}))
+	assert.True(t, utils.CheckPassword(d.newHash, "baru12345"))
+}
+
+// ===========================
+// LUPA PASSWORD: TIDAK BOCORKAN EMAIL
+// ===========================
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	d := &passwordDeps{user: newPasswordUser(t, "lama12345")}
+	app := setupPasswordApp(d)
+
+	assert.Equal(t, 200, sendJSON(app, "POST", "/forgot-password", model.ForgotPasswordRequest{Email: "tidakada@mail.com"}))
+	assert.Empty(t, d.outbox.Outbox)
+	assert.Empty(t, d.tokens)
+}
+
+// ===========================
+// LUPA -> RESET PASSWORD
+// ===========================
+func TestForgotAndResetPassword(t *testing.T) {
+	d := &passwordDeps{user: newPasswordUser(t, "lama12345")}
+	app := setupPasswordApp(d)
+
+	assert.Equal(t, 200, sendJSON(app, "POST", "/forgot-password", model.ForgotPasswordRequest{Email: "BUDI@mail.com"}))
+	assert.Len(t, d.outbox.Outbox, 1)
+
+	body := d.outbox.Outbox[0].Body
+	token := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+
+	// token disimpan dalam bentuk hash dan punya masa berlaku
+	stored := d.tokens[utils.HashToken(token)]
+	assert.NotNil(t, stored)
+	assert.Equal(t, model.TokenPurposePasswordReset, stored.Purpose)
+	assert.True(t, stored.ExpiresAt.Before(time.Now().Add(2*time.Hour)))
+
+	assert.Equal(t, 400, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: "ngawur", NewPassword: "baru12345"}))
+	assert.Equal(t, 200, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "baru12345"}))
+	assert.True(t, utils.CheckPassword(d.newHash, "baru12345"))
+
+	// semua sesi dicabut
+	assert.Equal(t, d.user.ID, d.refreshUser)
+	assert.Equal(t, d.user.ID, d.cutoffUser)
+
+	// token sekali pakai
+	assert.Equal(t, 400, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "lagi12345"}))
+}
+
+// ===========================
+// RESET: TOKEN EXPIRED
+// ===========================
+func TestResetPassword_Expired(t *testing.T) {
+	d := &passwordDeps{user: newPasswordUser(t, "lama12345")}
+	app := setupPasswordApp(d)
+
+	sendJSON(app, "POST", "/forgot-password", model.ForgotPasswordRequest{Email: "budi@mail.com"})
+	body := d.outbox.Outbox[0].Body
+	token := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+	d.tokens[utils.HashToken(token)].ExpiresAt = time.Now().Add(-time.Minute)
+
+	assert.Equal(t, 400, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "baru12345"}))
+	assert.Empty(t, d.newHash)
+}
+
+// ===========================
+// RESET: GAGAL SIMPAN PASSWORD TIDAK MENGHANGUSKAN TOKEN
+// ===========================
+func TestResetPassword_UpdateFailureKeepsToken(t *testing.T) {
+	d := &passwordDeps{user: newPasswordUser(t, "lama12345")}
+	app := setupPasswordApp(d)
+
+	sendJSON(app, "POST", "/forgot-password", model.ForgotPasswordRequest{Email: "budi@mail.com"})
+	body := d.outbox.Outbox[0].Body
+	token := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+
+	d.updateErr = errors.New("koneksi database terputus")
+	assert.Equal(t, 500, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "baru12345"}))
+	assert.Nil(t, d.tokens[utils.HashToken(token)].UsedAt)
+
+	// token yang sama masih bisa dipakai setelah database pulih
+	d.updateErr = nil
+	assert.Equal(t, 200, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "baru12345"}))
+	assert.True(t, utils.CheckPassword(d.newHash, "baru12345"))
+	assert.Equal(t, 400, sendJSON(app, "POST", "/reset-password", model.ResetPasswordRequest{Token: token, NewPassword: "lagi12345"}))
+}
@@ -0,0 +1,152 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// refreshStore adalah penyimpanan refresh token in-memory untuk test
+type refreshStore struct {
+	byHash  map[string]*model.RefreshToken
+	revoked []primitive.ObjectID
+}
+
+func newRefreshStore() *refreshStore {
+	return &refreshStore{byHash: map[string]*model.RefreshToken{}}
+}
+
+func (st *refreshStore) mock() *mocks.RefreshTokenRepositoryMock {
+	return &mocks.RefreshTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, token *model.RefreshToken) error {
+			st.byHash[token.TokenHash] = token
+			return nil
+		},
+		FindByHashFunc: func(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+			return st.byHash[tokenHash], nil
+		},
+		MarkUsedFunc: func(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error) {
+			for _, t := range st.byHash {
+				if t.ID == id && t.UsedAt == nil && t.RevokedAt == nil {
+					now := time.Now()
+					t.UsedAt = &now
+					t.ReplacedBy = &replacedBy
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, familyID primitive.ObjectID) error {
+			st.revoked = append(st.revoked, familyID)
+			now := time.Now()
+			for _, t := range st.byHash {
+				if t.FamilyID == familyID {
+					t.RevokedAt = &now
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func loginAndDecode(t *testing.T, app *fiber.App, url string, v interface{}) (int, model.LoginResponse) {
+	body, _ := json.Marshal(v)
+	req := httptest.NewRequest("POST", url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	var out model.LoginResponse
+	if resp.StatusCode == 200 {
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	}
+	return resp.StatusCode, out
+}
+
+func setupRefreshApp(st *refreshStore) *fiber.App {
+	user := &model.User{ID: primitive.NewObjectID(), Username: "user", Role: "user"}
+	repo := &mocks.UserRepositoryMock{
+		FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) { return user, nil },
+		FindByIDFunc:              func(ctx context.Context, id primitive.ObjectID) (*model.User, error) { return user, nil },
+	}
+	pw := mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return true }}
+	tg := mocks.TokenGeneratorMock{GenerateFunc: func(user model.User, sessionID string) (string, error) { return "ACCESS", nil }}
+
+	return setupTestApp(service.NewAuthServiceMock(repo, &mocks.UserTokenRepositoryMock{}, st.mock(), &mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), pw, tg, &mocks.EmailSenderMock{}))
+}
+
+// ===========================
+// ROTASI REFRESH TOKEN
+// ===========================
+func TestRefresh_Rotation(t *testing.T) {
+	st := newRefreshStore()
+	app := setupRefreshApp(st)
+
+	code, login := loginAndDecode(t, app, "/login", model.LoginRequest{Username: "user", Password: "x"})
+	assert.Equal(t, 200, code)
+	assert.NotEmpty(t, login.RefreshToken)
+
+	code, rotated := loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "ACCESS", rotated.Token)
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+
+	// token baru tetap di family yang sama
+	families := map[primitive.ObjectID]bool{}
+	for _, tk := range st.byHash {
+		families[tk.FamilyID] = true
+	}
+	assert.Len(t, families, 1)
+	assert.Empty(t, st.revoked)
+}
+
+// ===========================
+// REUSE DETECTION
+// ===========================
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	st := newRefreshStore()
+	app := setupRefreshApp(st)
+
+	_, login := loginAndDecode(t, app, "/login", model.LoginRequest{Username: "user", Password: "x"})
+	_, rotated := loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: login.RefreshToken})
+
+	// token lama dipakai lagi -> ditolak dan family dicabut
+	code, _ := loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, 401, code)
+	assert.Len(t, st.revoked, 1)
+
+	// token hasil rotasi ikut tidak berlaku
+	code, _ = loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: rotated.RefreshToken})
+	assert.Equal(t, 401, code)
+}
+
+// ===========================
+// TOKEN TIDAK DIKENAL / EXPIRED
+// ===========================
+func TestRefresh_InvalidOrExpired(t *testing.T) {
+	st := newRefreshStore()
+	app := setupRefreshApp(st)
+
+	code, _ := loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: "ngawur"})
+	assert.Equal(t, 401, code)
+
+	code, _ = loginAndDecode(t, app, "/refresh", model.RefreshRequest{})
+	assert.Equal(t, 400, code)
+
+	_, login := loginAndDecode(t, app, "/login", model.LoginRequest{Username: "user", Password: "x"})
+	for _, tk := range st.byHash {
+		tk.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	code, _ = loginAndDecode(t, app, "/refresh", model.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, 401, code)
+}
@@ -0,0 +1,201 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func postJSON(app *fiber.App, url string, v interface{}) int {
+	body, _ := json.Marshal(v)
+	req := httptest.NewRequest("POST", url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ===========================
+// REGISTER: VALIDASI INPUT
+// ===========================
+func TestRegister_InvalidInput(t *testing.T) {
+	authSvc := newAuthService(&mocks.UserRepositoryMock{}, mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{})
+	app := setupTestApp(authSvc)
+
+	cases := []model.RegisterRequest{
+		{Username: "ab", Email: "a@mail.com", Password: "password123"},
+		{Username: "budi", Email: "bukan-email", Password: "password123"},
+		{Username: "budi", Email: "a@mail.com", Password: "123"},
+	}
+	for _, in := range cases {
+		assert.Equal(t, 400, postJSON(app, "/register", in))
+	}
+}
+
+// ===========================
+// REGISTER: DUPLIKAT
+// ===========================
+func TestRegister_Duplicate(t *testing.T) {
+	repo := &mocks.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, user *model.User) error {
+			return repository.ErrUserExists
+		},
+	}
+	app := setupTestApp(newAuthService(repo, mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}))
+
+	code := postJSON(app, "/register", model.RegisterRequest{Username: "budi", Email: "budi@mail.com", Password: "password123"})
+	assert.Equal(t, 409, code)
+}
+
+// ===========================
+// REGISTER SUKSES -> VERIFIKASI -> LOGIN
+// ===========================
+func TestRegister_VerifyFlow(t *testing.T) {
+	var created *model.User
+	var stored *model.UserToken
+
+	repo := &mocks.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, user *model.User) error {
+			user.ID = primitive.NewObjectID()
+			created = user
+			return nil
+		},
+		MarkEmailVerifiedFunc: func(ctx context.Context, id primitive.ObjectID) error {
+			assert.Equal(t, created.ID, id)
+			created.Status = model.UserStatusActive
+			return nil
+		},
+		FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) {
+			return created, nil
+		},
+	}
+	tokenRepo := &mocks.UserTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, token *model.UserToken) error {
+			stored = token
+			return nil
+		},
+		FindValidFunc: func(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+			if stored != nil && stored.TokenHash == tokenHash && stored.UsedAt == nil {
+				return stored, nil
+			}
+			return nil, nil
+		},
+		MarkUsedFunc: func(ctx context.Context, id primitive.ObjectID) (bool, error) {
+			now := time.Now()
+			stored.UsedAt = &now
+			return true, nil
+		},
+	}
+	outbox := &mocks.EmailSenderMock{}
+	pw := mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return utils.CheckPassword(hash, password) }}
+	tg := mocks.TokenGeneratorMock{GenerateFunc: func(user model.User, sessionID string) (string, error) { return "TOKEN123", nil }}
+
+	app := setupTestApp(service.NewAuthServiceMock(repo, tokenRepo, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), pw, tg, outbox))
+
+	code := postJSON(app, "/register", model.RegisterRequest{Username: "budi", Email: "Budi@Mail.com ", Password: "password123"})
+	assert.Equal(t, 201, code)
+	assert.Equal(t, "budi@mail.com", created.Email)
+	assert.Equal(t, model.UserStatusPending, created.Status)
+	assert.NotEqual(t, "password123", created.PasswordHash)
+
+	// email verifikasi masuk ke outbox dan berisi token
+	assert.Len(t, outbox.Outbox, 1)
+	assert.Equal(t, "budi@mail.com", outbox.Outbox[0].To)
+	body := outbox.Outbox[0].Body
+	idx := strings.Index(body, "token=")
+	assert.True(t, idx >= 0)
+	token := strings.Fields(body[idx+len("token="):])[0]
+	assert.Equal(t, utils.HashToken(token), stored.TokenHash)
+
+	// belum verifikasi -> login ditolak
+	assert.Equal(t, 403, postJSON(app, "/login", model.LoginRequest{Username: "budi", Password: "password123"}))
+
+	// verifikasi
+	resp, _ := app.Test(httptest.NewRequest("GET", "/verify-email?token="+token, nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	// token sekali pakai
+	resp, _ = app.Test(httptest.NewRequest("GET", "/verify-email?token="+token, nil))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	// setelah verifikasi login berhasil
+	assert.Equal(t, 200, postJSON(app, "/login", model.LoginRequest{Username: "budi", Password: "password123"}))
+}
+
+// ===========================
+// VERIFIKASI: TOKEN TIDAK DIKENAL
+// ===========================
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	app := setupTestApp(newAuthService(&mocks.UserRepositoryMock{}, mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/verify-email?token=ngawur", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/verify-email", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+// ===========================
+// VERIFIKASI: KONFIRMASI GANTI EMAIL DARI PUT /me
+// ===========================
+func TestVerifyEmail_ConfirmsEmailChange(t *testing.T) {
+	userID := primitive.NewObjectID()
+	stored := &model.UserToken{
+		ID: primitive.NewObjectID(), UserID: userID, Purpose: model.TokenPurposeEmailChange,
+		Email: "budi.baru@example.com", TokenHash: utils.HashToken("ganti-email"),
+	}
+	var updated string
+	failUpdate := true
+	repo := &mocks.UserRepositoryMock{
+		UpdateEmailFunc: func(ctx context.Context, id primitive.ObjectID, email string) error {
+			if failUpdate {
+				return repository.ErrUserExists
+			}
+			assert.Equal(t, userID, id)
+			updated = email
+			return nil
+		},
+	}
+	tokenRepo := &mocks.UserTokenRepositoryMock{
+		FindValidFunc: func(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+			if purpose == stored.Purpose && tokenHash == stored.TokenHash && stored.UsedAt == nil {
+				return stored, nil
+			}
+			return nil, nil
+		},
+		MarkUsedFunc: func(ctx context.Context, id primitive.ObjectID) (bool, error) {
+			now := time.Now()
+			stored.UsedAt = &now
+			return true, nil
+		},
+	}
+	app := setupTestApp(service.NewAuthServiceMock(repo, tokenRepo, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenRevocationRepositoryMock{},
+		&mocks.SessionRepositoryMock{}, service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}, &mocks.EmailSenderMock{}))
+
+	// email sudah diambil akun lain: token tidak ikut terbakar
+	resp, _ := app.Test(httptest.NewRequest("GET", "/verify-email?token=ganti-email", nil))
+	assert.Equal(t, 409, resp.StatusCode)
+	assert.Nil(t, stored.UsedAt)
+
+	failUpdate = false
+	resp, _ = app.Test(httptest.NewRequest("GET", "/verify-email?token=ganti-email", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "budi.baru@example.com", updated)
+	assert.NotNil(t, stored.UsedAt)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/verify-email?token=ganti-email", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
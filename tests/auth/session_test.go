@@ -0,0 +1,240 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ===========================
+// SESSION: LOGIN MEMBUAT SESI
+// ===========================
+func TestSession_LoginCreatesSession(t *testing.T) {
+	user := &model.User{ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser}
+	var created *model.Session
+	var family primitive.ObjectID
+	var tokenSID string
+
+	sessions := &mocks.SessionRepositoryMock{
+		CreateFunc: func(ctx context.Context, s *model.Session) error {
+			created = s
+			return nil
+		},
+	}
+	refresh := &mocks.RefreshTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, token *model.RefreshToken) error {
+			family = token.FamilyID
+			return nil
+		},
+	}
+	authSvc := service.NewAuthServiceMock(
+		&mocks.UserRepositoryMock{FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) { return user, nil }},
+		&mocks.UserTokenRepositoryMock{}, refresh, &mocks.TokenRevocationRepositoryMock{}, sessions,
+		service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}),
+		mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return true }},
+		mocks.TokenGeneratorMock{GenerateFunc: func(user model.User, sessionID string) (string, error) {
+			tokenSID = sessionID
+			return "ACCESS", nil
+		}},
+		&mocks.EmailSenderMock{})
+	app := setupTestApp(authSvc)
+
+	req := httptest.NewRequest("POST", "/login", jsonBody(model.LoginRequest{Username: "budi", Password: "x"}))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Firefox/130")
+	resp, _ := app.Test(req)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	require.NotNil(t, created)
+	assert.Equal(t, user.ID, created.UserID)
+	assert.Equal(t, "Firefox/130", created.UserAgent)
+	assert.NotEmpty(t, created.IP)
+	assert.Equal(t, created.ID.Hex(), tokenSID, "access token membawa sid")
+	assert.Equal(t, created.ID, family, "family refresh token = sesi")
+}
+
+// ===========================
+// SESSION: REFRESH MEMPERTAHANKAN SESI
+// ===========================
+func TestSession_RefreshKeepsSession(t *testing.T) {
+	user := &model.User{ID: primitive.NewObjectID(), Username: "budi"}
+	current := &model.RefreshToken{ID: primitive.NewObjectID(), UserID: user.ID, FamilyID: primitive.NewObjectID(), ExpiresAt: time.Now().Add(time.Hour)}
+	var refreshed primitive.ObjectID
+	var tokenSID string
+
+	authSvc := service.NewAuthServiceMock(
+		&mocks.UserRepositoryMock{FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) { return user, nil }},
+		&mocks.UserTokenRepositoryMock{},
+		&mocks.RefreshTokenRepositoryMock{
+			FindByHashFunc: func(ctx context.Context, tokenHash string) (*model.RefreshToken, error) { return current, nil },
+			MarkUsedFunc:   func(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error) { return true, nil },
+		},
+		&mocks.TokenRevocationRepositoryMock{},
+		&mocks.SessionRepositoryMock{RefreshedFunc: func(ctx context.Context, id primitive.ObjectID, ip, userAgent string, expiresAt time.Time) error {
+			refreshed = id
+			return nil
+		}},
+		service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}),
+		mocks.PasswordCheckerMock{},
+		mocks.TokenGeneratorMock{GenerateFunc: func(user model.User, sessionID string) (string, error) {
+			tokenSID = sessionID
+			return "ACCESS", nil
+		}},
+		&mocks.EmailSenderMock{})
+	app := setupTestApp(authSvc)
+
+	req := httptest.NewRequest("POST", "/refresh", jsonBody(model.RefreshRequest{RefreshToken: "REFRESH"}))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, current.FamilyID, refreshed)
+	assert.Equal(t, current.FamilyID.Hex(), tokenSID)
+}
+
+// sessionFixture: service sesi dengan pencatat pemanggilan revoke
+type sessionFixture struct {
+	userID        primitive.ObjectID
+	sessionID     primitive.ObjectID
+	revokedKeys   []string
+	revokedFamily []primitive.ObjectID
+	allRevoked    []string
+}
+
+func (f *sessionFixture) app() *fiber.App {
+	sessions := &mocks.SessionRepositoryMock{
+		FindActiveByUserFunc: func(ctx context.Context, userID primitive.ObjectID) ([]model.Session, error) {
+			return []model.Session{{ID: f.sessionID, UserID: userID}, {ID: primitive.NewObjectID(), UserID: userID}}, nil
+		},
+		RevokeFunc: func(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+			return id == f.sessionID && userID == f.userID, nil
+		},
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			f.allRevoked = append(f.allRevoked, "sessions")
+			return nil
+		},
+	}
+	refresh := &mocks.RefreshTokenRepositoryMock{
+		RevokeFamilyFunc: func(ctx context.Context, familyID primitive.ObjectID) error {
+			f.revokedFamily = append(f.revokedFamily, familyID)
+			return nil
+		},
+		RevokeByUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			f.allRevoked = append(f.allRevoked, "refresh")
+			return nil
+		},
+	}
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeFunc: func(ctx context.Context, jti string, userID primitive.ObjectID, expiresAt time.Time) error {
+			f.revokedKeys = append(f.revokedKeys, jti)
+			return nil
+		},
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error {
+			f.allRevoked = append(f.allRevoked, "access")
+			return nil
+		},
+	}
+	users := &mocks.UserRepositoryMock{FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+		if id == f.userID {
+			return &model.User{ID: id}, nil
+		}
+		return nil, nil
+	}}
+	s := service.NewSessionService(sessions, refresh, revocations, users)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: f.userID.Hex(), SessionID: f.sessionID.Hex()})
+		return c.Next()
+	})
+	app.Get("/me/sessions", s.GetMine)
+	app.Delete("/me/sessions/:id", s.Revoke)
+	app.Delete("/users/:id/sessions", s.RevokeAllForUser)
+	return app
+}
+
+func TestSession_ListMine(t *testing.T) {
+	f := &sessionFixture{userID: primitive.NewObjectID(), sessionID: primitive.NewObjectID()}
+	resp, _ := f.app().Test(httptest.NewRequest("GET", "/me/sessions", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data []model.Session `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 2)
+	assert.True(t, body.Data[0].Current)
+	assert.False(t, body.Data[1].Current)
+}
+
+func TestSession_RevokeOne(t *testing.T) {
+	f := &sessionFixture{userID: primitive.NewObjectID(), sessionID: primitive.NewObjectID()}
+	app := f.app()
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/me/sessions/"+primitive.NewObjectID().Hex(), nil))
+	assert.Equal(t, 404, resp.StatusCode)
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/me/sessions/bukan-id", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Empty(t, f.revokedKeys)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/me/sessions/"+f.sessionID.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []primitive.ObjectID{f.sessionID}, f.revokedFamily)
+	assert.Equal(t, []string{repository.SessionRevocationKey(f.sessionID.Hex())}, f.revokedKeys)
+}
+
+func TestSession_AdminSignOutEverywhere(t *testing.T) {
+	f := &sessionFixture{userID: primitive.NewObjectID(), sessionID: primitive.NewObjectID()}
+	app := f.app()
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/users/"+primitive.NewObjectID().Hex()+"/sessions", nil))
+	assert.Equal(t, 404, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/users/"+f.userID.Hex()+"/sessions", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.ElementsMatch(t, []string{"access", "refresh", "sessions"}, f.allRevoked)
+}
+
+// ===========================
+// SESSION: LOGOUT MENGAKHIRI SESI
+// ===========================
+func TestSession_LogoutEndsSession(t *testing.T) {
+	userID, sessionID := primitive.NewObjectID(), primitive.NewObjectID()
+	var revokedKeys []string
+	var ended bool
+
+	authSvc := service.NewAuthServiceMock(&mocks.UserRepositoryMock{}, &mocks.UserTokenRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{},
+		&mocks.TokenRevocationRepositoryMock{RevokeFunc: func(ctx context.Context, jti string, uid primitive.ObjectID, expiresAt time.Time) error {
+			revokedKeys = append(revokedKeys, jti)
+			return nil
+		}},
+		&mocks.SessionRepositoryMock{RevokeFunc: func(ctx context.Context, id, uid primitive.ObjectID) (bool, error) {
+			ended = id == sessionID && uid == userID
+			return true, nil
+		}},
+		service.NewLockoutService(&mocks.LoginAttemptRepositoryMock{}), mocks.PasswordCheckerMock{}, mocks.TokenGeneratorMock{}, &mocks.EmailSenderMock{})
+
+	claims := &model.JWTClaims{UserID: userID.Hex(), SessionID: sessionID.Hex(), RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"}}
+	assert.Equal(t, 200, logoutRequest(setupLogoutApp(authSvc, claims), nil))
+	assert.True(t, ended)
+	assert.Equal(t, []string{"jti-1", repository.SessionRevocationKey(sessionID.Hex())}, revokedKeys)
+}
+
+func jsonBody(v interface{}) *bytes.Buffer {
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(v)
+	return &buf
+}
@@ -0,0 +1,340 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/app/utils"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// twoFactorStore: satu user in-memory dengan field 2FA yang ikut berubah
+type twoFactorStore struct {
+	user model.User
+}
+
+func (s *twoFactorStore) mock() *mocks.UserRepositoryMock {
+	return &mocks.UserRepositoryMock{
+		FindByUsernameOrEmailFunc: func(ctx context.Context, username string) (*model.User, error) {
+			if username != s.user.Username {
+				return nil, nil
+			}
+			u := s.user
+			return &u, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			u := s.user
+			return &u, nil
+		},
+		SetTOTPSecretFunc: func(ctx context.Context, id primitive.ObjectID, secret string) error {
+			s.user.TOTPSecret = secret
+			return nil
+		},
+		EnableTOTPFunc: func(ctx context.Context, id primitive.ObjectID, step int64, hashes []string) error {
+			s.user.TOTPEnabled = true
+			s.user.TOTPLastStep = step
+			s.user.RecoveryCodes = hashes
+			return nil
+		},
+		DisableTOTPFunc: func(ctx context.Context, id primitive.ObjectID) error {
+			s.user.TOTPEnabled = false
+			s.user.TOTPSecret = ""
+			s.user.RecoveryCodes = nil
+			return nil
+		},
+		UseTOTPStepFunc: func(ctx context.Context, id primitive.ObjectID, step int64) (bool, error) {
+			if step <= s.user.TOTPLastStep {
+				return false, nil
+			}
+			s.user.TOTPLastStep = step
+			return true, nil
+		},
+		UseRecoveryCodeFunc: func(ctx context.Context, id primitive.ObjectID, hash string) (bool, error) {
+			for i, h := range s.user.RecoveryCodes {
+				if h == hash {
+					s.user.RecoveryCodes = append(s.user.RecoveryCodes[:i], s.user.RecoveryCodes[i+1:]...)
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+func setupTwoFactorApp(t *testing.T, store *twoFactorStore) *fiber.App {
+	return setupTwoFactorAppWithAttempts(t, store, newAttemptStore())
+}
+
+func setupTwoFactorAppWithAttempts(t *testing.T, store *twoFactorStore, attempts *attemptStore) *fiber.App {
+	t.Setenv("JWT_SECRET", "test-secret")
+	require.NoError(t, utils.LoadKeys())
+
+	authSvc := service.NewAuthServiceMock(store.mock(), &mocks.UserTokenRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{},
+		&mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{}, service.NewLockoutService(attempts.mock()),
+		mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return password == "rahasia123" }},
+		mocks.TokenGeneratorMock{GenerateFunc: func(user model.User, sessionID string) (string, error) { return "ACCESS", nil }},
+		&mocks.EmailSenderMock{})
+
+	app := fiber.New()
+	app.Post("/login", authSvc.Login)
+	app.Post("/login/2fa", authSvc.LoginTwoFactor)
+	app.Post("/login/2fa/setup", authSvc.LoginTwoFactorSetup)
+
+	me := app.Group("/me/2fa", func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: store.user.ID.Hex(), Role: store.user.Role})
+		return c.Next()
+	})
+	me.Post("/setup", authSvc.SetupTwoFactor)
+	me.Post("/enable", authSvc.EnableTwoFactor)
+	me.Post("/disable", authSvc.DisableTwoFactor)
+	return app
+}
+
+func postBody(app *fiber.App, url string, v interface{}) (int, map[string]interface{}) {
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(v)
+	req := httptest.NewRequest("POST", url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	var out map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func totpNow(t *testing.T, secret string, offset time.Duration) string {
+	code, err := utils.TOTPCode(secret, time.Now().Add(offset))
+	require.NoError(t, err)
+	return code
+}
+
+// ===========================
+// TOTP: TEST VECTOR RFC 6238 (SHA1)
+// ===========================
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+	for ts, want := range map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924"} {
+		code, err := utils.TOTPCode(secret, time.Unix(ts, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, code)
+	}
+
+	step, ok := utils.ValidateTOTP(secret, "287082", time.Unix(59, 0), 0)
+	assert.True(t, ok)
+	_, ok = utils.ValidateTOTP(secret, "287082", time.Unix(59, 0), step)
+	assert.False(t, ok, "langkah yang sudah dipakai ditolak")
+	_, ok = utils.ValidateTOTP(secret, "287082", time.Unix(59+120, 0), 0)
+	assert.False(t, ok, "kode kadaluarsa ditolak")
+}
+
+// ===========================
+// 2FA: LOGIN DUA LANGKAH
+// ===========================
+func TestTwoFactor_Login(t *testing.T) {
+	secret, _ := utils.GenerateTOTPSecret()
+	store := &twoFactorStore{user: model.User{
+		ID: primitive.NewObjectID(), Username: "admin", Role: model.RoleAdmin,
+		TOTPSecret: secret, TOTPEnabled: true,
+		RecoveryCodes: []string{utils.HashToken("abcde-12345")},
+	}}
+	app := setupTwoFactorApp(t, store)
+
+	status, body := postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "rahasia123"})
+	assert.Equal(t, 202, status)
+	assert.Equal(t, true, body["two_factor_required"])
+	assert.Nil(t, body["token"], "access token belum diberikan")
+	challenge := body["challenge_token"].(string)
+
+	// token challenge tidak bisa dipakai sebagai access token
+	_, err := utils.ValidateToken(challenge)
+	assert.Error(t, err)
+
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: "ngasal", Code: "000000"})
+	assert.Equal(t, 401, status)
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: totpNow(t, secret, -5*time.Minute)})
+	assert.Equal(t, 401, status)
+
+	code := totpNow(t, secret, 0)
+	status, body = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: code})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "ACCESS", body["token"])
+
+	// kode yang sama tidak bisa di-replay
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: code})
+	assert.Equal(t, 401, status)
+
+	// kode pemulihan hanya bisa dipakai sekali
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "ABCDE-12345"})
+	assert.Equal(t, 200, status)
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "abcde-12345"})
+	assert.Equal(t, 401, status)
+}
+
+// kode 2FA salah menambah counter akun yang sama dengan password salah
+func TestTwoFactor_LoginSharesAccountLockout(t *testing.T) {
+	secret, _ := utils.GenerateTOTPSecret()
+	store := &twoFactorStore{user: model.User{
+		ID: primitive.NewObjectID(), Username: "admin", Role: model.RoleAdmin,
+		TOTPSecret: secret, TOTPEnabled: true,
+	}}
+	attempts := newAttemptStore()
+	app := setupTwoFactorAppWithAttempts(t, store, attempts)
+
+	for i := 0; i < 2; i++ {
+		status, _ := postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "salah"})
+		assert.Equal(t, 401, status)
+	}
+	status, body := postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "rahasia123"})
+	require.Equal(t, 202, status)
+	challenge := body["challenge_token"].(string)
+
+	for i := 0; i < 3; i++ {
+		status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"})
+		assert.Equal(t, 401, status)
+	}
+	assert.Equal(t, 5, attempts.failures(model.AttemptKindAccount, store.user.ID.Hex()))
+	assert.Equal(t, 0, attempts.failures(model.AttemptKindUser, "admin"))
+
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: totpNow(t, secret, 0)})
+	assert.Equal(t, 423, status)
+	status, _ = postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "rahasia123"})
+	assert.Equal(t, 423, status)
+}
+
+func TestTwoFactor_LoginWithoutTwoFactor(t *testing.T) {
+	store := &twoFactorStore{user: model.User{ID: primitive.NewObjectID(), Username: "admin", Role: model.RoleAdmin}}
+	app := setupTwoFactorApp(t, store)
+
+	status, body := postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "rahasia123"})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "ACCESS", body["token"])
+}
+
+// ===========================
+// 2FA: KEBIJAKAN WAJIB UNTUK ADMIN
+// ===========================
+func TestTwoFactor_RequiredForAdmin(t *testing.T) {
+	t.Setenv("REQUIRE_2FA_FOR_ADMIN", "true")
+	store := &twoFactorStore{user: model.User{ID: primitive.NewObjectID(), Username: "admin", Role: model.RoleAdmin}}
+	app := setupTwoFactorApp(t, store)
+
+	status, body := postBody(app, "/login", model.LoginRequest{Username: "admin", Password: "rahasia123"})
+	assert.Equal(t, 202, status)
+	assert.Equal(t, true, body["setup_required"])
+	challenge := body["challenge_token"].(string)
+
+	// belum setup -> belum bisa login
+	status, _ = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "123456"})
+	assert.Equal(t, 400, status)
+
+	status, body = postBody(app, "/login/2fa/setup", model.TwoFactorSetupRequest{ChallengeToken: challenge})
+	assert.Equal(t, 200, status)
+	secret := body["secret"].(string)
+	assert.Contains(t, body["otpauth_uri"], "otpauth://totp/")
+	assert.False(t, store.user.TOTPEnabled)
+
+	status, body = postBody(app, "/login/2fa", model.TwoFactorLoginRequest{ChallengeToken: challenge, Code: totpNow(t, secret, 0)})
+	assert.Equal(t, 200, status)
+	assert.Len(t, body["recovery_codes"], 10)
+	assert.True(t, store.user.TOTPEnabled)
+
+	// admin tidak bisa mematikan 2FA selama kebijakan aktif
+	status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: totpNow(t, secret, 30*time.Second)})
+	assert.Equal(t, 403, status)
+
+	// role lain tidak terkena kebijakan
+	user := &twoFactorStore{user: model.User{ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser}}
+	status, _ = postBody(setupTwoFactorApp(t, user), "/login", model.LoginRequest{Username: "budi", Password: "rahasia123"})
+	assert.Equal(t, 200, status)
+}
+
+// ===========================
+// 2FA: SETUP / AKTIFKAN / MATIKAN DARI AKUN SENDIRI
+// ===========================
+func TestTwoFactor_Enrollment(t *testing.T) {
+	store := &twoFactorStore{user: model.User{ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser}}
+	app := setupTwoFactorApp(t, store)
+
+	status, _ := postBody(app, "/me/2fa/enable", model.TwoFactorCodeRequest{Code: "123456"})
+	assert.Equal(t, 400, status, "setup belum dimulai")
+
+	status, body := postBody(app, "/me/2fa/setup", nil)
+	assert.Equal(t, 200, status)
+	secret := body["secret"].(string)
+
+	status, _ = postBody(app, "/me/2fa/enable", model.TwoFactorCodeRequest{Code: "000000"})
+	assert.Equal(t, 401, status)
+	status, body = postBody(app, "/me/2fa/enable", model.TwoFactorCodeRequest{Code: totpNow(t, secret, 0)})
+	assert.Equal(t, 200, status)
+	codes := body["recovery_codes"].([]interface{})
+	assert.Len(t, codes, 10)
+	assert.True(t, store.user.TOTPEnabled)
+
+	status, _ = postBody(app, "/me/2fa/setup", nil)
+	assert.Equal(t, 409, status)
+
+	status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "salah", Code: codes[0].(string)})
+	assert.Equal(t, 401, status)
+	status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: codes[0].(string)})
+	assert.Equal(t, 200, status)
+	assert.False(t, store.user.TOTPEnabled)
+}
+
+// ===========================
+// 2FA: MATIKAN 2FA IKUT DIBATASI LOCKOUT AKUN
+// ===========================
+func TestTwoFactor_DisableLockout(t *testing.T) {
+	secret, _ := utils.GenerateTOTPSecret()
+	store := &twoFactorStore{user: model.User{
+		ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser,
+		TOTPSecret: secret, TOTPEnabled: true,
+	}}
+	attempts := newAttemptStore()
+	app := setupTwoFactorAppWithAttempts(t, store, attempts)
+	account := store.user.ID.Hex()
+
+	// kode salah dicatat, kode benar mereset counter akun
+	status, _ := postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: "000000"})
+	assert.Equal(t, 401, status)
+	assert.Equal(t, 1, attempts.failures(model.AttemptKindAccount, account))
+
+	for i := 0; i < 4; i++ {
+		status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: "000000"})
+		assert.Equal(t, 401, status)
+	}
+	assert.Equal(t, 5, attempts.failures(model.AttemptKindAccount, account))
+
+	// setelah batas terlewati, kode benar pun ditolak dan 2FA tetap aktif
+	status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: totpNow(t, secret, 0)})
+	assert.Equal(t, 423, status)
+	assert.True(t, store.user.TOTPEnabled)
+}
+
+func TestTwoFactor_DisableSuccessResetsCounter(t *testing.T) {
+	secret, _ := utils.GenerateTOTPSecret()
+	store := &twoFactorStore{user: model.User{
+		ID: primitive.NewObjectID(), Username: "budi", Role: model.RoleUser,
+		TOTPSecret: secret, TOTPEnabled: true,
+	}}
+	attempts := newAttemptStore()
+	app := setupTwoFactorAppWithAttempts(t, store, attempts)
+
+	status, _ := postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "salah", Code: "000000"})
+	assert.Equal(t, 401, status)
+	assert.Equal(t, 1, attempts.failures(model.AttemptKindAccount, store.user.ID.Hex()))
+
+	status, _ = postBody(app, "/me/2fa/disable", model.DisableTwoFactorRequest{Password: "rahasia123", Code: totpNow(t, secret, 0)})
+	assert.Equal(t, 200, status)
+	assert.Equal(t, 0, attempts.failures(model.AttemptKindAccount, store.user.ID.Hex()))
+	assert.False(t, store.user.TOTPEnabled)
+}
@@ -0,0 +1,49 @@
+package file_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================== CURSOR ==========================
+func TestGetAll_CursorMode(t *testing.T) {
+	var gotLimit int
+	repo := &mocks.FileRepositoryMock{
+		FindAllByCursorFunc: func(limit int, cursor string) ([]model.File, string, error) {
+			gotLimit = limit
+			return []model.File{{FileName: "a.jpg"}}, "", nil
+		},
+		FindAllFunc: func() ([]model.File, error) {
+			t.Fatal("FindAll tidak boleh dipanggil pada mode cursor")
+			return nil, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo, t.TempDir()).Test(httptest.NewRequest("GET", "/api/files?cursor=&limit=3", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 3, gotLimit)
+
+	var body struct {
+		Meta model.CursorMeta `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, model.CursorMeta{Limit: 3, SortBy: "uploaded_at", Order: "DESC"}, body.Meta)
+}
+
+func TestGetAll_InvalidCursor(t *testing.T) {
+	repo := &mocks.FileRepositoryMock{
+		FindAllByCursorFunc: func(limit int, cursor string) ([]model.File, string, error) {
+			return nil, "", repository.ErrInvalidCursor
+		},
+	}
+
+	resp, _ := setupTestApp(repo, t.TempDir()).Test(httptest.NewRequest("GET", "/api/files?cursor=rusak", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
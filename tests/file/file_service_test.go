@@ -147,6 +147,49 @@ func TestUploadPhoto_UserSetAlumniID_Forbidden(t *testing.T) {
 	assert.Equal(t, 403, resp.StatusCode)
 }
 
+// helper: app dengan user non-admin (alumniID kosong = akun belum terhubung)
+func setupUserApp(repo *mocks.FileRepositoryMock, uploadBase, alumniID string) *fiber.App {
+	app := fiber.New()
+	svc := service.NewFileService(repo, uploadBase)
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", map[string]interface{}{"id": primitive.NewObjectID().Hex(), "role": "user", "alumni_id": alumniID})
+		return c.Next()
+	})
+	app.Post("/api/files/photo", svc.UploadPhoto)
+	app.Delete("/api/files/:id", svc.DeleteByID)
+	return app
+}
+
+func TestUploadPhoto_UserNotLinked_Forbidden(t *testing.T) {
+	repo := &mocks.FileRepositoryMock{}
+	app := setupUserApp(repo, t.TempDir(), "")
+
+	data := []byte{0xFF, 0xD8, 0xFF}
+	req := makeMultipartRequest(t, "POST", "/api/files/photo", "file", "photo.jpg", "image/jpeg", data, nil)
+	resp, _ := app.Test(req)
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestUploadPhoto_UserUsesLinkedAlumni(t *testing.T) {
+	alumniID := primitive.NewObjectID()
+	var saved *model.File
+	repo := &mocks.FileRepositoryMock{
+		CreateFunc: func(f *model.File) (primitive.ObjectID, error) {
+			saved = f
+			return primitive.NewObjectID(), nil
+		},
+	}
+	app := setupUserApp(repo, t.TempDir(), alumniID.Hex())
+
+	data := []byte{0xFF, 0xD8, 0xFF}
+	req := makeMultipartRequest(t, "POST", "/api/files/photo", "file", "photo.jpg", "image/jpeg", data, nil)
+	resp, _ := app.Test(req)
+	assert.Equal(t, 201, resp.StatusCode)
+	if assert.NotNil(t, saved) && assert.NotNil(t, saved.AlumniID) {
+		assert.Equal(t, alumniID, *saved.AlumniID)
+	}
+}
+
 func TestUploadPhoto_SaveFileError(t *testing.T) {
 	repo := &mocks.FileRepositoryMock{}
 	uploadBase := t.TempDir()
@@ -345,6 +388,27 @@ func TestDelete_Forbidden(t *testing.T) {
 	assert.Equal(t, 403, resp.StatusCode)
 }
 
+func TestDelete_UserOwnsViaLinkedAlumni(t *testing.T) {
+	alumniID := primitive.NewObjectID()
+	repo := &mocks.FileRepositoryMock{
+		FindByIDFunc: func(id primitive.ObjectID) (*model.File, error) {
+			return &model.File{FileName: "a.jpg", AlumniID: &alumniID, FilePath: filepath.Join(t.TempDir(), "a.jpg")}, nil
+		},
+		DeleteByIDFunc: func(id primitive.ObjectID) error {
+			return nil
+		},
+	}
+
+	// file milik alumni lain -> 403
+	app := setupUserApp(repo, t.TempDir(), primitive.NewObjectID().Hex())
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/api/files/"+primitive.NewObjectID().Hex(), nil))
+	assert.Equal(t, 403, resp.StatusCode)
+
+	app = setupUserApp(repo, t.TempDir(), alumniID.Hex())
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/api/files/"+primitive.NewObjectID().Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
 func TestDelete_RepoError(t *testing.T) {
 	oid := primitive.NewObjectID()
 	repo := &mocks.FileRepositoryMock{
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AlumniClaimRepositoryMock struct {
+	CreateFunc     func(ctx context.Context, claim *model.AlumniClaim) error
+	FindByIDFunc   func(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error)
+	FindByUserFunc func(ctx context.Context, userID primitive.ObjectID) ([]model.AlumniClaim, error)
+	FindAllFunc    func(ctx context.Context, status string) ([]model.AlumniClaim, error)
+	ResolveFunc    func(ctx context.Context, id primitive.ObjectID, status string, reviewer primitive.ObjectID, note string) (bool, error)
+}
+
+func (m *AlumniClaimRepositoryMock) Create(ctx context.Context, claim *model.AlumniClaim) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, claim)
+	}
+	return nil
+}
+
+func (m *AlumniClaimRepositoryMock) FindByID(ctx context.Context, id primitive.ObjectID) (*model.AlumniClaim, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *AlumniClaimRepositoryMock) FindByUser(ctx context.Context, userID primitive.ObjectID) ([]model.AlumniClaim, error) {
+	if m.FindByUserFunc != nil {
+		return m.FindByUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *AlumniClaimRepositoryMock) FindAll(ctx context.Context, status string) ([]model.AlumniClaim, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx, status)
+	}
+	return nil, nil
+}
+
+func (m *AlumniClaimRepositoryMock) Resolve(ctx context.Context, id primitive.ObjectID, status string, reviewer primitive.ObjectID, note string) (bool, error) {
+	if m.ResolveFunc != nil {
+		return m.ResolveFunc(ctx, id, status, reviewer, note)
+	}
+	return true, nil
+}
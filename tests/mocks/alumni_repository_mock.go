@@ -8,19 +8,34 @@ import (
 
 type AlumniRepositoryMock struct {
 	// GetAll
-	GetAllFunc func() ([]model.Alumni, error)
+	GetAllFunc func(q model.AlumniQuery) ([]model.Alumni, error)
+
+	// Count
+	CountFunc func(q model.AlumniQuery) (int, error)
+
+	// GetAllByCursor
+	GetAllByCursorFunc func(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error)
 
 	// GetByID
 	GetByIDFunc func(id primitive.ObjectID) (*model.Alumni, error)
 
+	// FindByNIM
+	FindByNIMFunc func(nim string) (*model.Alumni, error)
+
 	// Create
 	CreateFunc func(alumni *model.Alumni) error
 
 	// Update
-	UpdateFunc func(id primitive.ObjectID, alumni *model.Alumni) error
+	UpdateFunc func(id primitive.ObjectID, alumni *model.Alumni, version int64) error
+
+	// UpdateContact
+	UpdateContactFunc func(id primitive.ObjectID, req model.UpdateMyAlumniRequest) error
+
+	// Patch
+	PatchFunc func(id primitive.ObjectID, alumni *model.Alumni, set, unset []string, version int64) error
 
 	// SoftDelete
-	SoftDeleteFunc func(id primitive.ObjectID) error
+	SoftDeleteFunc func(id primitive.ObjectID, version int64) error
 
 	// GetTrashed
 	GetTrashedFunc func() ([]model.Alumni, error)
@@ -35,13 +50,27 @@ type AlumniRepositoryMock struct {
 	ForceDeleteFunc func(id primitive.ObjectID) error
 }
 
-func (m *AlumniRepositoryMock) GetAll() ([]model.Alumni, error) {
+func (m *AlumniRepositoryMock) GetAll(q model.AlumniQuery) ([]model.Alumni, error) {
     if m.GetAllFunc != nil {
-        return m.GetAllFunc()
+        return m.GetAllFunc(q)
     }
     return []model.Alumni{}, nil
 }
 
+func (m *AlumniRepositoryMock) Count(q model.AlumniQuery) (int, error) {
+    if m.CountFunc != nil {
+        return m.CountFunc(q)
+    }
+    return 0, nil
+}
+
+func (m *AlumniRepositoryMock) GetAllByCursor(q model.AlumniQuery, cursor string) ([]model.Alumni, string, error) {
+    if m.GetAllByCursorFunc != nil {
+        return m.GetAllByCursorFunc(q, cursor)
+    }
+    return []model.Alumni{}, "", nil
+}
+
 func (m *AlumniRepositoryMock) GetByID(id primitive.ObjectID) (*model.Alumni, error) {
     if m.GetByIDFunc != nil {
         return m.GetByIDFunc(id)
@@ -49,6 +78,13 @@ func (m *AlumniRepositoryMock) GetByID(id primitive.ObjectID) (*model.Alumni, er
     return nil, nil
 }
 
+func (m *AlumniRepositoryMock) FindByNIM(nim string) (*model.Alumni, error) {
+    if m.FindByNIMFunc != nil {
+        return m.FindByNIMFunc(nim)
+    }
+    return nil, nil
+}
+
 func (m *AlumniRepositoryMock) Create(alumni *model.Alumni) error {
     if m.CreateFunc != nil {
         return m.CreateFunc(alumni)
@@ -56,16 +92,16 @@ func (m *AlumniRepositoryMock) Create(alumni *model.Alumni) error {
     return nil
 }
 
-func (m *AlumniRepositoryMock) Update(id primitive.ObjectID, alumni *model.Alumni) error {
+func (m *AlumniRepositoryMock) Update(id primitive.ObjectID, alumni *model.Alumni, version int64) error {
     if m.UpdateFunc != nil {
-        return m.UpdateFunc(id, alumni)
+        return m.UpdateFunc(id, alumni, version)
     }
     return nil
 }
 
-func (m *AlumniRepositoryMock) SoftDelete(id primitive.ObjectID) error {
+func (m *AlumniRepositoryMock) SoftDelete(id primitive.ObjectID, version int64) error {
     if m.SoftDeleteFunc != nil {
-        return m.SoftDeleteFunc(id)
+        return m.SoftDeleteFunc(id, version)
     }
     return nil
 }
@@ -98,3 +134,17 @@ func (m *AlumniRepositoryMock) ForceDelete(id primitive.ObjectID) error {
     return nil
 }
 
+
+func (m *AlumniRepositoryMock) UpdateContact(id primitive.ObjectID, req model.UpdateMyAlumniRequest) error {
+    if m.UpdateContactFunc != nil {
+        return m.UpdateContactFunc(id, req)
+    }
+    return nil
+}
+
+func (m *AlumniRepositoryMock) Patch(id primitive.ObjectID, alumni *model.Alumni, set, unset []string, version int64) error {
+    if m.PatchFunc != nil {
+        return m.PatchFunc(id, alumni, set, unset, version)
+    }
+    return nil
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import "praktikum3/app/model"
+
+type AlumniStatusRepositoryMock struct {
+	GetAlumniByStatusFunc func(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error)
+}
+
+func (m *AlumniStatusRepositoryMock) GetAlumniByStatus(q model.AlumniStatusQuery) ([]model.AlumniPekerjaanReport, model.AlumniStatusCounts, error) {
+	if m.GetAlumniByStatusFunc != nil {
+		return m.GetAlumniByStatusFunc(q)
+	}
+	return []model.AlumniPekerjaanReport{}, model.AlumniStatusCounts{}, nil
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import "praktikum3/app/model"
+
+type AnalyticsRepositoryMock struct {
+	EmploymentRateFunc  func(groupBy string) ([]model.EmploymentRate, error)
+	WaitingTimeFunc     func(groupBy string) ([]model.WaitingTime, error)
+	DistributionFunc    func(field string) ([]model.DistributionItem, error)
+	SalaryHistogramFunc func() ([]model.SalaryBucket, error)
+}
+
+func (m *AnalyticsRepositoryMock) EmploymentRate(groupBy string) ([]model.EmploymentRate, error) {
+	if m.EmploymentRateFunc != nil {
+		return m.EmploymentRateFunc(groupBy)
+	}
+	return []model.EmploymentRate{}, nil
+}
+
+func (m *AnalyticsRepositoryMock) WaitingTime(groupBy string) ([]model.WaitingTime, error) {
+	if m.WaitingTimeFunc != nil {
+		return m.WaitingTimeFunc(groupBy)
+	}
+	return []model.WaitingTime{}, nil
+}
+
+func (m *AnalyticsRepositoryMock) Distribution(field string) ([]model.DistributionItem, error) {
+	if m.DistributionFunc != nil {
+		return m.DistributionFunc(field)
+	}
+	return []model.DistributionItem{}, nil
+}
+
+func (m *AnalyticsRepositoryMock) SalaryHistogram() ([]model.SalaryBucket, error) {
+	if m.SalaryHistogramFunc != nil {
+		return m.SalaryHistogramFunc()
+	}
+	return []model.SalaryBucket{}, nil
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type APIKeyRepositoryMock struct {
+	CreateFunc        func(ctx context.Context, key *model.APIKey) error
+	FindByHashFunc    func(ctx context.Context, keyHash string) (*model.APIKey, error)
+	FindAllFunc       func(ctx context.Context) ([]model.APIKey, error)
+	RevokeFunc        func(ctx context.Context, id primitive.ObjectID) (bool, error)
+	TouchLastUsedFunc func(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+func (m *APIKeyRepositoryMock) Create(ctx context.Context, key *model.APIKey) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, key)
+	}
+	return nil
+}
+
+func (m *APIKeyRepositoryMock) FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	if m.FindByHashFunc != nil {
+		return m.FindByHashFunc(ctx, keyHash)
+	}
+	return nil, nil
+}
+
+func (m *APIKeyRepositoryMock) FindAll(ctx context.Context) ([]model.APIKey, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx)
+	}
+	return []model.APIKey{}, nil
+}
+
+func (m *APIKeyRepositoryMock) Revoke(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	if m.RevokeFunc != nil {
+		return m.RevokeFunc(ctx, id)
+	}
+	return true, nil
+}
+
+func (m *APIKeyRepositoryMock) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	if m.TouchLastUsedFunc != nil {
+		return m.TouchLastUsedFunc(ctx, id, at)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package mocks
+
+// SentEmail adalah satu email yang tertampung di outbox
+type SentEmail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSenderMock menampung email di memori (in-memory outbox) alih-alih mengirim
+type EmailSenderMock struct {
+	Outbox  []SentEmail
+	SendErr error
+}
+
+func (m *EmailSenderMock) Send(to, subject, body string) error {
+	if m.SendErr != nil {
+		return m.SendErr
+	}
+	m.Outbox = append(m.Outbox, SentEmail{To: to, Subject: subject, Body: body})
+	return nil
+}
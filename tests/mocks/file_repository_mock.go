@@ -12,6 +12,7 @@ type FileRepositoryMock struct {
 	FindByIDFunc         func(id primitive.ObjectID) (*model.File, error)
 	DeleteByIDFunc       func(id primitive.ObjectID) error
 	FindByUploadedByFunc func(userID primitive.ObjectID) ([]model.File, error)
+	FindAllByCursorFunc  func(limit int, cursor string) ([]model.File, string, error)
 }
 
 func (m *FileRepositoryMock) Create(file *model.File) (primitive.ObjectID, error) {
@@ -48,3 +49,10 @@ func (m *FileRepositoryMock) FindByUploadedBy(userID primitive.ObjectID) ([]mode
 	}
 	return nil, nil
 }
+
+func (m *FileRepositoryMock) FindAllByCursor(limit int, cursor string) ([]model.File, string, error) {
+	if m.FindAllByCursorFunc != nil {
+		return m.FindAllByCursorFunc(limit, cursor)
+	}
+	return nil, "", nil
+}
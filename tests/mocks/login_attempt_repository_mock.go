@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"time"
+)
+
+type LoginAttemptRepositoryMock struct {
+	GetFunc             func(ctx context.Context, key string) (*model.LoginAttempt, error)
+	RegisterFailureFunc func(ctx context.Context, kind, subject string) (*model.LoginAttempt, error)
+	SetLockedUntilFunc  func(ctx context.Context, key string, until time.Time) error
+	ResetFunc           func(ctx context.Context, key string) error
+	ListLockedFunc      func(ctx context.Context) ([]model.LoginAttempt, error)
+}
+
+func (m *LoginAttemptRepositoryMock) Get(ctx context.Context, key string) (*model.LoginAttempt, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, key)
+	}
+	return nil, nil
+}
+
+func (m *LoginAttemptRepositoryMock) RegisterFailure(ctx context.Context, kind, subject string) (*model.LoginAttempt, error) {
+	if m.RegisterFailureFunc != nil {
+		return m.RegisterFailureFunc(ctx, kind, subject)
+	}
+	return &model.LoginAttempt{Key: repository.AttemptKey(kind, subject), Kind: kind, Subject: subject, Failures: 1}, nil
+}
+
+func (m *LoginAttemptRepositoryMock) SetLockedUntil(ctx context.Context, key string, until time.Time) error {
+	if m.SetLockedUntilFunc != nil {
+		return m.SetLockedUntilFunc(ctx, key, until)
+	}
+	return nil
+}
+
+func (m *LoginAttemptRepositoryMock) Reset(ctx context.Context, key string) error {
+	if m.ResetFunc != nil {
+		return m.ResetFunc(ctx, key)
+	}
+	return nil
+}
+
+func (m *LoginAttemptRepositoryMock) ListLocked(ctx context.Context) ([]model.LoginAttempt, error) {
+	if m.ListLockedFunc != nil {
+		return m.ListLockedFunc(ctx)
+	}
+	return nil, nil
+}
@@ -8,18 +8,21 @@ import (
 )
 
 type PekerjaanRepositoryMock struct {
-	GetAllWithQueryFunc   func(search, sortBy, order string, limit, offset int) ([]model.Pekerjaan, error)
-	CountFunc             func(search string) (int, error)
+	GetAllWithQueryFunc   func(q model.PekerjaanQuery) ([]model.Pekerjaan, error)
+	CountFunc             func(q model.PekerjaanQuery) (int, error)
+	GetAllByCursorFunc    func(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error)
 	GetByIDFunc           func(id primitive.ObjectID) (*model.Pekerjaan, error)
 	GetByAlumniIDFunc     func(alumniID primitive.ObjectID, includeDeleted bool) ([]model.Pekerjaan, error)
 	CreateFunc            func(in model.CreatePekerjaanReq, mulai, selesai *time.Time) (primitive.ObjectID, error)
-	UpdateFunc            func(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time) error
-	SoftDeleteByUserFunc  func(id primitive.ObjectID, alumniID primitive.ObjectID) error
-	SoftDeleteByAdminFunc func(id primitive.ObjectID) error
+	UpdateFunc            func(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time, version int64) error
+	PatchFunc             func(id primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error
+	SoftDeleteByUserFunc  func(id primitive.ObjectID, alumniID primitive.ObjectID, version int64) error
+	SoftDeleteByAdminFunc func(id primitive.ObjectID, version int64) error
 	RestoreByIDFunc       func(id primitive.ObjectID) error
 	HardDeleteByIDFunc    func(id primitive.ObjectID) error
 	GetAllTrashFunc       func() ([]model.PekerjaanTrash, error)
 	GetUserTrashFunc      func(alumniID primitive.ObjectID) ([]model.PekerjaanTrash, error)
+	ExpireContractsFunc   func(before time.Time) (int64, error)
 }
 
 // HardDeleteByUser implements repository.PekerjaanRepository.
@@ -32,20 +35,27 @@ func (m *PekerjaanRepositoryMock) RestoreByIDAndUser(id primitive.ObjectID, alum
 	panic("unimplemented")
 }
 
-func (m *PekerjaanRepositoryMock) GetAllWithQuery(search, sortBy, order string, limit, offset int) ([]model.Pekerjaan, error) {
+func (m *PekerjaanRepositoryMock) GetAllWithQuery(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
 	if m.GetAllWithQueryFunc != nil {
-		return m.GetAllWithQueryFunc(search, sortBy, order, limit, offset)
+		return m.GetAllWithQueryFunc(q)
 	}
 	return nil, nil
 }
 
-func (m *PekerjaanRepositoryMock) Count(search string) (int, error) {
+func (m *PekerjaanRepositoryMock) Count(q model.PekerjaanQuery) (int, error) {
 	if m.CountFunc != nil {
-		return m.CountFunc(search)
+		return m.CountFunc(q)
 	}
 	return 0, nil
 }
 
+func (m *PekerjaanRepositoryMock) GetAllByCursor(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error) {
+	if m.GetAllByCursorFunc != nil {
+		return m.GetAllByCursorFunc(q, cursor)
+	}
+	return nil, "", nil
+}
+
 func (m *PekerjaanRepositoryMock) GetByID(id primitive.ObjectID) (*model.Pekerjaan, error) {
 	if m.GetByIDFunc != nil {
 		return m.GetByIDFunc(id)
@@ -67,23 +77,23 @@ func (m *PekerjaanRepositoryMock) Create(in model.CreatePekerjaanReq, mulai, sel
 	return primitive.NilObjectID, nil
 }
 
-func (m *PekerjaanRepositoryMock) Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time) error {
+func (m *PekerjaanRepositoryMock) Update(id primitive.ObjectID, in model.UpdatePekerjaanReq, mulai, selesai *time.Time, version int64) error {
 	if m.UpdateFunc != nil {
-		return m.UpdateFunc(id, in, mulai, selesai)
+		return m.UpdateFunc(id, in, mulai, selesai, version)
 	}
 	return nil
 }
 
-func (m *PekerjaanRepositoryMock) SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID) error {
+func (m *PekerjaanRepositoryMock) SoftDeleteByUser(id primitive.ObjectID, alumniID primitive.ObjectID, version int64) error {
 	if m.SoftDeleteByUserFunc != nil {
-		return m.SoftDeleteByUserFunc(id, alumniID)
+		return m.SoftDeleteByUserFunc(id, alumniID, version)
 	}
 	return nil
 }
 
-func (m *PekerjaanRepositoryMock) SoftDeleteByAdmin(id primitive.ObjectID) error {
+func (m *PekerjaanRepositoryMock) SoftDeleteByAdmin(id primitive.ObjectID, version int64) error {
 	if m.SoftDeleteByAdminFunc != nil {
-		return m.SoftDeleteByAdminFunc(id)
+		return m.SoftDeleteByAdminFunc(id, version)
 	}
 	return nil
 }
@@ -115,3 +125,17 @@ func (m *PekerjaanRepositoryMock) GetUserTrash(alumniID primitive.ObjectID) ([]m
 	}
 	return nil, nil
 }
+
+func (m *PekerjaanRepositoryMock) Patch(id primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(id, p, set, unset, version)
+	}
+	return nil
+}
+
+func (m *PekerjaanRepositoryMock) ExpireContracts(before time.Time) (int64, error) {
+	if m.ExpireContractsFunc != nil {
+		return m.ExpireContractsFunc(before)
+	}
+	return 0, nil
+}
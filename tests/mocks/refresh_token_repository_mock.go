@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RefreshTokenRepositoryMock struct {
+	CreateFunc       func(ctx context.Context, token *model.RefreshToken) error
+	FindByHashFunc   func(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	MarkUsedFunc     func(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error)
+	RevokeFamilyFunc func(ctx context.Context, familyID primitive.ObjectID) error
+	RevokeByUserFunc func(ctx context.Context, userID primitive.ObjectID) error
+}
+
+func (m *RefreshTokenRepositoryMock) Create(ctx context.Context, token *model.RefreshToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *RefreshTokenRepositoryMock) FindByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	if m.FindByHashFunc != nil {
+		return m.FindByHashFunc(ctx, tokenHash)
+	}
+	return nil, nil
+}
+
+func (m *RefreshTokenRepositoryMock) MarkUsed(ctx context.Context, id, replacedBy primitive.ObjectID) (bool, error) {
+	if m.MarkUsedFunc != nil {
+		return m.MarkUsedFunc(ctx, id, replacedBy)
+	}
+	return true, nil
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	if m.RevokeFamilyFunc != nil {
+		return m.RevokeFamilyFunc(ctx, familyID)
+	}
+	return nil
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeByUser(ctx context.Context, userID primitive.ObjectID) error {
+	if m.RevokeByUserFunc != nil {
+		return m.RevokeByUserFunc(ctx, userID)
+	}
+	return nil
+}
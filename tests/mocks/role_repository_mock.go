@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"praktikum3/app/model"
+)
+
+// RoleRepositoryMock tanpa func yang di-set berperilaku seperti database berisi role bawaan
+type RoleRepositoryMock struct {
+	FindAllFunc        func(ctx context.Context) ([]model.Role, error)
+	FindByNameFunc     func(ctx context.Context, name string) (*model.Role, error)
+	CreateFunc         func(ctx context.Context, role *model.Role) error
+	UpdateFunc         func(ctx context.Context, name, description string, permissions []string) error
+	DeleteFunc         func(ctx context.Context, name string) error
+	EnsureDefaultsFunc func(ctx context.Context) error
+	PermissionsFunc    func(ctx context.Context, role string) ([]string, error)
+}
+
+func (m *RoleRepositoryMock) FindAll(ctx context.Context) ([]model.Role, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx)
+	}
+	return model.DefaultRoles(), nil
+}
+
+func (m *RoleRepositoryMock) FindByName(ctx context.Context, name string) (*model.Role, error) {
+	if m.FindByNameFunc != nil {
+		return m.FindByNameFunc(ctx, name)
+	}
+	for _, r := range model.DefaultRoles() {
+		if r.Name == name {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *RoleRepositoryMock) Create(ctx context.Context, role *model.Role) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, role)
+	}
+	return nil
+}
+
+func (m *RoleRepositoryMock) Update(ctx context.Context, name, description string, permissions []string) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, name, description, permissions)
+	}
+	return nil
+}
+
+func (m *RoleRepositoryMock) Delete(ctx context.Context, name string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, name)
+	}
+	return nil
+}
+
+func (m *RoleRepositoryMock) EnsureDefaults(ctx context.Context) error {
+	if m.EnsureDefaultsFunc != nil {
+		return m.EnsureDefaultsFunc(ctx)
+	}
+	return nil
+}
+
+func (m *RoleRepositoryMock) Permissions(ctx context.Context, role string) ([]string, error) {
+	if m.PermissionsFunc != nil {
+		return m.PermissionsFunc(ctx, role)
+	}
+	return model.DefaultPermissions(role), nil
+}
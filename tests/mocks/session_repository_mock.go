@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionRepositoryMock struct {
+	CreateFunc           func(ctx context.Context, session *model.Session) error
+	FindActiveByUserFunc func(ctx context.Context, userID primitive.ObjectID) ([]model.Session, error)
+	RefreshedFunc        func(ctx context.Context, id primitive.ObjectID, ip, userAgent string, expiresAt time.Time) error
+	TouchFunc            func(ctx context.Context, id primitive.ObjectID) error
+	RevokeFunc           func(ctx context.Context, id, userID primitive.ObjectID) (bool, error)
+	RevokeAllForUserFunc func(ctx context.Context, userID primitive.ObjectID) error
+}
+
+func (m *SessionRepositoryMock) Create(ctx context.Context, session *model.Session) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, session)
+	}
+	return nil
+}
+
+func (m *SessionRepositoryMock) FindActiveByUser(ctx context.Context, userID primitive.ObjectID) ([]model.Session, error) {
+	if m.FindActiveByUserFunc != nil {
+		return m.FindActiveByUserFunc(ctx, userID)
+	}
+	return []model.Session{}, nil
+}
+
+func (m *SessionRepositoryMock) Refreshed(ctx context.Context, id primitive.ObjectID, ip, userAgent string, expiresAt time.Time) error {
+	if m.RefreshedFunc != nil {
+		return m.RefreshedFunc(ctx, id, ip, userAgent, expiresAt)
+	}
+	return nil
+}
+
+func (m *SessionRepositoryMock) Touch(ctx context.Context, id primitive.ObjectID) error {
+	if m.TouchFunc != nil {
+		return m.TouchFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *SessionRepositoryMock) Revoke(ctx context.Context, id, userID primitive.ObjectID) (bool, error) {
+	if m.RevokeFunc != nil {
+		return m.RevokeFunc(ctx, id, userID)
+	}
+	return true, nil
+}
+
+func (m *SessionRepositoryMock) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error {
+	if m.RevokeAllForUserFunc != nil {
+		return m.RevokeAllForUserFunc(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"context"
+
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SurveyRepositoryMock struct {
+	CreateFunc               func(ctx context.Context, s *model.Survey) error
+	FindByIDFunc             func(ctx context.Context, id primitive.ObjectID) (*model.Survey, error)
+	FindAllFunc              func(ctx context.Context, code, status string) ([]model.Survey, error)
+	LatestVersionFunc        func(ctx context.Context, code string) (int, error)
+	UpdateDraftFunc          func(ctx context.Context, id primitive.ObjectID, req model.SurveyRequest) error
+	PublishFunc              func(ctx context.Context, id primitive.ObjectID, code string) error
+	CloseFunc                func(ctx context.Context, id primitive.ObjectID) (bool, error)
+	CreateResponseFunc       func(ctx context.Context, r *model.SurveyResponse) error
+	FindResponsesFunc        func(ctx context.Context, surveyID primitive.ObjectID) ([]model.SurveyResponse, error)
+	FindResponseByAlumniFunc func(ctx context.Context, surveyID, alumniID primitive.ObjectID) (*model.SurveyResponse, error)
+	StatsFunc                func(ctx context.Context, surveyID primitive.ObjectID) (model.SurveyStats, error)
+}
+
+func (m *SurveyRepositoryMock) Create(ctx context.Context, s *model.Survey) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, s)
+	}
+	return nil
+}
+
+func (m *SurveyRepositoryMock) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Survey, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *SurveyRepositoryMock) FindAll(ctx context.Context, code, status string) ([]model.Survey, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx, code, status)
+	}
+	return []model.Survey{}, nil
+}
+
+func (m *SurveyRepositoryMock) LatestVersion(ctx context.Context, code string) (int, error) {
+	if m.LatestVersionFunc != nil {
+		return m.LatestVersionFunc(ctx, code)
+	}
+	return 0, nil
+}
+
+func (m *SurveyRepositoryMock) UpdateDraft(ctx context.Context, id primitive.ObjectID, req model.SurveyRequest) error {
+	if m.UpdateDraftFunc != nil {
+		return m.UpdateDraftFunc(ctx, id, req)
+	}
+	return nil
+}
+
+func (m *SurveyRepositoryMock) Publish(ctx context.Context, id primitive.ObjectID, code string) error {
+	if m.PublishFunc != nil {
+		return m.PublishFunc(ctx, id, code)
+	}
+	return nil
+}
+
+func (m *SurveyRepositoryMock) Close(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	if m.CloseFunc != nil {
+		return m.CloseFunc(ctx, id)
+	}
+	return true, nil
+}
+
+func (m *SurveyRepositoryMock) CreateResponse(ctx context.Context, r *model.SurveyResponse) error {
+	if m.CreateResponseFunc != nil {
+		return m.CreateResponseFunc(ctx, r)
+	}
+	return nil
+}
+
+func (m *SurveyRepositoryMock) FindResponses(ctx context.Context, surveyID primitive.ObjectID) ([]model.SurveyResponse, error) {
+	if m.FindResponsesFunc != nil {
+		return m.FindResponsesFunc(ctx, surveyID)
+	}
+	return []model.SurveyResponse{}, nil
+}
+
+func (m *SurveyRepositoryMock) FindResponseByAlumni(ctx context.Context, surveyID, alumniID primitive.ObjectID) (*model.SurveyResponse, error) {
+	if m.FindResponseByAlumniFunc != nil {
+		return m.FindResponseByAlumniFunc(ctx, surveyID, alumniID)
+	}
+	return nil, nil
+}
+
+func (m *SurveyRepositoryMock) Stats(ctx context.Context, surveyID primitive.ObjectID) (model.SurveyStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, surveyID)
+	}
+	return model.SurveyStats{}, nil
+}
@@ -3,9 +3,9 @@ package mocks
 import "praktikum3/app/model"
 
 type TokenGeneratorMock struct {
-	GenerateFunc func(user model.User) (string, error)
+	GenerateFunc func(user model.User, sessionID string) (string, error)
 }
 
-func (m TokenGeneratorMock) Generate(user model.User) (string, error) {
-	return m.GenerateFunc(user)
+func (m TokenGeneratorMock) Generate(user model.User, sessionID string) (string, error) {
+	return m.GenerateFunc(user, sessionID)
 }
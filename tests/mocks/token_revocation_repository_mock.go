@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TokenRevocationRepositoryMock struct {
+	RevokeFunc    func(ctx context.Context, jti string, userID primitive.ObjectID, expiresAt time.Time) error
+	IsRevokedFunc func(ctx context.Context, jti string) (bool, error)
+
+	RevokeAllForUserFunc func(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error
+	IsUserRevokedFunc    func(ctx context.Context, userID primitive.ObjectID, issuedAt time.Time) (bool, error)
+}
+
+func (m *TokenRevocationRepositoryMock) Revoke(ctx context.Context, jti string, userID primitive.ObjectID, expiresAt time.Time) error {
+	if m.RevokeFunc != nil {
+		return m.RevokeFunc(ctx, jti, userID, expiresAt)
+	}
+	return nil
+}
+
+func (m *TokenRevocationRepositoryMock) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if m.IsRevokedFunc != nil {
+		return m.IsRevokedFunc(ctx, jti)
+	}
+	return false, nil
+}
+
+func (m *TokenRevocationRepositoryMock) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID, notBefore time.Time) error {
+	if m.RevokeAllForUserFunc != nil {
+		return m.RevokeAllForUserFunc(ctx, userID, notBefore)
+	}
+	return nil
+}
+
+func (m *TokenRevocationRepositoryMock) IsUserRevoked(ctx context.Context, userID primitive.ObjectID, issuedAt time.Time) (bool, error) {
+	if m.IsUserRevokedFunc != nil {
+		return m.IsUserRevokedFunc(ctx, userID, issuedAt)
+	}
+	return false, nil
+}
@@ -9,13 +9,181 @@ import (
 
 type UserRepositoryMock struct {
 	FindByUsernameOrEmailFunc func(ctx context.Context, username string) (*model.User, error)
+	FindByIDFunc              func(ctx context.Context, id primitive.ObjectID) (*model.User, error)
+	FindAllFunc               func(ctx context.Context, search string, limit, offset int) ([]model.User, error)
+	CountFunc                 func(ctx context.Context, search string) (int, error)
+	CountByRoleFunc           func(ctx context.Context, role string) (int, error)
+	CreateFunc                func(ctx context.Context, user *model.User) error
+	MarkEmailVerifiedFunc     func(ctx context.Context, id primitive.ObjectID) error
+	UpdateRoleFunc            func(ctx context.Context, id primitive.ObjectID, role string) error
+	UpdatePasswordFunc        func(ctx context.Context, id primitive.ObjectID, passwordHash string) error
+	UpdateEmailFunc           func(ctx context.Context, id primitive.ObjectID, email string) error
+	FindByAlumniIDFunc        func(ctx context.Context, alumniID primitive.ObjectID) (*model.User, error)
+	LinkAlumniFunc            func(ctx context.Context, id, alumniID primitive.ObjectID) error
+	UnlinkAlumniFunc          func(ctx context.Context, id, alumniID primitive.ObjectID) error
+	SetTOTPSecretFunc         func(ctx context.Context, id primitive.ObjectID, secret string) error
+	EnableTOTPFunc            func(ctx context.Context, id primitive.ObjectID, step int64, recoveryHashes []string) error
+	DisableTOTPFunc           func(ctx context.Context, id primitive.ObjectID) error
+	UseTOTPStepFunc           func(ctx context.Context, id primitive.ObjectID, step int64) (bool, error)
+	UseRecoveryCodeFunc       func(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error)
 	SoftDeleteUserFunc        func(ctx context.Context, id primitive.ObjectID) error
+	GetTrashedFunc            func(ctx context.Context) ([]model.User, error)
+	FindTrashedByIDFunc       func(ctx context.Context, id primitive.ObjectID) (*model.User, error)
+	RestoreFunc               func(ctx context.Context, id primitive.ObjectID) error
+	HardDeleteFunc            func(ctx context.Context, id primitive.ObjectID) error
 }
 
 func (m *UserRepositoryMock) FindByUsernameOrEmail(ctx context.Context, username string) (*model.User, error) {
 	return m.FindByUsernameOrEmailFunc(ctx, username)
 }
 
+func (m *UserRepositoryMock) FindByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *UserRepositoryMock) FindAll(ctx context.Context, search string, limit, offset int) ([]model.User, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx, search, limit, offset)
+	}
+	return []model.User{}, nil
+}
+
+func (m *UserRepositoryMock) Count(ctx context.Context, search string) (int, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc(ctx, search)
+	}
+	return 0, nil
+}
+
+func (m *UserRepositoryMock) Create(ctx context.Context, user *model.User) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) MarkEmailVerified(ctx context.Context, id primitive.ObjectID) error {
+	if m.MarkEmailVerifiedFunc != nil {
+		return m.MarkEmailVerifiedFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) UpdateRole(ctx context.Context, id primitive.ObjectID, role string) error {
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(ctx, id, role)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error {
+	if m.UpdatePasswordFunc != nil {
+		return m.UpdatePasswordFunc(ctx, id, passwordHash)
+	}
+	return nil
+}
+
 func (m *UserRepositoryMock) SoftDeleteUser(ctx context.Context, id primitive.ObjectID) error {
 	return m.SoftDeleteUserFunc(ctx, id)
 }
+
+func (m *UserRepositoryMock) GetTrashed(ctx context.Context) ([]model.User, error) {
+	if m.GetTrashedFunc != nil {
+		return m.GetTrashedFunc(ctx)
+	}
+	return []model.User{}, nil
+}
+
+func (m *UserRepositoryMock) FindTrashedByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	if m.FindTrashedByIDFunc != nil {
+		return m.FindTrashedByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *UserRepositoryMock) Restore(ctx context.Context, id primitive.ObjectID) error {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) HardDelete(ctx context.Context, id primitive.ObjectID) error {
+	if m.HardDeleteFunc != nil {
+		return m.HardDeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) FindByAlumniID(ctx context.Context, alumniID primitive.ObjectID) (*model.User, error) {
+	if m.FindByAlumniIDFunc != nil {
+		return m.FindByAlumniIDFunc(ctx, alumniID)
+	}
+	return nil, nil
+}
+
+func (m *UserRepositoryMock) LinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error {
+	if m.LinkAlumniFunc != nil {
+		return m.LinkAlumniFunc(ctx, id, alumniID)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) UnlinkAlumni(ctx context.Context, id, alumniID primitive.ObjectID) error {
+	if m.UnlinkAlumniFunc != nil {
+		return m.UnlinkAlumniFunc(ctx, id, alumniID)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) CountByRole(ctx context.Context, role string) (int, error) {
+	if m.CountByRoleFunc != nil {
+		return m.CountByRoleFunc(ctx, role)
+	}
+	return 0, nil
+}
+
+func (m *UserRepositoryMock) SetTOTPSecret(ctx context.Context, id primitive.ObjectID, secret string) error {
+	if m.SetTOTPSecretFunc != nil {
+		return m.SetTOTPSecretFunc(ctx, id, secret)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) EnableTOTP(ctx context.Context, id primitive.ObjectID, step int64, recoveryHashes []string) error {
+	if m.EnableTOTPFunc != nil {
+		return m.EnableTOTPFunc(ctx, id, step, recoveryHashes)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) DisableTOTP(ctx context.Context, id primitive.ObjectID) error {
+	if m.DisableTOTPFunc != nil {
+		return m.DisableTOTPFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *UserRepositoryMock) UseTOTPStep(ctx context.Context, id primitive.ObjectID, step int64) (bool, error) {
+	if m.UseTOTPStepFunc != nil {
+		return m.UseTOTPStepFunc(ctx, id, step)
+	}
+	return true, nil
+}
+
+func (m *UserRepositoryMock) UseRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	if m.UseRecoveryCodeFunc != nil {
+		return m.UseRecoveryCodeFunc(ctx, id, codeHash)
+	}
+	return false, nil
+}
+
+func (m *UserRepositoryMock) UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error {
+	if m.UpdateEmailFunc != nil {
+		return m.UpdateEmailFunc(ctx, id, email)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"praktikum3/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserTokenRepositoryMock struct {
+	CreateFunc       func(ctx context.Context, token *model.UserToken) error
+	FindValidFunc    func(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error)
+	MarkUsedFunc     func(ctx context.Context, id primitive.ObjectID) (bool, error)
+	ReleaseFunc      func(ctx context.Context, id primitive.ObjectID) error
+	DeleteByUserFunc func(ctx context.Context, userID primitive.ObjectID, purpose string) error
+}
+
+func (m *UserTokenRepositoryMock) Create(ctx context.Context, token *model.UserToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *UserTokenRepositoryMock) FindValid(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+	if m.FindValidFunc != nil {
+		return m.FindValidFunc(ctx, purpose, tokenHash)
+	}
+	return nil, nil
+}
+
+func (m *UserTokenRepositoryMock) MarkUsed(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	if m.MarkUsedFunc != nil {
+		return m.MarkUsedFunc(ctx, id)
+	}
+	return true, nil
+}
+
+func (m *UserTokenRepositoryMock) Release(ctx context.Context, id primitive.ObjectID) error {
+	if m.ReleaseFunc != nil {
+		return m.ReleaseFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *UserTokenRepositoryMock) DeleteByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	if m.DeleteByUserFunc != nil {
+		return m.DeleteByUserFunc(ctx, userID, purpose)
+	}
+	return nil
+}
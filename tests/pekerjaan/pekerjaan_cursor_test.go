@@ -0,0 +1,55 @@
+package pekerjaan_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================== CURSOR ==========================
+func TestGetAll_CursorMode(t *testing.T) {
+	var gotQ model.PekerjaanQuery
+	var gotCursor string
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllByCursorFunc: func(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error) {
+			gotQ, gotCursor = q, cursor
+			return []model.Pekerjaan{{NamaPerusahaan: "PT A"}}, "next", nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/pekerjaan?cursor=abc&sortBy=nama_perusahaan&order=ASC&limit=500", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "nama_perusahaan", gotQ.SortBy)
+	assert.Equal(t, "ASC", gotQ.Order)
+	assert.Equal(t, model.MaxPekerjaanLimit, gotQ.Limit)
+	assert.Equal(t, "abc", gotCursor)
+
+	var body struct {
+		Meta model.CursorMeta `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "next", body.Meta.NextCursor)
+	assert.True(t, body.Meta.HasMore)
+}
+
+func TestGetAll_CursorInvalidSortBy(t *testing.T) {
+	resp, _ := setupTestApp(&mocks.PekerjaanRepositoryMock{}).Test(httptest.NewRequest("GET", "/pekerjaan?cursor=&sortBy=alumni_id;drop", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestGetAll_InvalidCursor(t *testing.T) {
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllByCursorFunc: func(q model.PekerjaanQuery, cursor string) ([]model.Pekerjaan, string, error) {
+			return nil, "", repository.ErrInvalidCursor
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/pekerjaan?cursor=rusak", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
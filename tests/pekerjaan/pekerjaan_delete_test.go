@@ -0,0 +1,75 @@
+package pekerjaan_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// respons CountDocuments (aggregate $count); n = 0 berarti batch kosong
+func countResponse(n int) bson.D {
+	if n == 0 {
+		return mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch)
+	}
+	return mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+}
+
+// ========================== SOFT DELETE BY USER ==========================
+func TestSoftDeleteByUser_NotOwnedAndMissing(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	notMatched := mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0})
+
+	mt.Run("milik alumni lain", func(mt *mtest.T) {
+		mt.AddMockResponses(notMatched, countResponse(0), countResponse(1))
+		err := repository.NewPekerjaanRepository(mt.DB).SoftDeleteByUser(primitive.NewObjectID(), primitive.NewObjectID(), repository.AnyVersion)
+		assert.Equal(mt, repository.ErrNotOwner, err)
+	})
+
+	mt.Run("tidak ada", func(mt *mtest.T) {
+		mt.AddMockResponses(notMatched, countResponse(0), countResponse(0))
+		err := repository.NewPekerjaanRepository(mt.DB).SoftDeleteByUser(primitive.NewObjectID(), primitive.NewObjectID(), repository.AnyVersion)
+		assert.Equal(mt, repository.ErrNotFound, err)
+	})
+
+	mt.Run("versi berubah", func(mt *mtest.T) {
+		mt.AddMockResponses(notMatched, countResponse(1))
+		err := repository.NewPekerjaanRepository(mt.DB).SoftDeleteByUser(primitive.NewObjectID(), primitive.NewObjectID(), 3)
+		assert.Equal(mt, repository.ErrVersionConflict, err)
+	})
+}
+
+func TestSoftDelete_UserNotOwner(t *testing.T) {
+	repo := &mocks.PekerjaanRepositoryMock{
+		SoftDeleteByUserFunc: func(id, alumniID primitive.ObjectID, version int64) error {
+			return repository.ErrNotOwner
+		},
+	}
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", map[string]interface{}{"id": primitive.NewObjectID().Hex(), "role": "user", "alumni_id": primitive.NewObjectID().Hex()})
+		return c.Next()
+	})
+	app.Delete("/pekerjaan/:id", service.NewPekerjaanService(repo).SoftDelete)
+
+	req := httptest.NewRequest("DELETE", "/pekerjaan/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"1"`)
+	resp, _ := app.Test(req)
+	assert.Equal(t, 403, resp.StatusCode)
+
+	repo.SoftDeleteByUserFunc = func(id, alumniID primitive.ObjectID, version int64) error {
+		return repository.ErrNotFound
+	}
+	req = httptest.NewRequest("DELETE", "/pekerjaan/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"1"`)
+	resp, _ = app.Test(req)
+	assert.Equal(t, 404, resp.StatusCode)
+}
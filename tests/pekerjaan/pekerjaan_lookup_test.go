@@ -0,0 +1,49 @@
+package pekerjaan_test
+
+import (
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// ========================== ALUMNI LOOKUP ==========================
+func TestGetAllWithQuery_AlumniLookupAcceptsLegacyStringID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filter jurusan", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.pekerjaan_alumni", mtest.FirstBatch))
+
+		q := model.PekerjaanQuery{SortBy: "created_at", Order: "DESC", Limit: 10, Filters: model.PekerjaanFilters{Jurusan: "Teknik Informatika"}}
+		_, err := repository.NewPekerjaanRepository(mt.DB).GetAllWithQuery(q)
+		assert.NoError(mt, err)
+
+		ev := mt.GetStartedEvent()
+		if !assert.NotNil(mt, ev) || !assert.Equal(mt, "aggregate", ev.CommandName) {
+			return
+		}
+		var cmd struct {
+			Pipeline []bson.M `bson:"pipeline"`
+		}
+		assert.NoError(mt, bson.Unmarshal(ev.Command, &cmd))
+
+		var lookup bson.M
+		for _, stage := range cmd.Pipeline {
+			if l, ok := stage["$lookup"].(bson.M); ok {
+				lookup = l
+			}
+		}
+		if assert.NotNil(mt, lookup) {
+			// join lewat let + $convert, bukan localField yang hanya cocok dengan ObjectID
+			assert.NotContains(mt, lookup, "localField")
+			let := lookup["let"].(bson.M)
+			conv := let["alumni_id"].(bson.M)["$convert"].(bson.M)
+			assert.Equal(mt, "$alumni_id", conv["input"])
+			assert.Equal(mt, "objectId", conv["to"])
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package pekerjaan_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func patchPekerjaan(repo *mocks.PekerjaanRepositoryMock, id, body string) int {
+	app := fiber.New()
+	app.Patch("/pekerjaan/:id", service.NewPekerjaanService(repo).Patch)
+
+	req := httptest.NewRequest("PATCH", "/pekerjaan/"+id, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "*")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ========================== PATCH ==========================
+func TestPatch_KeepsStartDate(t *testing.T) {
+	id := primitive.NewObjectID()
+	start := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	gaji := "5-10 juta"
+	var gotSet, gotUnset []string
+	var got *model.Pekerjaan
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Pekerjaan, error) {
+			return &model.Pekerjaan{ID: oid, NamaPerusahaan: "PT A", GajiRange: &gaji, TanggalMulaiKerja: &start}, nil
+		},
+		PatchFunc: func(oid primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error {
+			got, gotSet, gotUnset = p, set, unset
+			return nil
+		},
+	}
+
+	status := patchPekerjaan(repo, id.Hex(), `{"posisi_jabatan":"Backend Engineer","tanggal_selesai_kerja":"2024-06-30","gaji_range":null}`)
+	assert.Equal(t, 200, status)
+	assert.ElementsMatch(t, []string{"posisi_jabatan", "tanggal_selesai_kerja"}, gotSet)
+	assert.ElementsMatch(t, []string{"gaji_range"}, gotUnset)
+	if assert.NotNil(t, got) && assert.NotNil(t, got.TanggalSelesaiKerja) {
+		assert.Equal(t, "Backend Engineer", got.PosisiJabatan)
+		assert.Equal(t, "2024-06-30", got.TanggalSelesaiKerja.Format("2006-01-02"))
+		assert.Nil(t, got.GajiRange)
+	}
+}
+
+func TestPatch_Invalid(t *testing.T) {
+	deleted := time.Now()
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetByIDFunc: func(oid primitive.ObjectID) (*model.Pekerjaan, error) {
+			return &model.Pekerjaan{ID: oid, DeletedAt: &deleted}, nil
+		},
+	}
+	id := primitive.NewObjectID().Hex()
+
+	assert.Equal(t, 400, patchPekerjaan(repo, "INVALID", `{}`))
+	assert.Equal(t, 422, patchPekerjaan(repo, id, `{"alumni_id":"x"}`))
+	assert.Equal(t, 422, patchPekerjaan(repo, id, `{"tanggal_mulai_kerja":null}`))
+	assert.Equal(t, 404, patchPekerjaan(repo, id, `{"lokasi_kerja":"Bandung"}`))
+
+	repo.GetByIDFunc = func(oid primitive.ObjectID) (*model.Pekerjaan, error) {
+		return &model.Pekerjaan{ID: oid}, nil
+	}
+	assert.Equal(t, 400, patchPekerjaan(repo, id, `{"tanggal_mulai_kerja":"10-01-2022"}`))
+}
@@ -0,0 +1,146 @@
+package pekerjaan_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================== QUERY ==========================
+func TestGetAll_QuerySpec(t *testing.T) {
+	var got, counted model.PekerjaanQuery
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
+			got = q
+			return []model.Pekerjaan{}, nil
+		},
+		CountFunc: func(q model.PekerjaanQuery) (int, error) {
+			counted = q
+			return 250, nil
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/pekerjaan?search=%20(a%2B)%2B%20&searchMode=PREFIX&sortBy=tanggal_mulai_kerja&order=asc&page=2&limit=1000", nil)
+	resp, _ := setupTestApp(repo).Test(req)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	want := model.PekerjaanQuery{
+		Search:     "(a+)+",
+		SearchMode: model.SearchPrefix,
+		SortBy:     "tanggal_mulai_kerja",
+		Order:      "ASC",
+		Limit:      model.MaxPekerjaanLimit,
+		Offset:     model.MaxPekerjaanLimit,
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, want, counted)
+
+	var body struct {
+		Meta model.MetaInfo `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, model.MetaInfo{Page: 2, Limit: 100, Total: 250, Pages: 3, SortBy: "tanggal_mulai_kerja", Order: "ASC", Search: "(a+)+", Filters: map[string]interface{}{}}, body.Meta)
+}
+
+func TestGetAll_QueryDefaults(t *testing.T) {
+	var got model.PekerjaanQuery
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
+			got = q
+			return []model.Pekerjaan{}, nil
+		},
+	}
+
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/pekerjaan", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, model.PekerjaanQuery{SearchMode: model.SearchContains, SortBy: "created_at", Order: "DESC", Limit: 10}, got)
+}
+
+func TestGetAll_InvalidQuery(t *testing.T) {
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
+			t.Fatal("repository tidak boleh dipanggil untuk query tidak valid")
+			return nil, nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	for _, url := range []string{
+		"/pekerjaan?sortBy=deskripsi_pekerjaan",
+		"/pekerjaan?sortBy=%24where",
+		"/pekerjaan?order=random",
+		"/pekerjaan?searchMode=regex",
+		"/pekerjaan?limit=0",
+		"/pekerjaan?limit=sepuluh",
+		"/pekerjaan?page=-1",
+		"/pekerjaan?search=" + strings.Repeat("a", model.MaxPekerjaanSearch+1),
+		"/pekerjaan?mulai_dari=01-02-2020",
+		"/pekerjaan?mulai_dari=2021-01-01&mulai_sampai=2020-12-31",
+		"/pekerjaan?selesai_sampai=kemarin",
+		"/pekerjaan?angkatan=abc",
+	} {
+		resp, _ := app.Test(httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, 400, resp.StatusCode, url)
+	}
+}
+
+// ========================== FILTER ==========================
+func TestGetAll_Filters(t *testing.T) {
+	var got, counted model.PekerjaanFilters
+	repo := &mocks.PekerjaanRepositoryMock{
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
+			got = q.Filters
+			return []model.Pekerjaan{}, nil
+		},
+		CountFunc: func(q model.PekerjaanQuery) (int, error) {
+			counted = q.Filters
+			return 0, nil
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/pekerjaan?status_pekerjaan=aktif&bidang_industri=Teknologi&lokasi_kerja=%20Jakarta%20&mulai_dari=2020-01-01&mulai_sampai=2020-12-31&selesai_dari=2023-06-01&jurusan=Teknik%20Informatika&angkatan=2018", nil)
+	resp, _ := setupTestApp(repo).Test(req)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+	want := model.PekerjaanFilters{
+		StatusPekerjaan: "aktif",
+		BidangIndustri:  "Teknologi",
+		LokasiKerja:     "Jakarta",
+		MulaiDari:       date("2020-01-01"),
+		MulaiSampai:     date("2020-12-31"),
+		SelesaiDari:     date("2023-06-01"),
+		Jurusan:         "Teknik Informatika",
+		Angkatan:        2018,
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, want, counted)
+	assert.True(t, got.ByAlumni())
+
+	var body struct {
+		Meta struct {
+			Filters map[string]interface{} `json:"filters"`
+		} `json:"meta"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "aktif", body.Meta.Filters["status_pekerjaan"])
+	assert.Equal(t, "Teknik Informatika", body.Meta.Filters["jurusan"])
+	assert.Equal(t, float64(2018), body.Meta.Filters["angkatan"])
+	assert.Equal(t, "2020-01-01T00:00:00Z", body.Meta.Filters["mulai_dari"])
+	assert.NotContains(t, body.Meta.Filters, "selesai_sampai")
+}
+
+func TestGetAll_FiltersWithoutAlumni(t *testing.T) {
+	assert.False(t, model.PekerjaanFilters{StatusPekerjaan: "aktif"}.ByAlumni())
+	assert.True(t, model.PekerjaanFilters{Angkatan: 2019}.ByAlumni())
+}
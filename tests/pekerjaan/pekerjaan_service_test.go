@@ -3,6 +3,7 @@ package pekerjaan_test
 import (
 	"bytes"
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -50,7 +51,7 @@ func setupTestApp(repo *mocks.PekerjaanRepositoryMock) *fiber.App {
 // ==============================================================
 func TestGetAll_RepoError(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		GetAllWithQueryFunc: func(s1, s2, s3 string, l, o int) ([]model.Pekerjaan, error) {
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
 			return nil, errors.New("db error")
 		},
 	}
@@ -64,10 +65,10 @@ func TestGetAll_RepoError(t *testing.T) {
 
 func TestGetAll_CountError(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		GetAllWithQueryFunc: func(s1, s2, s3 string, l, o int) ([]model.Pekerjaan, error) {
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
 			return []model.Pekerjaan{}, nil
 		},
-		CountFunc: func(s string) (int, error) {
+		CountFunc: func(q model.PekerjaanQuery) (int, error) {
 			return 0, errors.New("count error")
 		},
 	}
@@ -81,10 +82,10 @@ func TestGetAll_CountError(t *testing.T) {
 
 func TestGetAll_Success(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		GetAllWithQueryFunc: func(s1, s2, s3 string, l, o int) ([]model.Pekerjaan, error) {
+		GetAllWithQueryFunc: func(q model.PekerjaanQuery) ([]model.Pekerjaan, error) {
 			return []model.Pekerjaan{}, nil
 		},
-		CountFunc: func(s string) (int, error) {
+		CountFunc: func(q model.PekerjaanQuery) (int, error) {
 			return 10, nil
 		},
 	}
@@ -242,7 +243,8 @@ func TestCreate_RepoError(t *testing.T) {
 	body := `{
 		"alumni_id":"` + primitive.NewObjectID().Hex() + `",
 		"nama_perusahaan":"X",
-		"tanggal_mulai_kerja":"2020-01-01"
+		"tanggal_mulai_kerja":"2020-01-01",
+		"status_pekerjaan":"aktif"
 	}`
 
 	req := httptest.NewRequest("POST", "/pekerjaan", bytes.NewBufferString(body))
@@ -264,7 +266,8 @@ func TestCreate_Success(t *testing.T) {
 	body := `{
 		"alumni_id":"` + primitive.NewObjectID().Hex() + `",
 		"nama_perusahaan":"X",
-		"tanggal_mulai_kerja":"2020-01-01"
+		"tanggal_mulai_kerja":"2020-01-01",
+		"status_pekerjaan":"aktif"
 	}`
 
 	req := httptest.NewRequest("POST", "/pekerjaan", bytes.NewBufferString(body))
@@ -313,7 +316,10 @@ func TestUpdate_InvalidStartDate(t *testing.T) {
 
 func TestUpdate_RepoError(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		UpdateFunc: func(id primitive.ObjectID, in model.UpdatePekerjaanReq, a, b *time.Time) error {
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Pekerjaan, error) {
+			return &model.Pekerjaan{ID: id, StatusPekerjaan: "aktif"}, nil
+		},
+		UpdateFunc: func(id primitive.ObjectID, in model.UpdatePekerjaanReq, a, b *time.Time, version int64) error {
 			return errors.New("update error")
 		},
 	}
@@ -321,11 +327,13 @@ func TestUpdate_RepoError(t *testing.T) {
 	app := setupTestApp(repo)
 
 	body := `{
-		"tanggal_mulai_kerja":"2020-01-01"
+		"tanggal_mulai_kerja":"2020-01-01",
+		"status_pekerjaan":"selesai"
 	}`
 
 	req := httptest.NewRequest("PUT", "/pekerjaan/"+primitive.NewObjectID().Hex(), bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"0"`)
 
 	resp, _ := app.Test(req)
 	assert.Equal(t, 500, resp.StatusCode)
@@ -333,7 +341,10 @@ func TestUpdate_RepoError(t *testing.T) {
 
 func TestUpdate_Success(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		UpdateFunc: func(id primitive.ObjectID, in model.UpdatePekerjaanReq, a, b *time.Time) error {
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Pekerjaan, error) {
+			return &model.Pekerjaan{ID: id, StatusPekerjaan: "aktif"}, nil
+		},
+		UpdateFunc: func(id primitive.ObjectID, in model.UpdatePekerjaanReq, a, b *time.Time, version int64) error {
 			return nil
 		},
 	}
@@ -341,11 +352,13 @@ func TestUpdate_Success(t *testing.T) {
 	app := setupTestApp(repo)
 
 	body := `{
-		"tanggal_mulai_kerja":"2020-01-01"
+		"tanggal_mulai_kerja":"2020-01-01",
+		"status_pekerjaan":"selesai"
 	}`
 
 	req := httptest.NewRequest("PUT", "/pekerjaan/"+primitive.NewObjectID().Hex(), bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"0"`)
 
 	resp, _ := app.Test(req)
 	assert.Equal(t, 200, resp.StatusCode)
@@ -366,7 +379,7 @@ func TestSoftDelete_InvalidID(t *testing.T) {
 
 func TestSoftDelete_AdminError(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		SoftDeleteByAdminFunc: func(id primitive.ObjectID) error {
+		SoftDeleteByAdminFunc: func(id primitive.ObjectID, version int64) error {
 			return errors.New("delete error")
 		},
 	}
@@ -374,6 +387,7 @@ func TestSoftDelete_AdminError(t *testing.T) {
 	app := setupTestApp(repo)
 
 	req := httptest.NewRequest("DELETE", "/pekerjaan/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"0"`)
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, 500, resp.StatusCode)
@@ -381,7 +395,7 @@ func TestSoftDelete_AdminError(t *testing.T) {
 
 func TestSoftDelete_AdminSuccess(t *testing.T) {
 	repo := &mocks.PekerjaanRepositoryMock{
-		SoftDeleteByAdminFunc: func(id primitive.ObjectID) error {
+		SoftDeleteByAdminFunc: func(id primitive.ObjectID, version int64) error {
 			return nil
 		},
 	}
@@ -389,11 +403,53 @@ func TestSoftDelete_AdminSuccess(t *testing.T) {
 	app := setupTestApp(repo)
 
 	req := httptest.NewRequest("DELETE", "/pekerjaan/"+primitive.NewObjectID().Hex(), nil)
+	req.Header.Set("If-Match", `"0"`)
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestSoftDelete_UserUsesLinkedAlumni(t *testing.T) {
+	userID := primitive.NewObjectID()
+	alumniID := primitive.NewObjectID()
+
+	var gotAlumniID primitive.ObjectID
+	repo := &mocks.PekerjaanRepositoryMock{
+		SoftDeleteByUserFunc: func(id, alumni primitive.ObjectID, version int64) error {
+			gotAlumniID = alumni
+			return nil
+		},
+	}
+
+	newApp := func(user map[string]interface{}) *fiber.App {
+		app := fiber.New()
+		s := service.NewPekerjaanService(repo)
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		})
+		app.Delete("/pekerjaan/:id", s.SoftDelete)
+		return app
+	}
+
+	deleteReq := func() *http.Request {
+		req := httptest.NewRequest("DELETE", "/pekerjaan/"+primitive.NewObjectID().Hex(), nil)
+		req.Header.Set("If-Match", `"1"`)
+		return req
+	}
+
+	// belum terhubung ke data alumni -> 403
+	app := newApp(map[string]interface{}{"id": userID.Hex(), "role": "user", "alumni_id": ""})
+	resp, _ := app.Test(deleteReq())
+	assert.Equal(t, 403, resp.StatusCode)
+
+	// kepemilikan memakai alumni_id, bukan user id
+	app = newApp(map[string]interface{}{"id": userID.Hex(), "role": "user", "alumni_id": alumniID.Hex()})
+	resp, _ = app.Test(deleteReq())
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, alumniID, gotAlumniID)
+}
+
 // ==============================================================
 //                         RESTORE
 // ==============================================================
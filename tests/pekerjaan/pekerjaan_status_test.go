@@ -0,0 +1,200 @@
+package pekerjaan_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func sendPekerjaan(repo *mocks.PekerjaanRepositoryMock, method, path, body string) (int, map[string]string) {
+	s := service.NewPekerjaanService(repo)
+	app := fiber.New()
+	app.Post("/pekerjaan", s.Create)
+	app.Put("/pekerjaan/:id", s.Update)
+	app.Patch("/pekerjaan/:id", s.Patch)
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "*")
+	resp, _ := app.Test(req)
+
+	var out struct {
+		Errors map[string]string `json:"errors"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out.Errors
+}
+
+func existingPekerjaan(status string, selesai *time.Time) *mocks.PekerjaanRepositoryMock {
+	mulai := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &mocks.PekerjaanRepositoryMock{
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Pekerjaan, error) {
+			return &model.Pekerjaan{ID: id, StatusPekerjaan: status, TanggalMulaiKerja: &mulai, TanggalSelesaiKerja: selesai}, nil
+		},
+	}
+}
+
+func futureDate() string {
+	return time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+}
+
+// ========================== CREATE ==========================
+func TestCreate_StatusValidation(t *testing.T) {
+	var got model.CreatePekerjaanReq
+	repo := &mocks.PekerjaanRepositoryMock{
+		CreateFunc: func(in model.CreatePekerjaanReq, mulai, selesai *time.Time) (primitive.ObjectID, error) {
+			got = in
+			return primitive.NewObjectID(), nil
+		},
+	}
+	create := func(extra string) (int, map[string]string) {
+		return sendPekerjaan(repo, "POST", "/pekerjaan", `{"nama_perusahaan":"PT A","tanggal_mulai_kerja":"2020-01-01"`+extra+`}`)
+	}
+
+	status, errs := create(``)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "status_pekerjaan")
+
+	status, errs = create(`,"status_pekerjaan":"kontrak"`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "status_pekerjaan")
+
+	status, _ = create(`,"status_pekerjaan":" Aktif "`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, model.StatusPekerjaanAktif, got.StatusPekerjaan)
+}
+
+func TestCreate_DateConsistency(t *testing.T) {
+	repo := &mocks.PekerjaanRepositoryMock{}
+	create := func(body string) (int, map[string]string) {
+		return sendPekerjaan(repo, "POST", "/pekerjaan", body)
+	}
+
+	status, errs := create(`{"status_pekerjaan":"selesai","tanggal_mulai_kerja":"2021-05-01","tanggal_selesai_kerja":"2021-04-30"}`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "tanggal_selesai_kerja")
+
+	// aktif dengan tanggal selesai yang sudah lewat harusnya selesai
+	status, errs = create(`{"status_pekerjaan":"aktif","tanggal_mulai_kerja":"2020-01-01","tanggal_selesai_kerja":"2021-01-01"}`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "tanggal_selesai_kerja")
+
+	// kontrak yang belum habis boleh
+	status, _ = create(`{"status_pekerjaan":"aktif","tanggal_mulai_kerja":"2020-01-01","tanggal_selesai_kerja":"` + futureDate() + `"}`)
+	assert.Equal(t, 200, status)
+
+	status, _ = create(`{"status_pekerjaan":"resigned","tanggal_mulai_kerja":"2020-01-01","tanggal_selesai_kerja":"2020-01-01"}`)
+	assert.Equal(t, 200, status)
+}
+
+// ========================== UPDATE ==========================
+func TestUpdate_StatusTransitions(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	update := func(repo *mocks.PekerjaanRepositoryMock, status string) int {
+		code, _ := sendPekerjaan(repo, "PUT", "/pekerjaan/"+id, `{"tanggal_mulai_kerja":"2020-01-01","status_pekerjaan":"`+status+`"}`)
+		return code
+	}
+
+	assert.Equal(t, 200, update(existingPekerjaan("aktif", nil), "resigned"))
+	assert.Equal(t, 200, update(existingPekerjaan("selesai", nil), "resigned"))
+	assert.Equal(t, 200, update(existingPekerjaan("selesai", nil), "selesai"))
+	assert.Equal(t, 422, update(existingPekerjaan("selesai", nil), "aktif"))
+	assert.Equal(t, 422, update(existingPekerjaan("resigned", nil), "aktif"))
+	// status lama teks bebas boleh dirapikan ke status valid
+	assert.Equal(t, 200, update(existingPekerjaan("Kontrak", nil), "aktif"))
+}
+
+func TestUpdate_NotFound(t *testing.T) {
+	status, _ := sendPekerjaan(&mocks.PekerjaanRepositoryMock{}, "PUT", "/pekerjaan/"+primitive.NewObjectID().Hex(), `{"tanggal_mulai_kerja":"2020-01-01","status_pekerjaan":"aktif"}`)
+	assert.Equal(t, 404, status)
+}
+
+// ========================== PATCH ==========================
+func TestPatch_StatusAndDates(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	lewat := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	status, errs := sendPekerjaan(existingPekerjaan("selesai", &lewat), "PATCH", "/pekerjaan/"+id, `{"status_pekerjaan":"aktif"}`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "status_pekerjaan")
+
+	// tanggal selesai tersimpan sudah lewat, jadi pekerjaan tidak bisa tetap aktif
+	status, errs = sendPekerjaan(existingPekerjaan("aktif", nil), "PATCH", "/pekerjaan/"+id, `{"tanggal_selesai_kerja":"2022-01-01"}`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "tanggal_selesai_kerja")
+
+	status, errs = sendPekerjaan(existingPekerjaan("aktif", nil), "PATCH", "/pekerjaan/"+id, `{"tanggal_selesai_kerja":"2019-12-31","status_pekerjaan":"selesai"}`)
+	assert.Equal(t, 422, status)
+	assert.Contains(t, errs, "tanggal_selesai_kerja")
+
+	var got *model.Pekerjaan
+	repo := existingPekerjaan("aktif", nil)
+	repo.PatchFunc = func(oid primitive.ObjectID, p *model.Pekerjaan, set, unset []string, version int64) error {
+		got = p
+		return nil
+	}
+	status, _ = sendPekerjaan(repo, "PATCH", "/pekerjaan/"+id, `{"tanggal_selesai_kerja":"2022-01-01","status_pekerjaan":"Selesai"}`)
+	assert.Equal(t, 200, status)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, model.StatusPekerjaanSelesai, got.StatusPekerjaan)
+	}
+
+	// field lain tidak memicu validasi status lama
+	status, _ = sendPekerjaan(existingPekerjaan("Kontrak", nil), "PATCH", "/pekerjaan/"+id, `{"lokasi_kerja":"Bandung"}`)
+	assert.Equal(t, 200, status)
+}
+
+// ========================== CONTRACT EXPIRY ==========================
+func TestStartContractExpiry(t *testing.T) {
+	calls := make(chan time.Time, 10)
+	repo := &mocks.PekerjaanRepositoryMock{
+		ExpireContractsFunc: func(before time.Time) (int64, error) {
+			calls <- before
+			return 1, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.StartContractExpiry(ctx, repo, 10*time.Millisecond)
+
+	// langsung jalan sekali saat start, lalu tiap interval
+	for i := 0; i < 2; i++ {
+		select {
+		case before := <-calls:
+			now := time.Now().UTC()
+			assert.Equal(t, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), before)
+		case <-time.After(time.Second):
+			t.Fatal("ExpireContracts tidak dipanggil")
+		}
+	}
+
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	for len(calls) > 0 {
+		<-calls
+	}
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, calls)
+}
+
+func TestContractExpiryInterval(t *testing.T) {
+	t.Setenv("CONTRACT_EXPIRY_INTERVAL", "")
+	assert.Equal(t, time.Hour, service.ContractExpiryInterval())
+
+	t.Setenv("CONTRACT_EXPIRY_INTERVAL", "15m")
+	assert.Equal(t, 15*time.Minute, service.ContractExpiryInterval())
+
+	t.Setenv("CONTRACT_EXPIRY_INTERVAL", "sebentar")
+	assert.Equal(t, time.Hour, service.ContractExpiryInterval())
+}
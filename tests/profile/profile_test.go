@@ -0,0 +1,165 @@
+package profile_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupTestApp(users *mocks.UserRepositoryMock, alumni *mocks.AlumniRepositoryMock, userID primitive.ObjectID) *fiber.App {
+	return setupTestAppWithMail(users, alumni, &mocks.UserTokenRepositoryMock{}, &mocks.EmailSenderMock{}, userID)
+}
+
+func setupTestAppWithMail(users *mocks.UserRepositoryMock, alumni *mocks.AlumniRepositoryMock, tokens *mocks.UserTokenRepositoryMock, outbox *mocks.EmailSenderMock, userID primitive.ObjectID) *fiber.App {
+	app := fiber.New()
+	pw := mocks.PasswordCheckerMock{CheckFunc: func(hash, password string) bool { return hash == password }}
+	s := service.NewProfileService(users, alumni, tokens, pw, outbox)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: userID.Hex(), Role: model.RoleUser})
+		return c.Next()
+	})
+	app.Get("/me", s.GetMe)
+	app.Put("/me", s.UpdateMe)
+	app.Get("/me/alumni", s.GetMyAlumni)
+	app.Put("/me/alumni", s.UpdateMyAlumni)
+	return app
+}
+
+func send(app *fiber.App, method, url string, v interface{}) int {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ===========================
+// PROFILE: GET & PUT /me
+// ===========================
+func TestUpdateMe(t *testing.T) {
+	userID := primitive.NewObjectID()
+	user := &model.User{ID: userID, Username: "budi", Email: "budi@example.com", PasswordHash: "rahasia123", Role: model.RoleUser}
+	users := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			if id != userID {
+				return nil, nil
+			}
+			return user, nil
+		},
+		FindByUsernameOrEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			if email == "taken@example.com" {
+				return &model.User{ID: primitive.NewObjectID(), Email: email}, nil
+			}
+			return nil, nil
+		},
+		UpdateEmailFunc: func(ctx context.Context, id primitive.ObjectID, email string) error {
+			t.Fatal("email tidak boleh langsung diganti sebelum dikonfirmasi")
+			return nil
+		},
+	}
+	var stored *model.UserToken
+	tokens := &mocks.UserTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, token *model.UserToken) error {
+			stored = token
+			return nil
+		},
+	}
+	outbox := &mocks.EmailSenderMock{}
+	app := setupTestAppWithMail(users, &mocks.AlumniRepositoryMock{}, tokens, outbox, userID)
+
+	assert.Equal(t, 200, send(app, "GET", "/me", nil))
+	assert.Equal(t, 403, send(app, "PUT", "/me", map[string]interface{}{"role": model.RoleAdmin}))
+	assert.Equal(t, 400, send(app, "PUT", "/me", map[string]interface{}{"password": "x"}))
+	assert.Equal(t, 400, send(app, "PUT", "/me", map[string]interface{}{"email": "bukan-email", "current_password": "rahasia123"}))
+
+	// ganti email wajib password saat ini
+	assert.Equal(t, 400, send(app, "PUT", "/me", map[string]interface{}{"email": "baru@example.com"}))
+	assert.Equal(t, 401, send(app, "PUT", "/me", map[string]interface{}{"email": "baru@example.com", "current_password": "salah"}))
+	assert.Equal(t, 409, send(app, "PUT", "/me", map[string]interface{}{"email": "taken@example.com", "current_password": "rahasia123"}))
+	assert.Nil(t, stored)
+	assert.Empty(t, outbox.Outbox)
+
+	// email sama tidak perlu konfirmasi
+	assert.Equal(t, 200, send(app, "PUT", "/me", map[string]interface{}{"email": "budi@example.com"}))
+
+	// username dikirim ulang tanpa perubahan tetap diterima; email lama tetap dipakai sampai link dibuka
+	status := send(app, "PUT", "/me", map[string]interface{}{"username": "budi", "email": " Budi.Baru@Example.com ", "current_password": "rahasia123"})
+	assert.Equal(t, 202, status)
+	if assert.NotNil(t, stored) {
+		assert.Equal(t, model.TokenPurposeEmailChange, stored.Purpose)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "budi.baru@example.com", stored.Email)
+	}
+	if assert.Len(t, outbox.Outbox, 1) {
+		assert.Equal(t, "budi.baru@example.com", outbox.Outbox[0].To)
+		assert.Contains(t, outbox.Outbox[0].Body, "/verify-email?token=")
+	}
+	assert.Equal(t, "budi@example.com", user.Email)
+}
+
+// ===========================
+// PROFILE: GET & PUT /me/alumni
+// ===========================
+func TestUpdateMyAlumni(t *testing.T) {
+	userID := primitive.NewObjectID()
+	alumniID := primitive.NewObjectID()
+	user := &model.User{ID: userID, Username: "budi", Role: model.RoleUser}
+	users := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			return user, nil
+		},
+	}
+	var got *model.UpdateMyAlumniRequest
+	alumni := &mocks.AlumniRepositoryMock{
+		GetByIDFunc: func(id primitive.ObjectID) (*model.Alumni, error) {
+			if id != alumniID {
+				return nil, nil
+			}
+			return &model.Alumni{ID: alumniID, NIM: "2020101234", Angkatan: 2020, Email: "budi@example.com"}, nil
+		},
+		UpdateContactFunc: func(id primitive.ObjectID, req model.UpdateMyAlumniRequest) error {
+			got = &req
+			return nil
+		},
+	}
+	app := setupTestApp(users, alumni, userID)
+
+	// belum terhubung ke data alumni
+	assert.Equal(t, 404, send(app, "GET", "/me/alumni", nil))
+	assert.Equal(t, 404, send(app, "PUT", "/me/alumni", map[string]interface{}{"alamat": "Jl. Baru"}))
+
+	user.AlumniID = &alumniID
+	assert.Equal(t, 200, send(app, "GET", "/me/alumni", nil))
+
+	assert.Equal(t, 403, send(app, "PUT", "/me/alumni", map[string]interface{}{"nim": "2020109999"}))
+	assert.Equal(t, 403, send(app, "PUT", "/me/alumni", map[string]interface{}{"angkatan": 2019, "alamat": "Jl. Baru"}))
+	assert.Equal(t, 400, send(app, "PUT", "/me/alumni", map[string]interface{}{"no_telepon": "telp-saya"}))
+	assert.Nil(t, got)
+
+	status := send(app, "PUT", "/me/alumni", map[string]interface{}{
+		"nim":        "2020101234", // tidak berubah, tetap diterima
+		"angkatan":   2020,
+		"no_telepon": "+62 812-3456-7890",
+		"alamat":     "  Jl. Baru No. 1 ",
+	})
+	assert.Equal(t, 200, status)
+	if assert.NotNil(t, got) {
+		assert.Nil(t, got.Email)
+		assert.Equal(t, "+62 812-3456-7890", *got.NoTelepon)
+		assert.Equal(t, "Jl. Baru No. 1", *got.Alamat)
+	}
+}
@@ -0,0 +1,105 @@
+package role_test
+
+import (
+	"context"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// respons upsert saat role belum ada (baru di-seed)
+func upserted(name string) bson.D {
+	return mtest.CreateSuccessResponse(
+		bson.E{Key: "n", Value: 1},
+		bson.E{Key: "nModified", Value: 0},
+		bson.E{Key: "upserted", Value: bson.A{bson.D{{Key: "index", Value: 0}, {Key: "_id", Value: name}}}},
+	)
+}
+
+// respons upsert saat role sudah ada
+func matched() bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 0})
+}
+
+func roleDoc(r model.Role) bson.D {
+	doc := bson.D{{Key: "_id", Value: r.Name}, {Key: "permissions", Value: r.Permissions}, {Key: "built_in", Value: true}}
+	if r.SeededPermissions != nil {
+		doc = append(doc, bson.E{Key: "seeded_permissions", Value: r.SeededPermissions})
+	}
+	return doc
+}
+
+// ========================== ENSURE DEFAULTS ==========================
+func TestEnsureDefaults_AddsNewPermissionToExistingRole(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("user role lama dapat surveys:respond", func(mt *mtest.T) {
+		// role user versi lama: belum punya seeded_permissions maupun surveys:respond, plus permission tambahan admin
+		legacy := model.Role{Name: model.RoleUser, Permissions: []string{
+			model.PermAlumniRead, model.PermPekerjaanRead, model.PermPekerjaanDeleteOwn,
+			model.PermFilesRead, model.PermFilesUploadOwn, model.PermFilesDeleteOwn,
+			model.PermAlumniClaimsCreate, model.PermAnalyticsRead,
+		}}
+
+		mt.AddMockResponses(
+			upserted(model.RoleAdmin),
+			upserted(model.RoleOperator),
+			matched(),
+			mtest.CreateCursorResponse(0, "db.roles", mtest.FirstBatch, roleDoc(legacy)),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			upserted(model.RoleViewer),
+		)
+
+		err := repository.NewRoleRepository(mt.DB).EnsureDefaults(context.Background())
+		assert.NoError(mt, err)
+
+		var addToSet bson.Raw
+		for _, ev := range mt.GetAllStartedEvents() {
+			if ev.CommandName != "update" {
+				continue
+			}
+			u := ev.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+			if v, err := u.LookupErr("$addToSet"); err == nil {
+				addToSet = v.Document()
+			}
+		}
+		if assert.NotNil(mt, addToSet, "role user harus di-update dengan $addToSet") {
+			var set struct {
+				Permissions struct {
+					Each []string `bson:"$each"`
+				} `bson:"permissions"`
+			}
+			assert.NoError(mt, bson.Unmarshal(addToSet, &set))
+			assert.Contains(mt, set.Permissions.Each, model.PermSurveysRespond)
+		}
+	})
+
+	mt.Run("role yang sudah lengkap tidak diubah", func(mt *mtest.T) {
+		user := model.Role{Name: model.RoleUser, Permissions: []string{model.PermAlumniRead}, SeededPermissions: model.DefaultPermissions(model.RoleUser)}
+
+		mt.AddMockResponses(
+			upserted(model.RoleAdmin),
+			upserted(model.RoleOperator),
+			matched(),
+			mtest.CreateCursorResponse(0, "db.roles", mtest.FirstBatch, roleDoc(user)),
+			upserted(model.RoleViewer),
+		)
+
+		err := repository.NewRoleRepository(mt.DB).EnsureDefaults(context.Background())
+		assert.NoError(mt, err)
+
+		// 4 upsert + 1 find, tanpa $addToSet: permission yang dicabut admin tidak dikembalikan
+		updates := 0
+		for _, ev := range mt.GetAllStartedEvents() {
+			if ev.CommandName == "update" {
+				updates++
+			}
+		}
+		assert.Equal(mt, 4, updates)
+	})
+}
@@ -0,0 +1,150 @@
+package role_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/middleware"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestApp(roles *mocks.RoleRepositoryMock, users *mocks.UserRepositoryMock) *fiber.App {
+	app := fiber.New()
+	s := service.NewRoleService(roles, users)
+
+	app.Get("/permissions", s.GetPermissions)
+	app.Get("/roles", s.GetAll)
+	app.Post("/roles", s.Create)
+	app.Put("/roles/:name", s.Update)
+	app.Delete("/roles/:name", s.Delete)
+	return app
+}
+
+func send(app *fiber.App, method, url string, v interface{}) int {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+// ===========================
+// ROLE: TAMBAH ROLE
+// ===========================
+func TestCreateRole(t *testing.T) {
+	var created *model.Role
+	roles := &mocks.RoleRepositoryMock{
+		CreateFunc: func(ctx context.Context, role *model.Role) error {
+			if role.Name == "operator" {
+				return repository.ErrRoleExists
+			}
+			created = role
+			return nil
+		},
+	}
+	app := setupTestApp(roles, &mocks.UserRepositoryMock{})
+
+	assert.Equal(t, 400, send(app, "POST", "/roles", model.RoleRequest{Name: "Bad Name!"}))
+	assert.Equal(t, 400, send(app, "POST", "/roles", model.RoleRequest{Name: "dosen", Permissions: []string{"alumni:fly"}}))
+	assert.Equal(t, 409, send(app, "POST", "/roles", model.RoleRequest{Name: "operator"}))
+
+	status := send(app, "POST", "/roles", model.RoleRequest{
+		Name:        " Dosen ",
+		Permissions: []string{model.PermAlumniRead, model.PermAlumniRead, model.PermPekerjaanRead},
+	})
+	assert.Equal(t, 201, status)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, "dosen", created.Name)
+		assert.Equal(t, []string{model.PermAlumniRead, model.PermPekerjaanRead}, created.Permissions)
+		assert.False(t, created.BuiltIn)
+	}
+}
+
+// ===========================
+// ROLE: UPDATE & HAPUS
+// ===========================
+func TestUpdateAndDeleteRole(t *testing.T) {
+	roles := &mocks.RoleRepositoryMock{
+		FindByNameFunc: func(ctx context.Context, name string) (*model.Role, error) {
+			switch name {
+			case "dosen", "kaprodi":
+				return &model.Role{Name: name}, nil
+			case model.RoleViewer:
+				return &model.Role{Name: name, BuiltIn: true}, nil
+			}
+			return nil, nil
+		},
+		UpdateFunc: func(ctx context.Context, name, description string, permissions []string) error {
+			if name != "dosen" {
+				return repository.ErrRoleNotFound
+			}
+			return nil
+		},
+	}
+	users := &mocks.UserRepositoryMock{
+		CountByRoleFunc: func(ctx context.Context, role string) (int, error) {
+			if role == "kaprodi" {
+				return 2, nil
+			}
+			return 0, nil
+		},
+	}
+	app := setupTestApp(roles, users)
+
+	assert.Equal(t, 403, send(app, "PUT", "/roles/admin", model.RoleRequest{}))
+	assert.Equal(t, 400, send(app, "PUT", "/roles/dosen", model.RoleRequest{Permissions: []string{"x"}}))
+	assert.Equal(t, 404, send(app, "PUT", "/roles/nope", model.RoleRequest{}))
+	assert.Equal(t, 200, send(app, "PUT", "/roles/dosen", model.RoleRequest{Permissions: []string{model.PermAlumniWrite}}))
+
+	assert.Equal(t, 404, send(app, "DELETE", "/roles/nope", nil))
+	assert.Equal(t, 403, send(app, "DELETE", "/roles/viewer", nil))
+	assert.Equal(t, 409, send(app, "DELETE", "/roles/kaprodi", nil))
+	assert.Equal(t, 200, send(app, "DELETE", "/roles/dosen", nil))
+}
+
+// ===========================
+// MIDDLEWARE: REQUIRE / REQUIRE ANY
+// ===========================
+func TestRequireMiddleware(t *testing.T) {
+	newApp := func(role string) *fiber.App {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("permissions", model.DefaultPermissions(role))
+			return c.Next()
+		})
+		ok := func(c *fiber.Ctx) error { return c.SendStatus(200) }
+		app.Post("/alumni", middleware.Require(model.PermAlumniWrite), ok)
+		app.Delete("/alumni/hard", middleware.Require(model.PermAlumniRestore, model.PermAlumniHardDelete), ok)
+		app.Delete("/pekerjaan", middleware.RequireAny(model.PermPekerjaanDelete, model.PermPekerjaanDeleteOwn), ok)
+		return app
+	}
+
+	cases := []struct {
+		role                           string
+		alumniWrite, hardDelete, delPk int
+	}{
+		{model.RoleAdmin, 200, 200, 200},
+		{model.RoleOperator, 200, 403, 200},
+		{model.RoleUser, 403, 403, 200},
+		{model.RoleViewer, 403, 403, 403},
+		{"unknown", 403, 403, 403},
+	}
+	for _, tc := range cases {
+		app := newApp(tc.role)
+		assert.Equal(t, tc.alumniWrite, send(app, "POST", "/alumni", nil), tc.role)
+		assert.Equal(t, tc.hardDelete, send(app, "DELETE", "/alumni/hard", nil), tc.role)
+		assert.Equal(t, tc.delPk, send(app, "DELETE", "/pekerjaan", nil), tc.role)
+	}
+}
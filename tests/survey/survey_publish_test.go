@@ -0,0 +1,126 @@
+package survey_test
+
+import (
+	"context"
+	"testing"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// ensureIndexes membuat tiga index saat repository dibuat
+func newSurveyRepo(mt *mtest.T) repository.SurveyRepository {
+	mt.AddMockResponses(
+		mtest.CreateSuccessResponse(),
+		mtest.CreateSuccessResponse(),
+		mtest.CreateSuccessResponse(),
+	)
+	return repository.NewSurveyRepository(mt.DB)
+}
+
+// updateStatuses: nilai status pada setiap perintah update, berurutan
+func updateStatuses(mt *mtest.T) []string {
+	var statuses []string
+	for _, ev := range mt.GetAllStartedEvents() {
+		if ev.CommandName != "update" {
+			continue
+		}
+		set := ev.Command.Lookup("updates").Array().Index(0).Value().Document().
+			Lookup("u").Document().Lookup("$set").Document()
+		statuses = append(statuses, set.Lookup("status").StringValue())
+	}
+	return statuses
+}
+
+func TestPublish_ClosesOtherVersionsFirst(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ns := func(mt *mtest.T) string { return mt.DB.Name() + ".surveys" }
+	draftCount := func(mt *mtest.T) bson.D {
+		return mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch, bson.D{{Key: "n", Value: 1}})
+	}
+	updated := mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1})
+
+	mt.Run("tutup versi lama lalu terbitkan draft", func(mt *mtest.T) {
+		repo := newSurveyRepo(mt)
+		mt.ClearEvents()
+		mt.AddMockResponses(
+			draftCount(mt),
+			mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch, bson.D{{Key: "_id", Value: primitive.NewObjectID()}}),
+			updated,
+			updated,
+		)
+
+		assert.NoError(mt, repo.Publish(context.Background(), primitive.NewObjectID(), "TS-2024"))
+		assert.Equal(mt, []string{model.SurveyStatusClosed, model.SurveyStatusPublished}, updateStatuses(mt))
+	})
+
+	mt.Run("belum ada versi terbit", func(mt *mtest.T) {
+		repo := newSurveyRepo(mt)
+		mt.ClearEvents()
+		mt.AddMockResponses(draftCount(mt), mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch), updated)
+
+		assert.NoError(mt, repo.Publish(context.Background(), primitive.NewObjectID(), "TS-2024"))
+		assert.Equal(mt, []string{model.SurveyStatusPublished}, updateStatuses(mt))
+	})
+
+	mt.Run("bukan draft tidak menutup versi lain", func(mt *mtest.T) {
+		repo := newSurveyRepo(mt)
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch))
+
+		err := repo.Publish(context.Background(), primitive.NewObjectID(), "TS-2024")
+		assert.ErrorIs(mt, err, repository.ErrSurveyNotDraft)
+		assert.Empty(mt, updateStatuses(mt))
+	})
+
+	mt.Run("publish gagal membuka kembali versi lama", func(mt *mtest.T) {
+		repo := newSurveyRepo(mt)
+		prevID := primitive.NewObjectID()
+		mt.ClearEvents()
+		mt.AddMockResponses(
+			draftCount(mt),
+			mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch, bson.D{{Key: "_id", Value: prevID}}),
+			updated,
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key"}),
+			updated,
+		)
+
+		err := repo.Publish(context.Background(), primitive.NewObjectID(), "TS-2024")
+		assert.ErrorIs(mt, err, repository.ErrSurveyPublishConflict)
+		assert.Equal(mt, []string{model.SurveyStatusClosed, model.SurveyStatusPublished, model.SurveyStatusPublished}, updateStatuses(mt))
+
+		// versi yang dibuka lagi adalah versi yang tadi ditutup, hanya jika masih tertutup oleh publish ini
+		var reopen bson.Raw
+		for _, ev := range mt.GetAllStartedEvents() {
+			if ev.CommandName == "update" {
+				reopen = ev.Command.Lookup("updates").Array().Index(0).Value().Document()
+			}
+		}
+		q := reopen.Lookup("q").Document()
+		assert.Equal(mt, prevID, q.Lookup("_id").ObjectID())
+		assert.Equal(mt, model.SurveyStatusClosed, q.Lookup("status").StringValue())
+		_, hasClosedAt := q.Lookup("closed_at").DateTimeOK()
+		assert.True(mt, hasClosedAt)
+	})
+
+	mt.Run("draft sudah diterbitkan request lain", func(mt *mtest.T) {
+		repo := newSurveyRepo(mt)
+		mt.ClearEvents()
+		mt.AddMockResponses(
+			draftCount(mt),
+			mtest.CreateCursorResponse(0, ns(mt), mtest.FirstBatch, bson.D{{Key: "_id", Value: primitive.NewObjectID()}}),
+			updated,
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+			updated,
+		)
+
+		err := repo.Publish(context.Background(), primitive.NewObjectID(), "TS-2024")
+		assert.ErrorIs(mt, err, repository.ErrSurveyNotDraft)
+		assert.Len(mt, updateStatuses(mt), 3)
+	})
+}
@@ -0,0 +1,391 @@
+package survey_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupTestApp(repo *mocks.SurveyRepositoryMock, users *mocks.UserRepositoryMock, role string) *fiber.App {
+	app := fiber.New()
+	s := service.NewSurveyService(repo, users)
+
+	userID := primitive.NewObjectID()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &model.JWTClaims{UserID: userID.Hex(), Role: role})
+		c.Locals("role", role)
+		return c.Next()
+	})
+	app.Post("/surveys", s.Create)
+	app.Get("/surveys", s.GetAll)
+	app.Get("/surveys/active", s.GetActive)
+	app.Get("/surveys/:id", s.GetByID)
+	app.Put("/surveys/:id", s.Update)
+	app.Post("/surveys/:id/versions", s.NewVersion)
+	app.Post("/surveys/:id/publish", s.Publish)
+	app.Post("/surveys/:id/close", s.Close)
+	app.Get("/surveys/:id/responses", s.ExportResponses)
+	app.Post("/surveys/:id/responses", s.Submit)
+	app.Get("/surveys/:id/responses/me", s.GetMyResponse)
+	app.Get("/surveys/:id/aggregates", s.Aggregates)
+	return app
+}
+
+func send(app *fiber.App, method, url string, v interface{}) (*http.Response, map[string]interface{}) {
+	var body bytes.Buffer
+	if v != nil {
+		_ = json.NewEncoder(&body).Encode(v)
+	}
+	req := httptest.NewRequest(method, url, &body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	var out map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp, out
+}
+
+func sampleSurvey(status string) *model.Survey {
+	return &model.Survey{
+		ID:      primitive.NewObjectID(),
+		Code:    "tracer-2025",
+		Version: 2,
+		Title:   "Tracer Study 2025",
+		Status:  status,
+		Questions: []model.SurveyQuestion{
+			{ID: "status", Text: "Status saat ini", Type: model.QuestionSingleChoice, Required: true, Options: []string{"Bekerja", "Wirausaha", "Studi lanjut"}},
+			{ID: "skill", Text: "Skill yang dipakai", Type: model.QuestionMultiChoice, Options: []string{"Go", "SQL", "Cloud"}},
+			{ID: "relevansi", Text: "Relevansi kuliah", Type: model.QuestionScale, Required: true, ScaleMin: 1, ScaleMax: 5},
+			{ID: "saran", Text: "Saran", Type: model.QuestionText},
+		},
+	}
+}
+
+func surveyRepo(s *model.Survey) *mocks.SurveyRepositoryMock {
+	return &mocks.SurveyRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.Survey, error) {
+			if id == s.ID {
+				return s, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+func linkedUsers(alumniID *primitive.ObjectID) *mocks.UserRepositoryMock {
+	return &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: id, AlumniID: alumniID}, nil
+		},
+	}
+}
+
+// ========================== CREATE ==========================
+func TestCreate_Valid(t *testing.T) {
+	var created *model.Survey
+	repo := &mocks.SurveyRepositoryMock{
+		CreateFunc: func(ctx context.Context, s *model.Survey) error {
+			created = s
+			return nil
+		},
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := send(app, "POST", "/surveys", map[string]interface{}{
+		"code":  " Tracer-2025 ",
+		"title": "Tracer Study 2025",
+		"questions": []map[string]interface{}{
+			{"id": "q1", "text": "Status", "type": "single_choice", "required": true, "options": []string{"A", "B"}},
+			{"id": "q2", "text": "Kepuasan", "type": "scale", "scale_min": 1, "scale_max": 5},
+		},
+	})
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "tracer-2025", created.Code)
+	assert.Equal(t, 1, created.Version)
+	assert.Len(t, created.Questions, 2)
+}
+
+func TestCreate_Invalid(t *testing.T) {
+	app := setupTestApp(&mocks.SurveyRepositoryMock{}, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, body := send(app, "POST", "/surveys", map[string]interface{}{
+		"code": "x",
+		"questions": []map[string]interface{}{
+			{"id": "q1", "text": "Pilih", "type": "single_choice", "options": []string{"A"}},
+			{"id": "q1", "text": "", "type": "scale", "scale_min": 5, "scale_max": 1},
+			{"id": "q3", "text": "Apa?", "type": "essay"},
+		},
+	})
+	assert.Equal(t, 422, resp.StatusCode)
+
+	errs := body["errors"].(map[string]interface{})
+	for _, key := range []string{"code", "title", "questions[0].options", "questions[1].id", "questions[1].text", "questions[1].scale_max", "questions[2].type"} {
+		assert.Contains(t, errs, key)
+	}
+}
+
+func TestCreate_DuplicateCode(t *testing.T) {
+	repo := &mocks.SurveyRepositoryMock{
+		CreateFunc: func(ctx context.Context, s *model.Survey) error {
+			return repository.ErrSurveyExists
+		},
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := send(app, "POST", "/surveys", map[string]interface{}{
+		"code":      "tracer-2025",
+		"title":     "Tracer",
+		"questions": []map[string]interface{}{{"id": "q1", "text": "Saran", "type": "text"}},
+	})
+	assert.Equal(t, 409, resp.StatusCode)
+}
+
+// ========================== VERSIONING ==========================
+func TestUpdate_PublishedConflict(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	repo := surveyRepo(s)
+	repo.UpdateDraftFunc = func(ctx context.Context, id primitive.ObjectID, req model.SurveyRequest) error {
+		return repository.ErrSurveyNotDraft
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := send(app, "PUT", "/surveys/"+s.ID.Hex(), map[string]interface{}{
+		"title":     "Baru",
+		"questions": []map[string]interface{}{{"id": "q1", "text": "Saran", "type": "text"}},
+	})
+	assert.Equal(t, 409, resp.StatusCode)
+}
+
+func TestNewVersion_CopiesQuestions(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	repo := surveyRepo(s)
+	repo.LatestVersionFunc = func(ctx context.Context, code string) (int, error) {
+		assert.Equal(t, "tracer-2025", code)
+		return 3, nil
+	}
+	var created *model.Survey
+	repo.CreateFunc = func(ctx context.Context, sv *model.Survey) error {
+		created = sv
+		return nil
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := send(app, "POST", "/surveys/"+s.ID.Hex()+"/versions", nil)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, 4, created.Version)
+	assert.Equal(t, s.Code, created.Code)
+	assert.Equal(t, s.Questions, created.Questions)
+}
+
+func TestPublishAndClose(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusDraft)
+	repo := surveyRepo(s)
+	var publishedCode string
+	repo.PublishFunc = func(ctx context.Context, id primitive.ObjectID, code string) error {
+		publishedCode = code
+		return nil
+	}
+	repo.CloseFunc = func(ctx context.Context, id primitive.ObjectID) (bool, error) {
+		return false, nil
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := send(app, "POST", "/surveys/"+s.ID.Hex()+"/publish", nil)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, s.Code, publishedCode)
+
+	resp, _ = send(app, "POST", "/surveys/"+s.ID.Hex()+"/close", nil)
+	assert.Equal(t, 409, resp.StatusCode)
+}
+
+func TestPublish_ConcurrentConflict(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusDraft)
+	repo := surveyRepo(s)
+	repo.PublishFunc = func(ctx context.Context, id primitive.ObjectID, code string) error {
+		return repository.ErrSurveyPublishConflict
+	}
+
+	resp, _ := send(setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin), "POST", "/surveys/"+s.ID.Hex()+"/publish", nil)
+	assert.Equal(t, 409, resp.StatusCode)
+}
+
+func TestGetByID_DraftHiddenFromAlumni(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusDraft)
+
+	resp, _ := send(setupTestApp(surveyRepo(s), &mocks.UserRepositoryMock{}, model.RoleUser), "GET", "/surveys/"+s.ID.Hex(), nil)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	resp, _ = send(setupTestApp(surveyRepo(s), &mocks.UserRepositoryMock{}, model.RoleAdmin), "GET", "/surveys/"+s.ID.Hex(), nil)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+// ========================== SUBMIT ==========================
+func TestSubmit_Valid(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	alumniID := primitive.NewObjectID()
+	repo := surveyRepo(s)
+	var saved *model.SurveyResponse
+	repo.CreateResponseFunc = func(ctx context.Context, r *model.SurveyResponse) error {
+		saved = r
+		return nil
+	}
+	app := setupTestApp(repo, linkedUsers(&alumniID), model.RoleUser)
+
+	resp, _ := send(app, "POST", "/surveys/"+s.ID.Hex()+"/responses", map[string]interface{}{
+		"answers": []map[string]interface{}{
+			{"question_id": "relevansi", "scale": 4},
+			{"question_id": "status", "choices": []string{"Bekerja"}},
+			{"question_id": "skill", "choices": []string{}},
+			{"question_id": "saran", "text": "  Tambah magang  "},
+		},
+	})
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, alumniID, saved.AlumniID)
+	assert.Equal(t, 2, saved.SurveyVersion)
+
+	// urut sesuai kuesioner, jawaban kosong opsional dibuang
+	assert.Len(t, saved.Answers, 3)
+	assert.Equal(t, "status", saved.Answers[0].QuestionID)
+	assert.Equal(t, "relevansi", saved.Answers[1].QuestionID)
+	assert.Equal(t, "Tambah magang", saved.Answers[2].Text)
+}
+
+func TestSubmit_InvalidAnswers(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	alumniID := primitive.NewObjectID()
+	app := setupTestApp(surveyRepo(s), linkedUsers(&alumniID), model.RoleUser)
+
+	resp, body := send(app, "POST", "/surveys/"+s.ID.Hex()+"/responses", map[string]interface{}{
+		"answers": []map[string]interface{}{
+			{"question_id": "status", "choices": []string{"Bekerja", "Wirausaha"}},
+			{"question_id": "skill", "choices": []string{"Rust"}},
+			{"question_id": "umur", "text": "25"},
+		},
+	})
+	assert.Equal(t, 422, resp.StatusCode)
+
+	errs := body["errors"].(map[string]interface{})
+	for _, key := range []string{"answers.status", "answers.skill", "answers.relevansi", "answers.umur"} {
+		assert.Contains(t, errs, key)
+	}
+}
+
+func TestSubmit_Rules(t *testing.T) {
+	alumniID := primitive.NewObjectID()
+	answers := map[string]interface{}{"answers": []map[string]interface{}{
+		{"question_id": "status", "choices": []string{"Bekerja"}},
+		{"question_id": "relevansi", "scale": 3},
+	}}
+
+	// kuesioner sudah ditutup
+	closed := sampleSurvey(model.SurveyStatusClosed)
+	resp, _ := send(setupTestApp(surveyRepo(closed), linkedUsers(&alumniID), model.RoleUser), "POST", "/surveys/"+closed.ID.Hex()+"/responses", answers)
+	assert.Equal(t, 409, resp.StatusCode)
+
+	// akun belum terhubung ke alumni
+	s := sampleSurvey(model.SurveyStatusPublished)
+	resp, _ = send(setupTestApp(surveyRepo(s), linkedUsers(nil), model.RoleUser), "POST", "/surveys/"+s.ID.Hex()+"/responses", answers)
+	assert.Equal(t, 403, resp.StatusCode)
+
+	// sudah pernah mengisi
+	repo := surveyRepo(s)
+	repo.CreateResponseFunc = func(ctx context.Context, r *model.SurveyResponse) error {
+		return repository.ErrAlreadyResponded
+	}
+	resp, _ = send(setupTestApp(repo, linkedUsers(&alumniID), model.RoleUser), "POST", "/surveys/"+s.ID.Hex()+"/responses", answers)
+	assert.Equal(t, 409, resp.StatusCode)
+}
+
+// ========================== EXPORT & AGGREGATES ==========================
+func TestExport_CSV(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusClosed)
+	repo := surveyRepo(s)
+	scale := 5
+	repo.FindResponsesFunc = func(ctx context.Context, surveyID primitive.ObjectID) ([]model.SurveyResponse, error) {
+		return []model.SurveyResponse{{
+			ID:          primitive.NewObjectID(),
+			SurveyID:    s.ID,
+			AlumniID:    primitive.NewObjectID(),
+			SubmittedAt: time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC),
+			Answers: []model.SurveyAnswer{
+				{QuestionID: "status", Choices: []string{"Bekerja"}},
+				{QuestionID: "skill", Choices: []string{"Go", "SQL"}},
+				{QuestionID: "relevansi", Scale: &scale},
+			},
+		}}, nil
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/surveys/"+s.ID.Hex()+"/responses?format=csv", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "survey-tracer-2025-v2.csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"response_id", "alumni_id", "user_id", "submitted_at", "status", "skill", "relevansi", "saran"}, rows[0])
+	assert.Equal(t, []string{"2025-07-01T08:00:00Z", "Bekerja", "Go; SQL", "5", ""}, rows[1][3:])
+}
+
+func TestExport_InvalidFormat(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	resp, _ := send(setupTestApp(surveyRepo(s), &mocks.UserRepositoryMock{}, model.RoleAdmin), "GET", "/surveys/"+s.ID.Hex()+"/responses?format=xlsx", nil)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestAggregates(t *testing.T) {
+	s := sampleSurvey(model.SurveyStatusPublished)
+	repo := surveyRepo(s)
+	repo.StatsFunc = func(ctx context.Context, surveyID primitive.ObjectID) (model.SurveyStats, error) {
+		return model.SurveyStats{
+			TotalResponses: 4,
+			Answered:       map[string]int{"status": 4, "relevansi": 4, "saran": 1},
+			Choices:        map[string]map[string]int{"status": {"Bekerja": 3, "Studi lanjut": 1}},
+			Scales:         map[string]map[int]int{"relevansi": {4: 2, 5: 2}},
+		}, nil
+	}
+	app := setupTestApp(repo, &mocks.UserRepositoryMock{}, model.RoleAdmin)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/surveys/"+s.ID.Hex()+"/aggregates", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var body struct {
+		Data struct {
+			TotalResponses int                       `json:"total_responses"`
+			Questions      []model.QuestionAggregate `json:"questions"`
+		} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, 4, body.Data.TotalResponses)
+	assert.Len(t, body.Data.Questions, 4)
+
+	status := body.Data.Questions[0]
+	assert.Equal(t, []model.OptionCount{
+		{Value: "Bekerja", Count: 3, Persentase: 75},
+		{Value: "Wirausaha", Count: 0, Persentase: 0},
+		{Value: "Studi lanjut", Count: 1, Persentase: 25},
+	}, status.Options)
+
+	relevansi := body.Data.Questions[2]
+	assert.Len(t, relevansi.Options, 5)
+	assert.Equal(t, 4.5, *relevansi.Average)
+
+	saran := body.Data.Questions[3]
+	assert.Equal(t, 1, saran.Answered)
+	assert.Nil(t, saran.Options)
+}
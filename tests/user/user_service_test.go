@@ -0,0 +1,366 @@
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"praktikum3/app/model"
+	"praktikum3/app/repository"
+	"praktikum3/app/service"
+	"praktikum3/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var adminID = primitive.NewObjectID()
+
+// ========================== SETUP APP ==========================
+func setupTestApp(repo *mocks.UserRepositoryMock) *fiber.App {
+	app := fiber.New()
+	s := service.NewUserService(repo, &mocks.RoleRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{},
+		&mocks.TokenRevocationRepositoryMock{}, &mocks.SessionRepositoryMock{})
+
+	// Bypass auth middleware
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", map[string]interface{}{
+			"id":       adminID.Hex(),
+			"username": "admin",
+			"role":     "admin",
+		})
+		c.Locals("role", "admin")
+		return c.Next()
+	})
+
+	app.Get("/users/trash", s.GetTrashed)
+	app.Put("/users/restore/:id", s.Restore)
+	app.Delete("/users/hard/:id", s.HardDelete)
+
+	app.Get("/users", s.GetAll)
+	app.Post("/users", s.Create)
+	app.Get("/users/:id", s.GetByID)
+	app.Put("/users/:id/role", s.UpdateRole)
+	app.Delete("/users/:id", s.SoftDelete)
+
+	return app
+}
+
+func jsonRequest(method, url string, v interface{}) *http.Request {
+	body, _ := json.Marshal(v)
+	req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// ==============================================================
+//
+//	GET ALL
+//
+// ==============================================================
+func TestGetAll_PaginationMeta(t *testing.T) {
+	repo := &mocks.UserRepositoryMock{
+		FindAllFunc: func(ctx context.Context, search string, limit, offset int) ([]model.User, error) {
+			assert.Equal(t, "budi", search)
+			assert.Equal(t, 5, limit)
+			assert.Equal(t, 5, offset)
+			return []model.User{{Username: "budi"}}, nil
+		},
+		CountFunc: func(ctx context.Context, search string) (int, error) {
+			return 11, nil
+		},
+	}
+
+	app := setupTestApp(repo)
+	resp, _ := app.Test(httptest.NewRequest("GET", "/users?search=budi&page=2&limit=5", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var out struct {
+		Meta model.MetaInfo `json:"meta"`
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(raw, &out)
+	assert.Equal(t, 11, out.Meta.Total)
+	assert.Equal(t, 3, out.Meta.Pages)
+	assert.Equal(t, 2, out.Meta.Page)
+}
+
+func TestGetAll_Error(t *testing.T) {
+	repo := &mocks.UserRepositoryMock{
+		FindAllFunc: func(ctx context.Context, search string, limit, offset int) ([]model.User, error) {
+			return nil, errors.New("db error")
+		},
+	}
+	resp, _ := setupTestApp(repo).Test(httptest.NewRequest("GET", "/users", nil))
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+// ==============================================================
+//
+//	GET BY ID
+//
+// ==============================================================
+func TestGetByID(t *testing.T) {
+	id := primitive.NewObjectID()
+	repo := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			if oid == id {
+				return &model.User{ID: id, Username: "budi"}, nil
+			}
+			return nil, nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/users/"+id.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/users/"+primitive.NewObjectID().Hex(), nil))
+	assert.Equal(t, 404, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/users/bukan-id", nil))
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+// ==============================================================
+//
+//	CREATE
+//
+// ==============================================================
+func TestCreate(t *testing.T) {
+	var created *model.User
+	repo := &mocks.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, user *model.User) error {
+			if user.Username == "dupe" {
+				return repository.ErrUserExists
+			}
+			created = user
+			return nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	resp, _ := app.Test(jsonRequest("POST", "/users", model.CreateUserRequest{Username: "budi", Email: "budi@mail.com", Password: "password123", Role: "admin"}))
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "admin", created.Role)
+	assert.Equal(t, model.UserStatusActive, created.Status)
+
+	resp, _ = app.Test(jsonRequest("POST", "/users", model.CreateUserRequest{Username: "dupe", Email: "d@mail.com", Password: "password123"}))
+	assert.Equal(t, 409, resp.StatusCode)
+
+	resp, _ = app.Test(jsonRequest("POST", "/users", model.CreateUserRequest{Username: "budi", Email: "budi@mail.com", Password: "password123", Role: "superuser"}))
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+// ==============================================================
+//
+//	UPDATE ROLE
+//
+// ==============================================================
+func TestUpdateRole(t *testing.T) {
+	id := primitive.NewObjectID()
+	var newRole string
+	repo := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: oid}, nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, oid primitive.ObjectID, role string) error {
+			newRole = role
+			return nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	resp, _ := app.Test(jsonRequest("PUT", "/users/"+id.Hex()+"/role", model.UpdateRoleRequest{Role: "admin"}))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "admin", newRole)
+
+	resp, _ = app.Test(jsonRequest("PUT", "/users/"+id.Hex()+"/role", model.UpdateRoleRequest{Role: "root"}))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	// admin tidak bisa menurunkan role dirinya sendiri
+	resp, _ = app.Test(jsonRequest("PUT", "/users/"+adminID.Hex()+"/role", model.UpdateRoleRequest{Role: "user"}))
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestUpdateRole_RevokesTokensAndSessions(t *testing.T) {
+	id := primitive.NewObjectID()
+	var refreshRevoked, sessionsEnded []primitive.ObjectID
+	var notBefore time.Time
+	repo := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: oid, Role: model.RoleAdmin}, nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, oid primitive.ObjectID, role string) error {
+			return nil
+		},
+	}
+	refresh := &mocks.RefreshTokenRepositoryMock{
+		RevokeByUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			refreshRevoked = append(refreshRevoked, userID)
+			return nil
+		},
+	}
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID, nb time.Time) error {
+			notBefore = nb
+			return nil
+		},
+	}
+	sessions := &mocks.SessionRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			sessionsEnded = append(sessionsEnded, userID)
+			return nil
+		},
+	}
+	app := fiber.New()
+	s := service.NewUserService(repo, &mocks.RoleRepositoryMock{}, refresh, revocations, sessions)
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", map[string]interface{}{"id": adminID.Hex()})
+		return c.Next()
+	})
+	app.Put("/users/:id/role", s.UpdateRole)
+
+	// role sama: tidak ada yang dicabut
+	resp, _ := app.Test(jsonRequest("PUT", "/users/"+id.Hex()+"/role", model.UpdateRoleRequest{Role: model.RoleAdmin}))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Empty(t, refreshRevoked)
+
+	// admin diturunkan: token & sesi lama langsung tidak berlaku
+	before := time.Now()
+	resp, _ = app.Test(jsonRequest("PUT", "/users/"+id.Hex()+"/role", model.UpdateRoleRequest{Role: model.RoleUser}))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []primitive.ObjectID{id}, refreshRevoked)
+	assert.Equal(t, []primitive.ObjectID{id}, sessionsEnded)
+	assert.False(t, notBefore.Before(before), "cutoff harus setelah token yang sudah terbit")
+}
+
+// ==============================================================
+//
+//	SOFT DELETE / TRASH / RESTORE / HARD
+//
+// ==============================================================
+func TestSoftDelete(t *testing.T) {
+	id := primitive.NewObjectID()
+	deleted := false
+	repo := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: oid}, nil
+		},
+		SoftDeleteUserFunc: func(ctx context.Context, oid primitive.ObjectID) error {
+			deleted = oid == id
+			return nil
+		},
+	}
+	app := setupTestApp(repo)
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/users/"+id.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, deleted)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/users/"+adminID.Hex(), nil))
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestSoftDelete_RevokesTokensAndSessions(t *testing.T) {
+	id := primitive.NewObjectID()
+	var refreshRevoked, sessionsEnded primitive.ObjectID
+	var notBefore time.Time
+	repo := &mocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: oid}, nil
+		},
+		SoftDeleteUserFunc: func(ctx context.Context, oid primitive.ObjectID) error {
+			return nil
+		},
+	}
+	refresh := &mocks.RefreshTokenRepositoryMock{
+		RevokeByUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			refreshRevoked = userID
+			return nil
+		},
+	}
+	revocations := &mocks.TokenRevocationRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID, nb time.Time) error {
+			notBefore = nb
+			return nil
+		},
+	}
+	sessions := &mocks.SessionRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID primitive.ObjectID) error {
+			sessionsEnded = userID
+			return nil
+		},
+	}
+
+	newApp := func(perms []string) *fiber.App {
+		app := fiber.New()
+		s := service.NewUserService(repo, &mocks.RoleRepositoryMock{}, refresh, revocations, sessions)
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("user", map[string]interface{}{"id": adminID.Hex()})
+			c.Locals("permissions", perms)
+			return c.Next()
+		})
+		app.Delete("/users/:id", s.SoftDelete)
+		return app
+	}
+
+	before := time.Now()
+	resp, _ := newApp([]string{model.PermUsersManage}).Test(httptest.NewRequest("DELETE", "/users/"+id.Hex(), nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, id, refreshRevoked)
+	assert.Equal(t, id, sessionsEnded)
+	assert.False(t, notBefore.Before(before), "cutoff harus setelah token yang sudah terbit")
+
+	// tanpa users:manage -> 403, bukan 500
+	resp, _ = newApp([]string{model.PermAlumniRead}).Test(httptest.NewRequest("DELETE", "/users/"+id.Hex(), nil))
+	assert.Equal(t, 403, resp.StatusCode)
+}
+
+func TestRestoreAndHardDelete_NotInTrash(t *testing.T) {
+	app := setupTestApp(&mocks.UserRepositoryMock{})
+	id := primitive.NewObjectID().Hex()
+
+	resp, _ := app.Test(httptest.NewRequest("PUT", "/users/restore/"+id, nil))
+	assert.Equal(t, 404, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/users/hard/"+id, nil))
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestRestoreAndHardDelete_Success(t *testing.T) {
+	restored, hardDeleted := false, false
+	repo := &mocks.UserRepositoryMock{
+		FindTrashedByIDFunc: func(ctx context.Context, oid primitive.ObjectID) (*model.User, error) {
+			return &model.User{ID: oid}, nil
+		},
+		RestoreFunc: func(ctx context.Context, oid primitive.ObjectID) error {
+			restored = true
+			return nil
+		},
+		HardDeleteFunc: func(ctx context.Context, oid primitive.ObjectID) error {
+			hardDeleted = true
+			return nil
+		},
+	}
+	app := setupTestApp(repo)
+	id := primitive.NewObjectID().Hex()
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/users/trash", nil))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("PUT", "/users/restore/"+id, nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, restored)
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/users/hard/"+id, nil))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, hardDeleted)
+}